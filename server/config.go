@@ -159,6 +159,12 @@ func ValidateConfig(logger *zap.Logger, c Config) map[string]string {
 	if c.GetSession().SingleParty && !c.GetSession().SingleSocket {
 		logger.Fatal("Single party cannot be enabled without single socket", zap.Strings("param", []string{"session.single_party", "session.single_socket"}))
 	}
+	if c.GetSession().Require2FAGraceLogins < 0 {
+		logger.Fatal("Require 2FA grace logins must be >= 0", zap.Int("session.require_2fa_grace_logins", c.GetSession().Require2FAGraceLogins))
+	}
+	if c.GetSession().Require2FATimeoutMs < 1 {
+		logger.Fatal("Require 2FA timeout milliseconds must be > 0", zap.Int("session.require_2fa_timeout_ms", c.GetSession().Require2FATimeoutMs))
+	}
 	if c.GetRuntime().HTTPKey == "" {
 		logger.Fatal("Runtime HTTP key must be set", zap.String("param", "runtime.http_key"))
 	}
@@ -264,6 +270,32 @@ func ValidateConfig(logger *zap.Logger, c Config) map[string]string {
 	if c.GetMatch().LabelUpdateIntervalMs < 1 {
 		logger.Fatal("Match label update interval milliseconds must be > 0", zap.Int("match.label_update_interval_ms", c.GetMatch().LabelUpdateIntervalMs))
 	}
+	if c.GetMatch().BroadcasterJoinTimeoutSec < 1 {
+		logger.Fatal("Match broadcaster join timeout seconds must be > 0", zap.Int("match.broadcaster_join_timeout_sec", c.GetMatch().BroadcasterJoinTimeoutSec))
+	}
+	for mode, rounds := range c.GetMatch().MaxRoundsByMode {
+		if rounds < 0 {
+			logger.Fatal("Match max rounds must be >= 0", zap.String("mode", mode), zap.Int("match.max_rounds_by_mode", rounds))
+		}
+	}
+	if c.GetMatch().CheckServerPortStart < 1024 || c.GetMatch().CheckServerPortEnd > 65535 || c.GetMatch().CheckServerPortStart > c.GetMatch().CheckServerPortEnd {
+		logger.Fatal("Match check server ports must be a range within 1024-65535", zap.Int("match.check_server_port_start", c.GetMatch().CheckServerPortStart), zap.Int("match.check_server_port_end", c.GetMatch().CheckServerPortEnd))
+	}
+	if c.GetMatch().CheckServerMaxPortRange < 1 {
+		logger.Fatal("Match check server max port range must be > 0", zap.Int("match.check_server_max_port_range", c.GetMatch().CheckServerMaxPortRange))
+	}
+	if c.GetMatch().PingCandidateCount < 0 {
+		logger.Fatal("Match ping candidate count must be >= 0", zap.Int("match.ping_candidate_count", c.GetMatch().PingCandidateCount))
+	}
+	if c.GetMatch().LatencyCacheRefreshSec < 1 {
+		logger.Fatal("Match latency cache refresh must be >= 1", zap.Int("match.latency_cache_refresh_sec", c.GetMatch().LatencyCacheRefreshSec))
+	}
+	if c.GetMatch().LatencyCacheExpirySec < c.GetMatch().LatencyCacheRefreshSec {
+		logger.Fatal("Match latency cache expiry must be >= match.latency_cache_refresh_sec", zap.Int("match.latency_cache_expiry_sec", c.GetMatch().LatencyCacheExpirySec))
+	}
+	if u, err := url.Parse(c.GetMatch().SparkLinkBaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		logger.Fatal("Match spark link base URL must be an absolute URL", zap.String("match.spark_link_base_url", c.GetMatch().SparkLinkBaseURL))
+	}
 	if c.GetTracker().EventQueueSize < 1 {
 		logger.Fatal("Tracker presence event queue size must be >= 1", zap.Int("tracker.event_queue_size", c.GetTracker().EventQueueSize))
 	}
@@ -738,14 +770,17 @@ var _ runtime.SessionConfig = &SessionConfig{}
 
 // SessionConfig is configuration relevant to the session.
 type SessionConfig struct {
-	EncryptionKey         string `yaml:"encryption_key" json:"encryption_key" usage:"The encryption key used to produce the client token."`
-	TokenExpirySec        int64  `yaml:"token_expiry_sec" json:"token_expiry_sec" usage:"Token expiry in seconds."`
-	RefreshEncryptionKey  string `yaml:"refresh_encryption_key" json:"refresh_encryption_key" usage:"The encryption key used to produce the client refresh token."`
-	RefreshTokenExpirySec int64  `yaml:"refresh_token_expiry_sec" json:"refresh_token_expiry_sec" usage:"Refresh token expiry in seconds."`
-	SingleSocket          bool   `yaml:"single_socket" json:"single_socket" usage:"Only allow one socket per user. Older sessions are disconnected. Default false."`
-	SingleMatch           bool   `yaml:"single_match" json:"single_match" usage:"Only allow one match per user. Older matches receive a leave. Requires single socket to enable. Default false."`
-	SingleParty           bool   `yaml:"single_party" json:"single_party" usage:"Only allow one party per user. Older parties receive a leave. Requires single socket to enable. Default false."`
-	SingleSession         bool   `yaml:"single_session" json:"single_session" usage:"Only allow one session token per user. Older session tokens are invalidated in the session cache. Default false."`
+	EncryptionKey           string `yaml:"encryption_key" json:"encryption_key" usage:"The encryption key used to produce the client token."`
+	TokenExpirySec          int64  `yaml:"token_expiry_sec" json:"token_expiry_sec" usage:"Token expiry in seconds."`
+	RefreshEncryptionKey    string `yaml:"refresh_encryption_key" json:"refresh_encryption_key" usage:"The encryption key used to produce the client refresh token."`
+	RefreshTokenExpirySec   int64  `yaml:"refresh_token_expiry_sec" json:"refresh_token_expiry_sec" usage:"Refresh token expiry in seconds."`
+	SingleSocket            bool   `yaml:"single_socket" json:"single_socket" usage:"Only allow one socket per user. Older sessions are disconnected. Default false."`
+	SingleMatch             bool   `yaml:"single_match" json:"single_match" usage:"Only allow one match per user. Older matches receive a leave. Requires single socket to enable. Default false."`
+	SingleParty             bool   `yaml:"single_party" json:"single_party" usage:"Only allow one party per user. Older parties receive a leave. Requires single socket to enable. Default false."`
+	SingleSession           bool   `yaml:"single_session" json:"single_session" usage:"Only allow one session token per user. Older session tokens are invalidated in the session cache. Default false."`
+	Require2FAGraceLogins   int    `yaml:"require_2fa_grace_logins" json:"require_2fa_grace_logins" usage:"Number of EVR logins allowed without Discord 2FA after a user is required to enable it. Default 0."`
+	Require2FATimeoutMs     int    `yaml:"require_2fa_timeout_ms" json:"require_2fa_timeout_ms" usage:"Time in milliseconds to wait for Discord to report a user's 2FA status during EVR login. Default 2000."`
+	Require2FADenyOnTimeout bool   `yaml:"require_2fa_deny_on_timeout" json:"require_2fa_deny_on_timeout" usage:"Reject EVR logins when Discord does not report a user's 2FA status in time. Default true."`
 }
 
 func (cfg *SessionConfig) GetEncryptionKey() string {
@@ -791,10 +826,12 @@ func (cfg *SessionConfig) Clone() *SessionConfig {
 
 func NewSessionConfig() *SessionConfig {
 	return &SessionConfig{
-		EncryptionKey:         "defaultencryptionkey",
-		TokenExpirySec:        60,
-		RefreshEncryptionKey:  "defaultrefreshencryptionkey",
-		RefreshTokenExpirySec: 3600,
+		EncryptionKey:           "defaultencryptionkey",
+		TokenExpirySec:          60,
+		RefreshEncryptionKey:    "defaultrefreshencryptionkey",
+		RefreshTokenExpirySec:   3600,
+		Require2FATimeoutMs:     2000,
+		Require2FADenyOnTimeout: true,
 	}
 }
 
@@ -1175,14 +1212,23 @@ func NewRuntimeConfig() *RuntimeConfig {
 
 // MatchConfig is configuration relevant to authoritative realtime multiplayer matches.
 type MatchConfig struct {
-	InputQueueSize        int `yaml:"input_queue_size" json:"input_queue_size" usage:"Size of the authoritative match buffer that stores client messages until they can be processed by the next tick. Default 128."`
-	CallQueueSize         int `yaml:"call_queue_size" json:"call_queue_size" usage:"Size of the authoritative match buffer that sequences calls to match handler callbacks to ensure no overlaps. Default 128."`
-	SignalQueueSize       int `yaml:"signal_queue_size" json:"signal_queue_size" usage:"Size of the authoritative match buffer that sequences signal operations to match handler callbacks to ensure no overlaps. Default 10."`
-	JoinAttemptQueueSize  int `yaml:"join_attempt_queue_size" json:"join_attempt_queue_size" usage:"Size of the authoritative match buffer that limits the number of in-progress join attempts. Default 128."`
-	DeferredQueueSize     int `yaml:"deferred_queue_size" json:"deferred_queue_size" usage:"Size of the authoritative match buffer that holds deferred message broadcasts until the end of each loop execution. Default 128."`
-	JoinMarkerDeadlineMs  int `yaml:"join_marker_deadline_ms" json:"join_marker_deadline_ms" usage:"Deadline in milliseconds that client authoritative match joins will wait for match handlers to acknowledge joins. Default 15000."`
-	MaxEmptySec           int `yaml:"max_empty_sec" json:"max_empty_sec" usage:"Maximum number of consecutive seconds that authoritative matches are allowed to be empty before they are stopped. 0 indicates no maximum. Default 0."`
-	LabelUpdateIntervalMs int `yaml:"label_update_interval_ms" json:"label_update_interval_ms" usage:"Time in milliseconds between match label update batch processes. Default 1000."`
+	InputQueueSize            int            `yaml:"input_queue_size" json:"input_queue_size" usage:"Size of the authoritative match buffer that stores client messages until they can be processed by the next tick. Default 128."`
+	CallQueueSize             int            `yaml:"call_queue_size" json:"call_queue_size" usage:"Size of the authoritative match buffer that sequences calls to match handler callbacks to ensure no overlaps. Default 128."`
+	SignalQueueSize           int            `yaml:"signal_queue_size" json:"signal_queue_size" usage:"Size of the authoritative match buffer that sequences signal operations to match handler callbacks to ensure no overlaps. Default 10."`
+	JoinAttemptQueueSize      int            `yaml:"join_attempt_queue_size" json:"join_attempt_queue_size" usage:"Size of the authoritative match buffer that limits the number of in-progress join attempts. Default 128."`
+	DeferredQueueSize         int            `yaml:"deferred_queue_size" json:"deferred_queue_size" usage:"Size of the authoritative match buffer that holds deferred message broadcasts until the end of each loop execution. Default 128."`
+	JoinMarkerDeadlineMs      int            `yaml:"join_marker_deadline_ms" json:"join_marker_deadline_ms" usage:"Deadline in milliseconds that client authoritative match joins will wait for match handlers to acknowledge joins. Default 15000."`
+	MaxEmptySec               int            `yaml:"max_empty_sec" json:"max_empty_sec" usage:"Maximum number of consecutive seconds that authoritative matches are allowed to be empty before they are stopped. 0 indicates no maximum. Default 0."`
+	LabelUpdateIntervalMs     int            `yaml:"label_update_interval_ms" json:"label_update_interval_ms" usage:"Time in milliseconds between match label update batch processes. Default 1000."`
+	BroadcasterJoinTimeoutSec int            `yaml:"broadcaster_join_timeout_sec" json:"broadcaster_join_timeout_sec" usage:"Time in seconds that EVR matches wait for their game server to join before shutting down. Default 60."`
+	MaxRoundsByMode           map[string]int `yaml:"max_rounds_by_mode" json:"max_rounds_by_mode" usage:"Number of rounds after which EVR matches of each mode (i.e. echo_arena) end. 0 or unset indicates no maximum."`
+	CheckServerPortStart      int            `yaml:"check_server_port_start" json:"check_server_port_start" usage:"First port of the default range scanned by the check-server command. Default 6792."`
+	CheckServerPortEnd        int            `yaml:"check_server_port_end" json:"check_server_port_end" usage:"Last port of the default range scanned by the check-server command. Default 6820."`
+	CheckServerMaxPortRange   int            `yaml:"check_server_max_port_range" json:"check_server_max_port_range" usage:"Maximum number of ports the check-server command will scan. Default 100."`
+	PingCandidateCount        int            `yaml:"ping_candidate_count" json:"ping_candidate_count" usage:"Maximum number of game servers a client is asked to ping. 0 scales with the number of registered game servers. Default 0."`
+	LatencyCacheRefreshSec    int            `yaml:"latency_cache_refresh_sec" json:"latency_cache_refresh_sec" usage:"Age in seconds after which a client's RTT to a game server is considered stale and the server is pinged again first. Default 10800."`
+	LatencyCacheExpirySec     int            `yaml:"latency_cache_expiry_sec" json:"latency_cache_expiry_sec" usage:"Age in seconds after which a client's RTT to a game server is ignored when choosing servers to ping. Must be >= latency_cache_refresh_sec. Default 259200."`
+	SparkLinkBaseURL          string         `yaml:"spark_link_base_url" json:"spark_link_base_url" usage:"Base URL of the link service that opens spark:// links to EVR matches. Default https://echo.taxi/."`
 }
 
 func (cfg *MatchConfig) Clone() *MatchConfig {
@@ -1196,14 +1242,23 @@ func (cfg *MatchConfig) Clone() *MatchConfig {
 
 func NewMatchConfig() *MatchConfig {
 	return &MatchConfig{
-		InputQueueSize:        128,
-		CallQueueSize:         128,
-		SignalQueueSize:       10,
-		JoinAttemptQueueSize:  128,
-		DeferredQueueSize:     128,
-		JoinMarkerDeadlineMs:  15000,
-		MaxEmptySec:           0,
-		LabelUpdateIntervalMs: 1000,
+		InputQueueSize:            128,
+		CallQueueSize:             128,
+		SignalQueueSize:           10,
+		JoinAttemptQueueSize:      128,
+		DeferredQueueSize:         128,
+		JoinMarkerDeadlineMs:      15000,
+		MaxEmptySec:               0,
+		LabelUpdateIntervalMs:     1000,
+		BroadcasterJoinTimeoutSec: BroadcasterJoinTimeoutSecs,
+		MaxRoundsByMode:           make(map[string]int),
+		CheckServerPortStart:      6792,
+		CheckServerPortEnd:        6820,
+		CheckServerMaxPortRange:   100,
+		PingCandidateCount:        0,
+		LatencyCacheRefreshSec:    int(LatencyCacheRefreshInterval.Seconds()),
+		LatencyCacheExpirySec:     int(LatencyCacheExpiry.Seconds()),
+		SparkLinkBaseURL:          DefaultSparkLinkBaseURL,
 	}
 }
 
@@ -0,0 +1,64 @@
+package evr
+
+import (
+	"sync"
+)
+
+// Symbols added at runtime are kept apart from the generated cache, so that the generated cache can be read without locking.
+var runtimeSymbolCache = struct {
+	sync.RWMutex
+	tokens map[Symbol]SymbolToken
+	recent []Symbol // In the order they were added
+}{
+	tokens: make(map[Symbol]SymbolToken),
+}
+
+// AddSymbolTokens adds the tokens to the symbol cache, and returns the symbols that were not already cached.
+func AddSymbolTokens(tokens ...string) []Symbol {
+	runtimeSymbolCache.Lock()
+	defer runtimeSymbolCache.Unlock()
+
+	added := make([]Symbol, 0, len(tokens))
+	for _, t := range tokens {
+		s := ToSymbol(t)
+		if _, ok := SymbolCache[s]; ok {
+			continue
+		}
+		if _, ok := runtimeSymbolCache.tokens[s]; ok {
+			continue
+		}
+		runtimeSymbolCache.tokens[s] = SymbolToken(t)
+		runtimeSymbolCache.recent = append(runtimeSymbolCache.recent, s)
+		added = append(added, s)
+	}
+	return added
+}
+
+// RecentSymbolTokens returns up to n of the most recently added tokens, newest first.
+func RecentSymbolTokens(n int) []SymbolToken {
+	runtimeSymbolCache.RLock()
+	defer runtimeSymbolCache.RUnlock()
+
+	recent := make([]SymbolToken, 0, n)
+	for i := len(runtimeSymbolCache.recent) - 1; i >= 0 && len(recent) < n; i-- {
+		recent = append(recent, runtimeSymbolCache.tokens[runtimeSymbolCache.recent[i]])
+	}
+	return recent
+}
+
+// SymbolCacheSize returns the number of generated and runtime symbols in the cache.
+func SymbolCacheSize() (generated int, added int) {
+	runtimeSymbolCache.RLock()
+	defer runtimeSymbolCache.RUnlock()
+	return len(SymbolCache), len(runtimeSymbolCache.tokens)
+}
+
+func lookupSymbolToken(s Symbol) (SymbolToken, bool) {
+	if t, ok := SymbolCache[s]; ok {
+		return t, true
+	}
+	runtimeSymbolCache.RLock()
+	defer runtimeSymbolCache.RUnlock()
+	t, ok := runtimeSymbolCache.tokens[s]
+	return t, ok
+}
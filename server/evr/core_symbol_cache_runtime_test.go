@@ -0,0 +1,24 @@
+package evr
+
+import "testing"
+
+func TestAddSymbolTokens(t *testing.T) {
+	token := "test_runtime_symbol_token"
+	symbol := ToSymbol(token)
+
+	if got := symbol.Token(); got == SymbolToken(token) {
+		t.Fatalf("Token() = %s before the token was added", got)
+	}
+
+	if added := AddSymbolTokens(token, token); len(added) != 1 || added[0] != symbol {
+		t.Errorf("AddSymbolTokens() = %v, want [%s]", added, symbol.HexString())
+	}
+
+	if got := symbol.Token(); got != SymbolToken(token) {
+		t.Errorf("Token() = %s, want %s", got, token)
+	}
+
+	if recent := RecentSymbolTokens(1); len(recent) != 1 || recent[0] != SymbolToken(token) {
+		t.Errorf("RecentSymbolTokens() = %v, want [%s]", recent, token)
+	}
+}
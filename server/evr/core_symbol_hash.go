@@ -17,7 +17,7 @@ func (s Symbol) HexString() string {
 // or returns the hex string representation of the token.
 // ToSymbol will detect 0x prefixed hex strings.
 func (s Symbol) Token() SymbolToken {
-	t, ok := SymbolCache[s]
+	t, ok := lookupSymbolToken(s)
 	if !ok {
 		// If it's not found, just return the number as a hex string
 		t = SymbolToken(s.HexString())
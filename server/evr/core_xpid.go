@@ -166,13 +166,17 @@ func (x XPID) MarshalText() ([]byte, error) {
 
 func (x *XPID) UnmarshalText(text []byte) error {
 
-	platformIDStr, accountIDStr, ok := strings.Cut(string(text), "-")
-	if !ok {
+	// The platform code may itself contain a dash (i.e. OVR-ORG), so the account ID follows the last one.
+	i := strings.LastIndex(string(text), "-")
+	if i == -1 {
 		return fmt.Errorf("invalid XPID format")
 	}
+	platformIDStr, accountIDStr := string(text[:i]), string(text[i+1:])
 
-	platformID, err := strconv.ParseUint(platformIDStr, 10, 32)
-	if err != nil {
+	var platformID PlatformID
+	if n, err := strconv.ParseUint(platformIDStr, 10, 32); err == nil {
+		platformID = PlatformID(n)
+	} else if err := platformID.UnmarshalText([]byte(strings.ReplaceAll(platformIDStr, "-", "_"))); err != nil {
 		return fmt.Errorf("failed to parse platform identifier: %w", err)
 	}
 
@@ -181,7 +185,7 @@ func (x *XPID) UnmarshalText(text []byte) error {
 		return fmt.Errorf("failed to parse account identifier: %w", err)
 	}
 
-	x.ProviderID.PlatformID = PlatformID(platformID)
+	x.ProviderID.PlatformID = platformID
 	x.AccountID = AccountID(accountID)
 
 	return nil
@@ -381,3 +381,28 @@ func TestXPIDFromString(t *testing.T) {
 		})
 	}
 }
+
+func TestXPID_UnmarshalText_RoundTrip(t *testing.T) {
+	for _, platformID := range []PlatformID{STM, PSN, XBX, OVR, OVR_Deprecated, BOT, DMO} {
+		want := NewXPID(platformID, 3963667097037078)
+		text, err := want.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText() error = %v", err)
+		}
+
+		got := XPID{}
+		if err := got.UnmarshalText(text); err != nil {
+			t.Errorf("UnmarshalText(%q) error = %v", text, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("UnmarshalText(%q) = %v, want %v", text, got, want)
+		}
+	}
+
+	// The numeric platform form is still accepted.
+	got := XPID{}
+	if err := got.UnmarshalText([]byte("4-1")); err != nil || got.ProviderID.PlatformID != OVR || got.AccountID != 1 {
+		t.Errorf("UnmarshalText(\"4-1\") = %v, %v, want %v", got, err, NewXPID(OVR, 1))
+	}
+}
@@ -131,10 +131,18 @@ func NewGameServerSessionStart(sessionID uuid.UUID, channel uuid.UUID, playerLim
 }
 
 type LobbySessionSettings struct {
-	AppID    string   `json:"appid"`
-	Mode     int64    `json:"gametype"`
-	Level    int64    `json:"level"`
-	Features []string `json:"features,omitempty"`
+	AppID    string         `json:"appid"`
+	Mode     int64          `json:"gametype"`
+	Level    int64          `json:"level"`
+	Features []string       `json:"features,omitempty"`
+	Pacing   *SessionPacing `json:"pacing,omitempty"` // Round timing overrides for the game server
+}
+
+// SessionPacing overrides the game server's round timing. Zero values use the game's defaults.
+type SessionPacing struct {
+	RoundDurationSecs     int `json:"round_duration_secs,omitempty"`      // The length of a round
+	AfterGoalDurationSecs int `json:"after_goal_duration_secs,omitempty"` // The pause after a goal, before the respawn and catapult
+	PreMatchWaitSecs      int `json:"pre_match_wait_secs,omitempty"`      // The wait before the first catapult
 }
 
 func (s *LobbySessionSettings) MarshalJSON() ([]byte, error) {
@@ -165,6 +173,7 @@ func (s *LobbySessionSettings) UnmarshalJSON(data []byte) error {
 	s.Features = aux.Features
 	s.AppID = aux.AppID
 	s.Mode = aux.Mode
+	s.Pacing = aux.Pacing
 	return nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -155,11 +156,16 @@ func (a *AccountMetadata) GetActiveGroupID() uuid.UUID {
 	return uuid.FromStringOrNil(a.ActiveGroupID)
 }
 
+// SetActiveGroupID sets the active group. A nil ID clears it, so the next login selects one.
 func (a *AccountMetadata) SetActiveGroupID(id uuid.UUID) {
-	if a.ActiveGroupID == id.String() {
+	activeGroupID := id.String()
+	if id == uuid.Nil {
+		activeGroupID = ""
+	}
+	if a.ActiveGroupID == activeGroupID {
 		return
 	}
-	a.ActiveGroupID = id.String()
+	a.ActiveGroupID = activeGroupID
 	a.isModified = true
 }
 
@@ -213,6 +219,18 @@ func (a *AccountMetadata) MarshalMap() map[string]interface{} {
 	return m
 }
 
+// IsChanged reports whether the metadata, or the display name, differ from those of the account it was loaded from.
+func (a *AccountMetadata) IsChanged(displayName string) bool {
+	if a.account == nil || a.account.GetUser().GetDisplayName() != displayName {
+		return true
+	}
+	var stored map[string]interface{}
+	if err := json.Unmarshal([]byte(a.account.GetUser().GetMetadata()), &stored); err != nil {
+		return true
+	}
+	return !reflect.DeepEqual(stored, a.MarshalMap())
+}
+
 func (a *AccountMetadata) NeedsUpdate() bool {
 	return a.isModified
 }
@@ -239,30 +257,20 @@ func GetDisplayNameByGroupID(ctx context.Context, nk runtime.NakamaModule, userI
 }
 
 func UserGuildGroupsList(ctx context.Context, nk runtime.NakamaModule, userID string) (map[string]*GuildGroup, error) {
-	groups := make(map[string]*GuildGroup, 0)
-	cursor := ""
-	for {
-		// Fetch the groups using the provided userId
-		userGroups, _, err := nk.UserGroupsList(ctx, userID, 100, nil, cursor)
+	userGroups, err := userGuildGroups(ctx, nk, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each caller gets its own guild groups, built from the (cached) groups.
+	groups := make(map[string]*GuildGroup, len(userGroups))
+	for _, g := range userGroups {
+		gg, err := NewGuildGroup(g)
 		if err != nil {
-			return nil, fmt.Errorf("error getting user groups: %w", err)
+			return nil, fmt.Errorf("error creating guild group: %w", err)
 		}
 
-		for _, ug := range userGroups {
-			g := ug.GetGroup()
-			if g.GetLangTag() != "guild" {
-				continue
-			}
-			gg, err := NewGuildGroup(g)
-			if err != nil {
-				return nil, fmt.Errorf("error creating guild group: %w", err)
-			}
-
-			groups[g.GetId()] = gg
-		}
-		if cursor == "" {
-			break
-		}
+		groups[g.GetId()] = gg
 	}
 	return groups, nil
 }
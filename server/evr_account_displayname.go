@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strings"
 	"time"
+	"unicode"
 
 	anyascii "github.com/anyascii/go"
 )
@@ -37,6 +38,14 @@ func (s *SuspensionStatus) Valid() bool {
 
 // sanitizeDisplayName filters the provided displayName to ensure it is valid.
 func sanitizeDisplayName(displayName string) string {
+	// Remove control and invisible formatting characters (i.e. zero-width spaces, bidi overrides)
+	displayName = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		return r
+	}, displayName)
+
 	mapping := map[string]string{
 		"๒": "b",
 		"ɭ": "l",
@@ -60,6 +69,9 @@ func sanitizeDisplayName(displayName string) string {
 	// Filter the string using the regular expression
 	displayName = DisplayNameFilterRegex.ReplaceAllLiteralString(displayName, "")
 
+	// Collapse runs of spaces
+	displayName = strings.Join(strings.Fields(displayName), " ")
+
 	// twenty characters maximum
 	if len(displayName) > 20 {
 		displayName = displayName[:20]
@@ -104,6 +104,30 @@ func (h *DisplayNameHistory) updateCache() {
 	}
 }
 
+// Trim removes all but the latest keep entries of the group's history, and returns the number of entries removed.
+func (h *DisplayNameHistory) Trim(groupID string, keep int) int {
+	items := h.Histories[groupID]
+	if len(items) <= keep {
+		return 0
+	}
+
+	removed := len(items) - keep
+	if keep == 0 {
+		delete(h.Histories, groupID)
+	} else {
+		h.Histories[groupID] = slices.Clone(items[removed:])
+	}
+	h.updated = true
+
+	h.updateCache()
+	if h.IsInactive {
+		h.Active = make([]string, 0)
+	} else {
+		h.updateActive()
+	}
+	return removed
+}
+
 func (h *DisplayNameHistory) AddReserved(displayName string) {
 	if !slices.Contains(h.Reserved, displayName) {
 		h.Reserved = append(h.Reserved, displayName)
@@ -210,7 +234,7 @@ func DisplayNameCacheRegexSearch(ctx context.Context, nk runtime.NakamaModule, p
 	cursor := ""
 
 	hardLimit := 1000
-	results := make([]*api.StorageObject, 100)
+	results := make([]*api.StorageObject, 0, 100)
 	var err error
 	var result *api.StorageObjects
 	for {
@@ -233,8 +257,8 @@ func DisplayNameCacheRegexSearch(ctx context.Context, nk runtime.NakamaModule, p
 		}
 	}
 
-	histories := make(map[string]*DisplayNameHistory, len(result.Objects))
-	for _, obj := range result.Objects {
+	histories := make(map[string]*DisplayNameHistory, len(results))
+	for _, obj := range results {
 		var history DisplayNameHistory
 		if err := json.Unmarshal([]byte(obj.Value), &history); err != nil {
 			return nil, fmt.Errorf("error unmarshalling display name history: %w", err)
@@ -1 +1,32 @@
 package server
+
+import "testing"
+
+func TestDisplayNameHistory_Trim(t *testing.T) {
+	groupID := "group"
+
+	h := NewDisplayNameHistory()
+	for _, name := range []string{"First", "Second", "Third"} {
+		h.Set(groupID, name)
+	}
+
+	if got := h.Trim(groupID, 1); got != 2 {
+		t.Errorf("Trim() = %d, want 2", got)
+	}
+	if got := h.Histories[groupID]; len(got) != 1 || got[0].DisplayName != "Third" {
+		t.Errorf("Histories[groupID] = %v, want only Third", got)
+	}
+	if len(h.Cache) != 1 || h.Cache[0] != "third" {
+		t.Errorf("Cache = %v, want [third]", h.Cache)
+	}
+
+	if got := h.Trim(groupID, 0); got != 1 {
+		t.Errorf("Trim() = %d, want 1", got)
+	}
+	if _, ok := h.Histories[groupID]; ok {
+		t.Errorf("Histories[groupID] still exists after clearing")
+	}
+	if len(h.Active) != 0 {
+		t.Errorf("Active = %v, want empty", h.Active)
+	}
+}
@@ -31,11 +31,14 @@ func (m *AlternateSearchMatch) IsMatch() bool {
 }
 
 func (m *AlternateSearchMatch) IsXPIMatch() bool {
-	return m.Entry.XPID == m.Entry.XPID
+	return m.Entry.XPID == m.Other.XPID
 }
 
 func (m *AlternateSearchMatch) IsHMDSerialNumberMatch() bool {
-	return m.Entry.LoginData.HMDSerialNumber == m.Entry.LoginData.HMDSerialNumber
+	if _, ok := IgnoredLoginValues[m.Entry.LoginData.HMDSerialNumber]; ok {
+		return false
+	}
+	return m.Entry.LoginData.HMDSerialNumber == m.Other.LoginData.HMDSerialNumber
 }
 
 func (m *AlternateSearchMatch) IsClientIPMatch() bool {
@@ -54,6 +57,25 @@ func (m *AlternateSearchMatch) Matches() (xpi bool, hmdSerialNumber bool, client
 	return
 }
 
+// MatchedItems returns the names of the login items shared by both entries.
+func (m *AlternateSearchMatch) MatchedItems() []string {
+	items := make([]string, 0, 4)
+	xpi, hmdSerialNumber, clientIP, systemProfile := m.Matches()
+	if xpi {
+		items = append(items, "xpid")
+	}
+	if hmdSerialNumber {
+		items = append(items, "hmd serial")
+	}
+	if clientIP {
+		items = append(items, "ip")
+	}
+	if systemProfile {
+		items = append(items, "system profile")
+	}
+	return items
+}
+
 func LoginAlternateSearch(ctx context.Context, nk runtime.NakamaModule, loginHistory *LoginHistory) ([]*AlternateSearchMatch, error) {
 
 	patterns := make([]string, 0)
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/heroiclabs/nakama/v3/server/evr"
+)
+
+func TestAlternateSearchMatch_Matches(t *testing.T) {
+	entry := &LoginHistoryEntry{
+		XPID:      evr.NewXPID(evr.OVR, 1),
+		ClientIP:  "10.0.0.1",
+		LoginData: &evr.LoginProfile{HMDSerialNumber: "SERIAL-A"},
+	}
+
+	tests := []struct {
+		name                string
+		other               *LoginHistoryEntry
+		wantXPI             bool
+		wantHMDSerialNumber bool
+		wantClientIP        bool
+	}{
+		{
+			name: "distinct entries do not match",
+			other: &LoginHistoryEntry{
+				XPID:      evr.NewXPID(evr.OVR, 2),
+				ClientIP:  "10.0.0.2",
+				LoginData: &evr.LoginProfile{HMDSerialNumber: "SERIAL-B"},
+			},
+		},
+		{
+			name: "shared items match",
+			other: &LoginHistoryEntry{
+				XPID:      evr.NewXPID(evr.OVR, 1),
+				ClientIP:  "10.0.0.1",
+				LoginData: &evr.LoginProfile{HMDSerialNumber: "SERIAL-A"},
+			},
+			wantXPI:             true,
+			wantHMDSerialNumber: true,
+			wantClientIP:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewAlternateSearchMatch("entry", "other", entry, tt.other)
+			xpi, hmdSerialNumber, clientIP, _ := m.Matches()
+			if xpi != tt.wantXPI || hmdSerialNumber != tt.wantHMDSerialNumber || clientIP != tt.wantClientIP {
+				t.Errorf("Matches() = (%v, %v, %v), want (%v, %v, %v)", xpi, hmdSerialNumber, clientIP, tt.wantXPI, tt.wantHMDSerialNumber, tt.wantClientIP)
+			}
+		})
+	}
+
+	t.Run("ignored serial numbers do not match", func(t *testing.T) {
+		ignored := &LoginHistoryEntry{LoginData: &evr.LoginProfile{HMDSerialNumber: "N/A"}}
+		if NewAlternateSearchMatch("entry", "other", ignored, ignored).IsHMDSerialNumberMatch() {
+			t.Errorf("IsHMDSerialNumberMatch() = true for an ignored serial number")
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"math"
 	"slices"
 	"strings"
 	"time"
@@ -21,6 +22,13 @@ const (
 	LoginStorageCollection = "Devices"
 	LoginHistoryStorageKey = "history"
 	LoginHistoryCacheIndex = "Index_DeviceHistory"
+
+	// Logins between two locations that would require travelling faster than this are flagged.
+	LoginImpossibleTravelSpeedKmh = 1000.0
+	// Short distances are ignored to account for inaccurate IP geolocation.
+	LoginImpossibleTravelMinDistanceKm = 500.0
+	// The maximum number of location anomalies kept on the history.
+	LoginLocationAnomalyLimit = 10
 )
 
 var (
@@ -57,16 +65,30 @@ func (h *LoginHistoryEntry) SystemProfile() string {
 	return strings.Join(components, "::")
 }
 
+// LoginLocationAnomaly records a login that is geographically implausible given the previous login.
+type LoginLocationAnomaly struct {
+	DetectedAt       time.Time `json:"detected_at"`
+	ClientIP         string    `json:"client_ip"`
+	Location         string    `json:"location"`
+	PreviousClientIP string    `json:"previous_client_ip"`
+	PreviousLocation string    `json:"previous_location"`
+	PreviousLoginAt  time.Time `json:"previous_login_at"`
+	DistanceKm       float64   `json:"distance_km"`
+	SpeedKmh         float64   `json:"speed_kmh"`
+}
+
 type LoginHistory struct {
-	History          map[string]*LoginHistoryEntry `json:"history"` // map[deviceID]DeviceHistoryEntry
-	Cache            []string                      `json:"cache"`   // list of IP addresses, XPID's, HMD Serial Numbers, and System Data
-	XPIs             map[string]time.Time          `json:"xpis"`    // list of XPIs
-	ClientIPs        map[string]time.Time          `json:"client_ips"`
-	AuthorizedIPs    map[string]time.Time          `json:"authorized_ips"`
-	AlternateUserIDs []string                      `json:"alternates"`
-	NotifiedGroupIDs map[string]time.Time          `json:"notified_groups"` // list of groups that have been notified of this alternate login
-	userID           string                        // user ID
-	version          string                        // storage record version
+	History           map[string]*LoginHistoryEntry `json:"history"` // map[deviceID]DeviceHistoryEntry
+	Cache             []string                      `json:"cache"`   // list of IP addresses, XPID's, HMD Serial Numbers, and System Data
+	XPIs              map[string]time.Time          `json:"xpis"`    // list of XPIs
+	ClientIPs         map[string]time.Time          `json:"client_ips"`
+	AuthorizedIPs     map[string]time.Time          `json:"authorized_ips"`
+	AlternateUserIDs  []string                      `json:"alternates"`
+	NotifiedGroupIDs  map[string]time.Time          `json:"notified_groups"`              // list of groups that have been notified of this alternate login
+	LocationAnomalies []*LoginLocationAnomaly       `json:"location_anomalies,omitempty"` // list of geographically implausible logins
+	Require2FALogins  int                           `json:"require_2fa_logins,omitempty"` // number of logins since 2FA was required
+	userID            string                        // user ID
+	version           string                        // storage record version
 }
 
 func NewLoginHistory() *LoginHistory {
@@ -103,6 +125,75 @@ func (h *LoginHistory) Insert(entry *LoginHistoryEntry) {
 	h.History[entry.Key()] = entry
 }
 
+// LastEntry returns the most recent login entry, or nil if there are none.
+func (h *LoginHistory) LastEntry() *LoginHistoryEntry {
+	var last *LoginHistoryEntry
+	for _, e := range h.History {
+		if last == nil || e.UpdatedAt.After(last.UpdatedAt) {
+			last = e
+		}
+	}
+	return last
+}
+
+// CheckLocationAnomaly compares the location of the current login against the previous login. If the distance
+// could not have been travelled in the time between the logins, the anomaly is recorded on the history and returned.
+func (h *LoginHistory) CheckLocationAnomaly(previous *LoginHistoryEntry, previousLocation *IPQSResponse, clientIP string, location *IPQSResponse, now time.Time) *LoginLocationAnomaly {
+	if previous == nil || previousLocation == nil || location == nil {
+		return nil
+	}
+
+	if previous.ClientIP == clientIP {
+		return nil
+	}
+
+	distance := greatCircleDistanceKm(previousLocation.Latitude, previousLocation.Longitude, location.Latitude, location.Longitude)
+	if distance < LoginImpossibleTravelMinDistanceKm {
+		return nil
+	}
+
+	elapsed := now.Sub(previous.UpdatedAt)
+	if elapsed < 0 {
+		return nil
+	}
+
+	// Avoid dividing by zero for near-simultaneous logins
+	hours := math.Max(elapsed.Hours(), 1.0/60)
+	speed := distance / hours
+	if speed < LoginImpossibleTravelSpeedKmh {
+		return nil
+	}
+
+	anomaly := &LoginLocationAnomaly{
+		DetectedAt:       now.UTC(),
+		ClientIP:         clientIP,
+		Location:         fmt.Sprintf("%s, %s", location.City, location.Region),
+		PreviousClientIP: previous.ClientIP,
+		PreviousLocation: fmt.Sprintf("%s, %s", previousLocation.City, previousLocation.Region),
+		PreviousLoginAt:  previous.UpdatedAt.UTC(),
+		DistanceKm:       math.Round(distance),
+		SpeedKmh:         math.Round(speed),
+	}
+
+	h.LocationAnomalies = append(h.LocationAnomalies, anomaly)
+	if len(h.LocationAnomalies) > LoginLocationAnomalyLimit {
+		h.LocationAnomalies = h.LocationAnomalies[len(h.LocationAnomalies)-LoginLocationAnomalyLimit:]
+	}
+
+	return anomaly
+}
+
+// greatCircleDistanceKm returns the haversine distance between two coordinates.
+func greatCircleDistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKm = 6371.0
+	toRadians := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
 func (h *LoginHistory) AuthorizeIP(ip string) {
 	if h.AuthorizedIPs == nil {
 		h.AuthorizedIPs = make(map[string]time.Time)
@@ -111,6 +202,20 @@ func (h *LoginHistory) AuthorizeIP(ip string) {
 	h.AuthorizedIPs[ip] = time.Now().UTC()
 }
 
+// LastUnauthorizedEntry returns the most recent login entry since the given time from an IP that has not been authorized, or nil if there are none.
+func (h *LoginHistory) LastUnauthorizedEntry(since time.Time) *LoginHistoryEntry {
+	var last *LoginHistoryEntry
+	for _, e := range h.History {
+		if e.UpdatedAt.Before(since) || h.IsAuthorizedIP(e.ClientIP) {
+			continue
+		}
+		if last == nil || e.UpdatedAt.After(last.UpdatedAt) {
+			last = e
+		}
+	}
+	return last
+}
+
 func (h *LoginHistory) IsAuthorizedIP(ip string) bool {
 	if h.AuthorizedIPs == nil {
 		return false
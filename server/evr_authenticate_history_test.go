@@ -0,0 +1,123 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoginHistory_CheckLocationAnomaly(t *testing.T) {
+	now := time.Now()
+
+	newYork := &IPQSResponse{City: "New York", Region: "New York", Latitude: 40.7128, Longitude: -74.0060}
+	newark := &IPQSResponse{City: "Newark", Region: "New Jersey", Latitude: 40.7357, Longitude: -74.1724}
+	london := &IPQSResponse{City: "London", Region: "England", Latitude: 51.5074, Longitude: -0.1278}
+
+	tests := []struct {
+		name             string
+		previous         *LoginHistoryEntry
+		previousLocation *IPQSResponse
+		clientIP         string
+		location         *IPQSResponse
+		want             bool
+	}{
+		{
+			name:             "no previous login",
+			previous:         nil,
+			previousLocation: nil,
+			clientIP:         "2.2.2.2",
+			location:         london,
+			want:             false,
+		},
+		{
+			name:             "same IP",
+			previous:         &LoginHistoryEntry{ClientIP: "1.1.1.1", UpdatedAt: now.Add(-time.Minute)},
+			previousLocation: newYork,
+			clientIP:         "1.1.1.1",
+			location:         london,
+			want:             false,
+		},
+		{
+			name:             "nearby location",
+			previous:         &LoginHistoryEntry{ClientIP: "1.1.1.1", UpdatedAt: now.Add(-time.Minute)},
+			previousLocation: newYork,
+			clientIP:         "2.2.2.2",
+			location:         newark,
+			want:             false,
+		},
+		{
+			name:             "plausible travel time",
+			previous:         &LoginHistoryEntry{ClientIP: "1.1.1.1", UpdatedAt: now.Add(-12 * time.Hour)},
+			previousLocation: newYork,
+			clientIP:         "2.2.2.2",
+			location:         london,
+			want:             false,
+		},
+		{
+			name:             "impossible travel",
+			previous:         &LoginHistoryEntry{ClientIP: "1.1.1.1", UpdatedAt: now.Add(-30 * time.Minute)},
+			previousLocation: newYork,
+			clientIP:         "2.2.2.2",
+			location:         london,
+			want:             true,
+		},
+		{
+			name:             "missing location",
+			previous:         &LoginHistoryEntry{ClientIP: "1.1.1.1", UpdatedAt: now.Add(-30 * time.Minute)},
+			previousLocation: newYork,
+			clientIP:         "2.2.2.2",
+			location:         nil,
+			want:             false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewLoginHistory()
+			got := h.CheckLocationAnomaly(tt.previous, tt.previousLocation, tt.clientIP, tt.location, now)
+			if (got != nil) != tt.want {
+				t.Fatalf("CheckLocationAnomaly() = %v, want anomaly %v", got, tt.want)
+			}
+			if tt.want && len(h.LocationAnomalies) != 1 {
+				t.Errorf("expected anomaly to be stored, got %d", len(h.LocationAnomalies))
+			}
+		})
+	}
+}
+
+func TestLoginHistory_CheckLocationAnomalyLimit(t *testing.T) {
+	now := time.Now()
+	newYork := &IPQSResponse{Latitude: 40.7128, Longitude: -74.0060}
+	london := &IPQSResponse{Latitude: 51.5074, Longitude: -0.1278}
+
+	h := NewLoginHistory()
+	for i := 0; i < LoginLocationAnomalyLimit+5; i++ {
+		previous := &LoginHistoryEntry{ClientIP: "1.1.1.1", UpdatedAt: now.Add(-time.Minute)}
+		if h.CheckLocationAnomaly(previous, newYork, "2.2.2.2", london, now) == nil {
+			t.Fatal("expected anomaly")
+		}
+	}
+
+	if len(h.LocationAnomalies) != LoginLocationAnomalyLimit {
+		t.Errorf("expected %d anomalies, got %d", LoginLocationAnomalyLimit, len(h.LocationAnomalies))
+	}
+}
+
+func TestLoginHistory_LastUnauthorizedEntry(t *testing.T) {
+	h := NewLoginHistory()
+	if e := h.LastUnauthorizedEntry(time.Time{}); e != nil {
+		t.Fatalf("expected no unauthorized entry, got %v", e.ClientIP)
+	}
+
+	now := time.Now()
+	h.Insert(&LoginHistoryEntry{UpdatedAt: now.Add(-2 * time.Hour), ClientIP: "1.1.1.1"})
+	h.Insert(&LoginHistoryEntry{UpdatedAt: now.Add(-1 * time.Hour), ClientIP: "2.2.2.2"})
+	h.Insert(&LoginHistoryEntry{UpdatedAt: now, ClientIP: "3.3.3.3"})
+	h.AuthorizeIP("3.3.3.3")
+
+	if e := h.LastUnauthorizedEntry(time.Time{}); e == nil || e.ClientIP != "2.2.2.2" {
+		t.Errorf("expected the most recent unauthorized entry to be 2.2.2.2, got %v", e)
+	}
+
+	if e := h.LastUnauthorizedEntry(now.Add(-30 * time.Minute)); e != nil {
+		t.Errorf("expected no unauthorized entry in the last 30 minutes, got %v", e.ClientIP)
+	}
+}
@@ -169,6 +169,46 @@ func Test_sanitizeDisplayName(t *testing.T) {
 			"a123456789012345678901234567890",
 			"a1234567890123456789",
 		},
+		{
+			"Zero-width characters are removed",
+			"Jo\u200bh\u200cn\u200d\u2060\ufeff",
+			"John",
+		},
+		{
+			"Bidi overrides are removed",
+			"\u202eevil\u202c",
+			"evil",
+		},
+		{
+			"Soft hyphen is removed",
+			"Pla\u00adyer",
+			"Player",
+		},
+		{
+			"Control characters are removed",
+			"\tna\x00me\n",
+			"name",
+		},
+		{
+			"Cyrillic confusables are normalized",
+			"\u0410dmin",
+			"Admin",
+		},
+		{
+			"Fullwidth characters are normalized",
+			"\uff30\uff4c\uff41\uff59\uff45\uff52",
+			"Player",
+		},
+		{
+			"Repeated spaces are collapsed",
+			"a   b\u3000\u3000c",
+			"a b c",
+		},
+		{
+			"Only invisible characters",
+			"\u200b\u200b\u200b",
+			"",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
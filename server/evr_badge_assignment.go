@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+const (
+	BadgeAssignmentStorageCollection = "BadgeAssignments"
+	BadgeGrantStorageCollection      = "BadgeGrants"
+)
+
+// BadgeAssignment is an audit record of badges granted to a user.
+type BadgeAssignment struct {
+	AssignerUserID    string    `json:"assigner_user_id"`
+	AssignerDiscordID string    `json:"assigner_discord_id"`
+	TargetUserID      string    `json:"target_user_id"`
+	TargetDiscordID   string    `json:"target_discord_id"`
+	GroupID           string    `json:"group_id"`
+	BadgeCodes        []string  `json:"badge_codes"`   // The requested badge codes
+	Granted           []string  `json:"granted"`       // The wallet entries that were granted
+	AlreadyOwned      []string  `json:"already_owned"` // The wallet entries the user already had
+	AssignedAt        time.Time `json:"assigned_at"`
+}
+
+// BadgeAssignmentStore writes the assignment record to the target user's storage.
+func BadgeAssignmentStore(ctx context.Context, nk runtime.NakamaModule, a *BadgeAssignment) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("failed to marshal badge assignment: %w", err)
+	}
+
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{
+		{
+			Collection:      BadgeAssignmentStorageCollection,
+			Key:             a.AssignedAt.UTC().Format(time.RFC3339Nano),
+			UserID:          a.TargetUserID,
+			Value:           string(data),
+			PermissionRead:  0,
+			PermissionWrite: 0,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to write badge assignment: %w", err)
+	}
+	return nil
+}
+
+// BadgeGrantClaim records that the badge (wallet entry) is granted to the target user. The record may only be created
+// once, so of concurrent assignments of the same badge only one claims it. It returns false if the badge was already claimed.
+func BadgeGrantClaim(ctx context.Context, nk runtime.NakamaModule, a *BadgeAssignment, badge string) (bool, error) {
+	data, err := json.Marshal(map[string]any{
+		"assigner_user_id": a.AssignerUserID,
+		"assigned_at":      a.AssignedAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal badge grant: %w", err)
+	}
+
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{
+		{
+			Collection:      BadgeGrantStorageCollection,
+			Key:             badge,
+			UserID:          a.TargetUserID,
+			Value:           string(data),
+			Version:         "*", // Only if it does not exist
+			PermissionRead:  0,
+			PermissionWrite: 0,
+		},
+	}); errors.Is(err, runtime.ErrStorageRejectedVersion) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to write badge grant: %w", err)
+	}
+	return true, nil
+}
+
+// BadgeGrantRelease removes the user's claims on the badges, so that they can be assigned again.
+func BadgeGrantRelease(ctx context.Context, nk runtime.NakamaModule, userID string, badges []string) error {
+	if len(badges) == 0 {
+		return nil
+	}
+	deletes := make([]*runtime.StorageDelete, 0, len(badges))
+	for _, badge := range badges {
+		deletes = append(deletes, &runtime.StorageDelete{
+			Collection: BadgeGrantStorageCollection,
+			Key:        badge,
+			UserID:     userID,
+		})
+	}
+	if err := nk.StorageDelete(ctx, deletes); err != nil {
+		return fmt.Errorf("failed to delete badge grants: %w", err)
+	}
+	return nil
+}
+
+// BadgeAssignmentsList returns the badge assignment records for the user.
+func BadgeAssignmentsList(ctx context.Context, nk runtime.NakamaModule, userID string) ([]*BadgeAssignment, error) {
+	assignments := make([]*BadgeAssignment, 0)
+	cursor := ""
+	for {
+		objs, c, err := nk.StorageList(ctx, SystemUserID, userID, BadgeAssignmentStorageCollection, 100, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list badge assignments: %w", err)
+		}
+		for _, obj := range objs {
+			a := &BadgeAssignment{}
+			if err := json.Unmarshal([]byte(obj.GetValue()), a); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal badge assignment: %w", err)
+			}
+			assignments = append(assignments, a)
+		}
+		if c == "" {
+			break
+		}
+		cursor = c
+	}
+	return assignments, nil
+}
@@ -2,16 +2,39 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/heroiclabs/nakama-common/runtime"
 	"gopkg.in/yaml.v3"
 )
 
+// ErrDiscordDisabled is returned when the server is running without a Discord session.
+var ErrDiscordDisabled = errors.New("discord disabled")
+
+// DefaultSparkLinkBaseURL is the link service that opens spark:// links in the game, unless configured otherwise.
+const DefaultSparkLinkBaseURL = "https://echo.taxi/"
+
+// SparkLink returns the link to the match through the link service at baseURL.
+func SparkLink(baseURL string, matchID MatchID) string {
+	return strings.TrimSuffix(baseURL, "/") + "/spark://c/" + strings.ToUpper(matchID.UUID.String())
+}
+
+// sparkLink returns the link to the match through the configured link service.
+func (d *DiscordAppBot) sparkLink(matchID MatchID) string {
+	return SparkLink(d.config.GetMatch().SparkLinkBaseURL, matchID)
+}
+
+// discordEnabled reports whether the bot has a Discord session. It is safe to call on a nil bot.
+func (d *DiscordAppBot) discordEnabled() bool {
+	return d != nil && d.dg != nil
+}
+
 func (d *DiscordAppBot) LogYAMLtoChannel(data any, channelID string) error {
-	if d.dg == nil {
-		return fmt.Errorf("discord session is not initialized")
+	if !d.discordEnabled() {
+		return ErrDiscordDisabled
 	}
 	if channelID == "" {
 		return fmt.Errorf("channelID is empty")
@@ -93,11 +116,12 @@ func (d *DiscordAppBot) LogInteractionToChannel(i *discordgo.InteractionCreate,
 }
 
 func (d *DiscordAppBot) LogMessageToChannel(message string, channelID string) error {
-	if d.dg == nil {
-		return fmt.Errorf("discord session is not initialized")
+	if !d.discordEnabled() {
+		return ErrDiscordDisabled
 	}
 	if channelID == "" {
-		return fmt.Errorf("channelID is empty")
+		// The channel has not been configured.
+		return nil
 	}
 	_, err := d.dg.ChannelMessageSend(channelID, message)
 	if err != nil {
@@ -107,6 +131,9 @@ func (d *DiscordAppBot) LogMessageToChannel(message string, channelID string) er
 }
 
 func (d *DiscordAppBot) LogAuditMessage(ctx context.Context, groupID string, message string, replaceMentions bool) (*discordgo.Message, error) {
+	if !d.discordEnabled() {
+		return nil, ErrDiscordDisabled
+	}
 	// replace all <@uuid> mentions with <@discordID>
 	if replaceMentions {
 		message = d.cache.ReplaceMentions(message)
@@ -117,13 +144,17 @@ func (d *DiscordAppBot) LogAuditMessage(ctx context.Context, groupID string, mes
 		return nil, err
 	}
 
-	if groupMetadata.AuditChannelID != "" {
-		return d.dg.ChannelMessageSend(groupMetadata.AuditChannelID, message)
+	// The guild has not set an audit channel (see /set-audit-channel).
+	if groupMetadata.AuditChannelID == "" {
+		return nil, nil
 	}
-	return nil, nil
+	return d.dg.ChannelMessageSend(groupMetadata.AuditChannelID, message)
 }
 
 func (d *DiscordAppBot) LogUserErrorMessage(ctx context.Context, groupID string, message string, replaceMentions bool) (*discordgo.Message, error) {
+	if !d.discordEnabled() {
+		return nil, ErrDiscordDisabled
+	}
 	// replace all <@uuid> mentions with <@discordID>
 	if replaceMentions {
 		message = d.cache.ReplaceMentions(message)
@@ -140,14 +171,73 @@ func (d *DiscordAppBot) LogUserErrorMessage(ctx context.Context, groupID string,
 	return nil, nil
 }
 
+// LogDebugMessage sends the message to the guild's debug channel, if one is set.
+func (d *DiscordAppBot) LogDebugMessage(groupID string, message string) (*discordgo.Message, error) {
+	if !d.discordEnabled() {
+		return nil, ErrDiscordDisabled
+	}
+
+	if channelID, ok := d.debugChannels.Load(groupID); ok && channelID != "" {
+		return d.dg.ChannelMessageSend(channelID, message)
+	}
+	return nil, nil
+}
+
+// loadDebugChannels populates the debug channels from the guild group metadata.
+func (d *DiscordAppBot) loadDebugChannels(ctx context.Context, logger runtime.Logger) {
+	for _, guild := range d.dg.State.Guilds {
+		groupID := d.cache.GuildIDToGroupID(guild.ID)
+		if groupID == "" {
+			continue
+		}
+
+		md, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+		if err != nil {
+			logger.Warn("Failed to get guild group metadata for %s: %v", guild.ID, err)
+			continue
+		}
+
+		if md.DebugChannelID != "" {
+			d.debugChannels.Store(groupID, md.DebugChannelID)
+		}
+	}
+}
+
+// SendMatchmakerMatchedNotification DMs the spark link to the entrants that opted in to match notifications.
+func (d *DiscordAppBot) SendMatchmakerMatchedNotification(entrants []*EvrMatchPresence, label *MatchLabel) {
+	if !d.discordEnabled() {
+		return
+	}
+
+	content := fmt.Sprintf("Your `%s` match is ready: [Spark Link](%s)", label.Mode.String(), d.sparkLink(label.ID))
+
+	for _, p := range entrants {
+		if p.DiscordID == "" {
+			continue
+		}
+
+		settings, err := LoadMatchmakingSettings(d.ctx, d.nk, p.GetUserId())
+		if err != nil {
+			d.logger.Warn("Failed to load matchmaking settings for %s: %v", p.GetUserId(), err)
+			continue
+		} else if !settings.NotifyOnMatch {
+			continue
+		}
+
+		if err := d.dms.SendUser(d.ctx, p.DiscordID, content); err != nil {
+			d.logger.Warn("Failed to send match notification to %s: %v", p.DiscordID, err)
+		}
+	}
+}
+
 func (d *DiscordAppBot) SendErrorToUser(userID string, userErr error) error {
 
 	if userErr == nil {
 		return nil
 	}
 	var err error
-	if d.dg == nil {
-		return fmt.Errorf("discord session is not initialized")
+	if !d.discordEnabled() {
+		return ErrDiscordDisabled
 	}
 
 	discordID := d.cache.UserIDToDiscordID(userID)
@@ -24,6 +24,10 @@ var (
 
 var mentionRegex = regexp.MustCompile(`<@([-0-9A-Fa-f]+?)>`)
 
+const (
+	SyncMemberQueueSize = 250 // The most member syncs that may be waiting; more are dropped.
+)
+
 type QueueEntry struct {
 	DiscordID string
 	GuildID   string
@@ -40,6 +44,7 @@ type DiscordCache struct {
 	dg *discordgo.Session
 
 	queueCh chan QueueEntry
+	pending *MapOf[QueueEntry, time.Time] // map[QueueEntry]enqueuedAt; entries that are queued, so duplicates are coalesced
 
 	idcache *MapOf[string, string]
 }
@@ -61,7 +66,8 @@ func NewDiscordCache(ctx context.Context, logger *zap.Logger, config Config, met
 
 		idcache: &MapOf[string, string]{},
 
-		queueCh: make(chan QueueEntry, 250),
+		queueCh: make(chan QueueEntry, SyncMemberQueueSize),
+		pending: &MapOf[QueueEntry, time.Time]{},
 	}
 }
 
@@ -87,26 +93,25 @@ func (c *DiscordCache) Start() {
 					zap.String("discord_id", entry.DiscordID),
 					zap.String("guild_id", entry.GuildID),
 				)
+				if enqueuedAt, ok := c.pending.LoadAndDelete(entry); ok {
+					c.nk.MetricsTimerRecord("discord_cache_sync_member_queue_wait", nil, time.Since(enqueuedAt))
+				}
+				c.nk.MetricsGaugeSet("discord_cache_sync_member_queue_depth", nil, float64(len(c.queueCh)))
+
 				if _, ok := queueCooldowns[entry]; ok {
 					continue
 				}
 
 				queueCooldowns[entry] = time.Now().Add(time.Second * 10)
 
-				if err := c.syncMember(c.ctx, logger, entry.DiscordID, entry.GuildID); err != nil {
-					logger.Warn("Error syncing guild group member", zap.Error(err))
-				}
-				logger.Debug("Synced guild group member")
+				c.syncQueuedMember(logger, entry)
 
 			case <-cooldownTicker.C:
 
 				for entry, t := range queueCooldowns {
 					if time.Now().After(t) {
 						delete(queueCooldowns, entry)
-						if err := c.syncMember(c.ctx, logger, entry.DiscordID, entry.GuildID); err != nil {
-							logger.Warn("Error syncing guild group member", zap.Error(err))
-						}
-						logger.Debug("Synced guild group member")
+						c.syncQueuedMember(logger, entry)
 					}
 				}
 			}
@@ -160,13 +165,34 @@ func (c *DiscordCache) Start() {
 	c.logger.Info("Starting Discord cache")
 }
 
-// Queue a user for caching/updating.
+// syncQueuedMember syncs the queued member, recording how long the sync took.
+func (c *DiscordCache) syncQueuedMember(logger *zap.Logger, entry QueueEntry) {
+	startTime := time.Now()
+	err := c.syncMember(c.ctx, logger, entry.DiscordID, entry.GuildID)
+	c.nk.MetricsTimerRecord("discord_cache_sync_member_latency", map[string]string{"success": fmt.Sprintf("%t", err == nil)}, time.Since(startTime))
+	if err != nil {
+		logger.Warn("Error syncing guild group member", zap.Error(err))
+		return
+	}
+	logger.Debug("Synced guild group member")
+}
+
+// Queue a user for caching/updating. A user that is already queued for the guild is not queued again, and
+// the entry is dropped if the queue is full.
 func (c *DiscordCache) QueueSyncMember(guildID, discordID string) {
+	entry := QueueEntry{GuildID: guildID, DiscordID: discordID}
+	if _, loaded := c.pending.LoadOrStore(entry, time.Now()); loaded {
+		c.nk.MetricsCounterAdd("discord_cache_sync_member_coalesced", nil, 1)
+		return
+	}
+
 	select {
-	case c.queueCh <- QueueEntry{GuildID: guildID, DiscordID: discordID}:
-		// Success
+	case c.queueCh <- entry:
+		c.nk.MetricsGaugeSet("discord_cache_sync_member_queue_depth", nil, float64(len(c.queueCh)))
 	default:
 		// Queue is full
+		c.pending.Delete(entry)
+		c.nk.MetricsCounterAdd("discord_cache_sync_member_dropped", nil, 1)
 		c.logger.Warn("Queue is full; dropping entry", zap.String("discord_id", discordID), zap.String("guild_id", guildID))
 	}
 }
@@ -269,6 +295,9 @@ func (c *DiscordCache) syncMember(ctx context.Context, logger *zap.Logger, disco
 		return fmt.Errorf("error building evr account: %w", err)
 	}
 
+	// Sync from the current memberships.
+	InvalidateUserGuildGroups(evrAccount.ID())
+
 	groups, err := UserGuildGroupsList(ctx, c.nk, c.DiscordIDToUserID(discordID))
 	if err != nil {
 		return fmt.Errorf("error getting user guild groups: %w", err)
@@ -280,6 +309,7 @@ func (c *DiscordCache) syncMember(ctx context.Context, logger *zap.Logger, disco
 		if err := c.nk.GroupUserJoin(ctx, groupID, evrAccount.ID(), evrAccount.Username()); err != nil {
 			return fmt.Errorf("error joining group: %w", err)
 		}
+		InvalidateUserGuildGroups(evrAccount.ID())
 
 		groups, err = UserGuildGroupsList(ctx, c.nk, c.DiscordIDToUserID(discordID))
 		if err != nil {
@@ -511,13 +541,14 @@ func (d *DiscordCache) updateGuild(ctx context.Context, logger *zap.Logger, guil
 		}
 
 		// Update the group
-		md.RulesText = "No #rules channel found. Please create the channel and set the topic to the rules."
-
-		for _, channel := range guild.Channels {
-			if channel.Type == discordgo.ChannelTypeGuildText && channel.Name == "rules" {
-				md.RulesText = channel.Topic
-				break
+		if rulesText, err := d.guildRulesText(guild); err != nil {
+			// Keep the existing rules, rather than failing the update.
+			logger.Warn("Failed to get guild channels", zap.String("guild_id", guild.ID), zap.Error(err))
+			if md.RulesText == "" {
+				md.RulesText = DefaultGuildRulesText
 			}
+		} else {
+			md.RulesText = rulesText
 		}
 
 		if err := d.nk.GroupUpdate(ctx, groupID, SystemUserID, guild.Name, botUserID, GuildGroupLangTag, guild.Description, guild.IconURL("512"), true, md.MarshalMap(), 100000); err != nil {
@@ -528,6 +559,31 @@ func (d *DiscordCache) updateGuild(ctx context.Context, logger *zap.Logger, guil
 	return nil
 }
 
+const DefaultGuildRulesText = "No #rules channel found. Please create the channel and set the topic to the rules."
+
+// guildRulesText returns the topic of the guild's #rules channel. Guild update events do not include the channels, so they are loaded from the state, or the API.
+func (d *DiscordCache) guildRulesText(guild *discordgo.Guild) (string, error) {
+	channels := guild.Channels
+	if len(channels) == 0 && d.dg.State != nil {
+		if g, err := d.dg.State.Guild(guild.ID); err == nil {
+			channels = g.Channels
+		}
+	}
+	if len(channels) == 0 {
+		var err error
+		if channels, err = d.dg.GuildChannels(guild.ID); err != nil {
+			return "", err
+		}
+	}
+
+	for _, channel := range channels {
+		if channel.Type == discordgo.ChannelTypeGuildText && channel.Name == "rules" {
+			return channel.Topic, nil
+		}
+	}
+	return DefaultGuildRulesText, nil
+}
+
 func (d *DiscordCache) handleGuildCreate(logger *zap.Logger, s *discordgo.Session, e *discordgo.GuildCreate) error {
 	ctx, cancel := context.WithTimeout(d.ctx, time.Second*5)
 	defer cancel()
@@ -638,6 +694,7 @@ func (d *DiscordCache) GuildGroupMemberRemove(ctx context.Context, guildID, disc
 			return fmt.Errorf("error removing user from group: %w", err)
 		}
 	}
+	InvalidateUserGuildGroups(userID)
 
 	delete(md.GroupDisplayNames, groupID)
 	if md.GetActiveGroupID().String() == groupID {
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"go.uber.org/zap"
+)
+
+type syncQueueTestModule struct {
+	runtime.NakamaModule
+	counters map[string]int64
+}
+
+func (m *syncQueueTestModule) MetricsCounterAdd(name string, tags map[string]string, delta int64) {
+	m.counters[name] += delta
+}
+
+func (m *syncQueueTestModule) MetricsGaugeSet(name string, tags map[string]string, value float64) {}
+
+func (m *syncQueueTestModule) MetricsTimerRecord(name string, tags map[string]string, value time.Duration) {
+}
+
+func TestDiscordCache_QueueSyncMember(t *testing.T) {
+	nk := &syncQueueTestModule{counters: make(map[string]int64)}
+	c := &DiscordCache{
+		logger:  zap.NewNop(),
+		nk:      nk,
+		queueCh: make(chan QueueEntry, 2),
+		pending: &MapOf[QueueEntry, time.Time]{},
+	}
+
+	c.QueueSyncMember("guild1", "user1")
+	c.QueueSyncMember("guild1", "user1") // Coalesced
+	c.QueueSyncMember("guild2", "user1")
+	c.QueueSyncMember("guild3", "user1") // Dropped
+
+	if got := len(c.queueCh); got != 2 {
+		t.Errorf("len(queueCh) = %d, want 2", got)
+	}
+	if got := nk.counters["discord_cache_sync_member_coalesced"]; got != 1 {
+		t.Errorf("coalesced = %d, want 1", got)
+	}
+	if got := nk.counters["discord_cache_sync_member_dropped"]; got != 1 {
+		t.Errorf("dropped = %d, want 1", got)
+	}
+
+	// A dropped entry may be queued again once there is room.
+	<-c.queueCh
+	c.pending.Delete(QueueEntry{GuildID: "guild1", DiscordID: "user1"})
+	c.QueueSyncMember("guild3", "user1")
+	if got := len(c.queueCh); got != 2 {
+		t.Errorf("len(queueCh) = %d after requeue, want 2", got)
+	}
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/gofrs/uuid/v5"
+	"github.com/heroiclabs/nakama/v3/server/evr"
+)
+
+var (
+	// The combat loadout options, as accepted by the client profile validation.
+	combatWeaponOptions  = []string{"assault", "blaster", "rocket", "scout", "magnum", "smg", "chain", "rifle"}
+	combatGrenadeOptions = []string{"arc", "burst", "det", "stun", "loc"}
+	combatAbilityOptions = []string{"buff", "heal", "sensor", "shield", "wraith"}
+	combatArmOptions     = []string{"left", "right"}
+)
+
+func combatLoadoutSelectMenu(field, placeholder string, options []string, selected string) discordgo.ActionsRow {
+	menuOptions := make([]discordgo.SelectMenuOption, 0, len(options))
+	for _, o := range options {
+		menuOptions = append(menuOptions, discordgo.SelectMenuOption{
+			Label:   o,
+			Value:   o,
+			Default: o == selected,
+		})
+	}
+	return discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.SelectMenu{
+				CustomID:    "combat_loadout:" + field,
+				Placeholder: placeholder,
+				Options:     menuOptions,
+			},
+		},
+	}
+}
+
+func combatLoadoutMessage(client evr.ClientProfile) (string, []discordgo.MessageComponent) {
+	arm := combatArmOptions[client.CombatDominantHand%2]
+	content := fmt.Sprintf("**Combat Loadout**\nWeapon: `%s`\nGrenade: `%s`\nAbility: `%s`\nWeapon Arm: `%s`", client.CombatWeapon, client.CombatGrenade, client.CombatAbility, arm)
+	components := []discordgo.MessageComponent{
+		combatLoadoutSelectMenu("weapon", "<select a weapon>", combatWeaponOptions, client.CombatWeapon),
+		combatLoadoutSelectMenu("grenade", "<select a grenade>", combatGrenadeOptions, client.CombatGrenade),
+		combatLoadoutSelectMenu("ability", "<select an ability>", combatAbilityOptions, client.CombatAbility),
+		combatLoadoutSelectMenu("weaponarm", "<select a weapon arm>", combatArmOptions, arm),
+	}
+	return content, components
+}
+
+// setCombatLoadout validates and applies a combat loadout selection to the user's profile.
+func (d *DiscordAppBot) setCombatLoadout(ctx context.Context, userID string, field string, value string) (*GameProfileData, error) {
+	uid := uuid.FromStringOrNil(userID)
+	profile, err := d.profileRegistry.Load(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load profile: %w", err)
+	}
+
+	client := profile.GetClient()
+	switch field {
+	case "weapon":
+		if !slices.Contains(combatWeaponOptions, value) {
+			return nil, fmt.Errorf("invalid weapon `%s`", value)
+		}
+		client.CombatWeapon = value
+	case "grenade":
+		if !slices.Contains(combatGrenadeOptions, value) {
+			return nil, fmt.Errorf("invalid grenade `%s`", value)
+		}
+		client.CombatGrenade = value
+	case "ability":
+		if !slices.Contains(combatAbilityOptions, value) {
+			return nil, fmt.Errorf("invalid ability `%s`", value)
+		}
+		client.CombatAbility = value
+	case "weaponarm":
+		idx := slices.Index(combatArmOptions, value)
+		if idx == -1 {
+			return nil, fmt.Errorf("invalid weapon arm `%s`", value)
+		}
+		client.CombatDominantHand = uint8(idx)
+	default:
+		return nil, fmt.Errorf("invalid loadout field `%s`", field)
+	}
+	profile.SetClient(client)
+
+	if err := d.profileRegistry.SaveAndCache(ctx, uid, profile); err != nil {
+		return nil, fmt.Errorf("failed to save profile: %w", err)
+	}
+	return profile, nil
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/binary"
@@ -11,7 +12,6 @@ import (
 	"net/http"
 	"regexp"
 	"slices"
-	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -20,10 +20,12 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/gofrs/uuid/v5"
 	"github.com/google/go-cmp/cmp"
+	"github.com/heroiclabs/nakama-common/api"
 	"github.com/heroiclabs/nakama-common/runtime"
 	"github.com/heroiclabs/nakama/v3/server/evr"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -46,13 +48,24 @@ type DiscordAppBot struct {
 	dg              *discordgo.Session
 
 	cache *DiscordCache
+	dms   *DirectMessageSender
 
-	debugChannels map[string]string // map[groupID]channelID
-	userID        string            // Nakama UserID of the bot
+	debugChannels *MapOf[string, string] // map[groupID]channelID
+	userID        string                 // Nakama UserID of the bot
 
 	prepareMatchRatePerMinute rate.Limit
 	prepareMatchBurst         int
 	prepareMatchRateLimiters  *MapOf[string, *rate.Limiter]
+	appealRateLimiters        *MapOf[string, *rate.Limiter] // map[userID]limiter
+	reportRateLimiters        *MapOf[string, *rate.Limiter] // map[userID]limiter
+	verificationRateLimiters  *MapOf[string, *rate.Limiter] // map[userID]limiter
+
+	registerCommandsMu   sync.Mutex // Ready is re-sent on reconnects
+	registerCommandsDone bool       // Set once the slash commands have been registered successfully
+
+	matchListGroup singleflight.Group // Collapses concurrent match listings into one call
+
+	regionStatusPinsMu sync.Mutex // Serializes updates to the stored region status pins
 }
 
 func NewDiscordAppBot(logger runtime.Logger, nk runtime.NakamaModule, db *sql.DB, metrics Metrics, pipeline *Pipeline, config Config, discordCache *DiscordCache, profileRegistry *ProfileRegistry, statusRegistry StatusRegistry, dg *discordgo.Session) (*DiscordAppBot, error) {
@@ -75,12 +88,16 @@ func NewDiscordAppBot(logger runtime.Logger, nk runtime.NakamaModule, db *sql.DB
 
 		cache: discordCache,
 
-		dg: dg,
+		dg:  dg,
+		dms: NewDirectMessageSender(dg),
 
 		prepareMatchRatePerMinute: 1,
 		prepareMatchBurst:         1,
 		prepareMatchRateLimiters:  &MapOf[string, *rate.Limiter]{},
-		debugChannels:             make(map[string]string),
+		appealRateLimiters:        &MapOf[string, *rate.Limiter]{},
+		reportRateLimiters:        &MapOf[string, *rate.Limiter]{},
+		verificationRateLimiters:  &MapOf[string, *rate.Limiter]{},
+		debugChannels:             &MapOf[string, string]{},
 	}
 
 	bot := dg
@@ -123,6 +140,11 @@ func NewDiscordAppBot(logger runtime.Logger, nk runtime.NakamaModule, db *sql.DB
 			logger.Error("Failed to register slash commands: %w", err)
 		}
 
+		appbot.loadDebugChannels(ctx, logger)
+
+		go appbot.runRegionStatusBoards(ctx, logger, RegionStatusBoardInterval)
+		go appbot.runSuspensionReconciler(ctx, logger, SuspensionReconcileInterval)
+
 		logger.Info("Bot `%s` ready in %d guilds", displayName, len(bot.State.Guilds))
 	})
 
@@ -134,6 +156,7 @@ func NewDiscordAppBot(logger runtime.Logger, nk runtime.NakamaModule, db *sql.DB
 	go func() {
 		updateTicker := time.NewTicker(1 * time.Minute)
 		defer updateTicker.Stop()
+		lastSampleTime := time.Time{}
 		for {
 			select {
 			case <-updateTicker.C:
@@ -144,18 +167,36 @@ func NewDiscordAppBot(logger runtime.Logger, nk runtime.NakamaModule, db *sql.DB
 				// Get all the matches
 				minSize := 2
 				maxSize := MatchLobbyMaxSize + 1
-				matches, err := nk.MatchList(ctx, 1000, true, "", &minSize, &maxSize, "*")
+				matches, err := appbot.listMatches(ctx, 1000, &minSize, &maxSize, "*")
 				if err != nil {
 					logger.WithField("err", err).Warn("Error fetching matches.")
 					continue
 				}
 				playerCount := 0
 				matchCount := 0
+				modeCounts := make(map[string]int)
 				for _, match := range matches {
 					playerCount += int(match.Size) - 1
 					matchCount++
 
+					label := MatchLabel{}
+					if err := json.Unmarshal([]byte(match.GetLabel().GetValue()), &label); err == nil {
+						modeCounts[label.Mode.String()] += int(match.Size) - 1
+					}
+				}
+
+				if time.Since(lastSampleTime) >= OccupancySampleInterval {
+					lastSampleTime = time.Now()
+					if err := RecordOccupancySample(ctx, nk, OccupancySample{
+						Timestamp: lastSampleTime.UTC(),
+						Players:   playerCount,
+						Matches:   matchCount,
+						Modes:     modeCounts,
+					}); err != nil {
+						logger.WithField("err", err).Warn("Failed to record occupancy sample")
+					}
 				}
+
 				status := fmt.Sprintf("with %d players in %d matches", playerCount, matchCount)
 				if err := bot.UpdateGameStatus(0, status); err != nil {
 					logger.WithField("err", err).Warn("Failed to update status")
@@ -172,12 +213,64 @@ func NewDiscordAppBot(logger runtime.Logger, nk runtime.NakamaModule, db *sql.DB
 	return &appbot, nil
 }
 
+const (
+	BulkAllocateMaxCount  = 10
+	MaxPrivateMatchRounds = 20
+	MaxCreateHoldSeconds  = 300
+	KickReasonMaxLength   = 80 // Limited by the length of the select menu's custom ID
+
+	MatchKickAllGraceSeconds = 10 // The time given to the match to shut down after the players are kicked
+
+	ServerReserveHoldDuration = 2 * time.Hour // The time a reserved server is held before it starts on its own
+
+	PlayerReportInterval = 10 * time.Minute // The rate at which a player may report others
+	PlayerReportBurst    = 3
+
+	LinkHeadsetRetryAttempts = 3
+	LinkHeadsetRetryBackoff  = 250 * time.Millisecond
+
+	MatchListTimeout = 10 * time.Second // The longest a shared match listing may run
+
+	SuspensionAppealInterval = 24 * time.Hour // The rate at which a player may appeal their suspensions
+	SuspensionAppealBurst    = 1
+
+	ResendVerificationInterval = 5 * time.Minute // The rate at which a player may resend their IP verification request
+	ResendVerificationBurst    = 1
+	ResendVerificationMaxAge   = 24 * time.Hour // Logins older than this are no longer resent for verification
+)
+
+// validateMatchRounds checks the number of rounds requested for a private match. 0 means the match has no round limit.
+func validateMatchRounds(mode evr.Symbol, rounds int) error {
+	if rounds < 0 || rounds > MaxPrivateMatchRounds {
+		return fmt.Errorf("rounds must be between 0 (no limit) and %d", MaxPrivateMatchRounds)
+	}
+	if rounds > 0 && (mode == evr.ModeArenaPublic || mode == evr.ModeCombatPublic || mode == evr.ModeSocialPublic) {
+		return fmt.Errorf("rounds may only be set for private matches; public matches use the configured round limit")
+	}
+	return nil
+}
+
 func (e *DiscordAppBot) loadPrepareMatchRateLimiter(userID, groupID string) *rate.Limiter {
 	key := strings.Join([]string{userID, groupID}, ":")
 	limiter, _ := e.prepareMatchRateLimiters.LoadOrStore(key, rate.NewLimiter(e.prepareMatchRatePerMinute, e.prepareMatchBurst))
 	return limiter
 }
 
+func (e *DiscordAppBot) loadAppealRateLimiter(userID string) *rate.Limiter {
+	limiter, _ := e.appealRateLimiters.LoadOrStore(userID, rate.NewLimiter(rate.Every(SuspensionAppealInterval), SuspensionAppealBurst))
+	return limiter
+}
+
+func (e *DiscordAppBot) loadReportRateLimiter(userID string) *rate.Limiter {
+	limiter, _ := e.reportRateLimiters.LoadOrStore(userID, rate.NewLimiter(rate.Every(PlayerReportInterval), PlayerReportBurst))
+	return limiter
+}
+
+func (e *DiscordAppBot) loadVerificationRateLimiter(userID string) *rate.Limiter {
+	limiter, _ := e.verificationRateLimiters.LoadOrStore(userID, rate.NewLimiter(rate.Every(ResendVerificationInterval), ResendVerificationBurst))
+	return limiter
+}
+
 var (
 	vrmlMap = map[string]string{
 		"p":  "VRML Season Preseason",
@@ -221,6 +314,10 @@ var (
 				},
 			},
 		},
+		{
+			Name:        "link-status",
+			Description: "Check the status of your headset links.",
+		},
 		{
 			Name:        "unlink-headset",
 			Description: "Unlink a headset from your discord account.",
@@ -250,6 +347,202 @@ var (
 			Name:        "reset-password",
 			Description: "Clear your echo password.",
 		},
+		{
+			Name:        "notify",
+			Description: "Get a DM when the matchmaker makes your match.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "enabled",
+					Description: "Send match notifications",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "self-unsuspend",
+			Description: "View your suspensions in this guild, and appeal them.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "appeal",
+					Description: "Your appeal, sent to the guild moderators",
+					Required:    false,
+					MaxLength:   1000,
+				},
+			},
+		},
+		{
+			Name:        "arena-stats",
+			Description: "View your arena stats.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The player to view (moderators only)",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "combat-stats",
+			Description: "View your combat stats.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The player to view (moderators only)",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "disconnect-user",
+			Description: "Disconnect all of a user's sessions.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The user to disconnect",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "reason",
+					Description: "The reason for the disconnect",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "login-link",
+			Description: "Get a one-time login code for a new headset.",
+		},
+		{
+			Name:        "set-default",
+			Description: "Set the guild's default mode and region for /create and /allocate. Omitted options are unchanged.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "mode",
+					Description: "Default game mode",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{
+							Name:  "Private Arena Match",
+							Value: "echo_arena_private",
+						},
+						{
+							Name:  "Private Combat Match",
+							Value: "echo_combat_private",
+						},
+						{
+							Name:  "Private Social Lobby",
+							Value: "social_2.0_private",
+						},
+						{
+							Name:  "Public Arena Match",
+							Value: "echo_arena",
+						},
+						{
+							Name:  "Public Combat Match",
+							Value: "echo_combat",
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "region",
+					Description: "Default region",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "reset",
+					Description: "Reset the defaults before applying the other options",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "arena-queue",
+			Description: "Start matchmaking for a public arena match from your game session.",
+		},
+		{
+			Name:        "combat-queue",
+			Description: "Start matchmaking for a public combat match from your game session.",
+		},
+		{
+			Name:        "arena-teamsize",
+			Description: "Set the team size of the guild's public arena matches.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "size",
+					Description: fmt.Sprintf("Players per team, %d-%d (leave blank to reset)", MinPublicTeamSize, MaxPublicTeamSize),
+					Required:    false,
+					MaxValue:    MaxPublicTeamSize,
+				},
+			},
+		},
+		{
+			Name:        "combat-teamsize",
+			Description: "Set the team size of the guild's public combat matches.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "size",
+					Description: fmt.Sprintf("Players per team, %d-%d (leave blank to reset)", MinPublicTeamSize, MaxPublicTeamSize),
+					Required:    false,
+					MaxValue:    MaxPublicTeamSize,
+				},
+			},
+		},
+		{
+			Name:        "trace",
+			Description: "Dump a user's session, matchmaking, party, and match context.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The user to trace",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "occupancy",
+			Description: "Show the player population over the last hours.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "hours",
+					Description: fmt.Sprintf("Number of hours to show (default %d)", OccupancyDefaultHours),
+					Required:    false,
+					MaxValue:    float64(OccupancyHistoryWindow / time.Hour),
+				},
+			},
+		},
+		{
+			Name:        "evr-symbol-cache",
+			Description: "Show the symbol cache, and optionally add tokens to it.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "add",
+					Description: "Comma-separated tokens to add to the cache",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "version",
+			Description: "Show the server's version lock and your game client's last reported build.",
+		},
+		{
+			Name:        "resend-verification",
+			Description: "Resend the verification request for your most recent login from a new location.",
+		},
 		{
 			Name:        "whoami",
 			Description: "Receive your account information (privately).",
@@ -260,11 +553,25 @@ var (
 					Description: "Include extra details",
 					Required:    false,
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "export",
+					Description: "Send the details as a file (for accounts too large to show)",
+					Required:    false,
+				},
 			},
 		},
 		{
 			Name:        "set-lobby",
 			Description: "Set your default lobby to this Discord server/guild.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "reset",
+					Description: "Clear your default lobby, so your largest guild is used at your next login.",
+					Required:    false,
+				},
+			},
 		},
 		{
 			Name:        "lookup",
@@ -288,6 +595,12 @@ var (
 					Description: "Partial name to use in search pattern",
 					Required:    true,
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "guild-only",
+					Description: "Only search this guild (default: true; global moderators only)",
+					Required:    false,
+				},
 			},
 		},
 		{
@@ -302,13 +615,19 @@ var (
 				},
 			},
 		},
-		/*
-			{
-				Name:        "kick",
-				Description: "Force user to go through community values in the social lobby.",
-				Options:     []*discordgo.ApplicationCommandOption{},
+		{
+			Name:        "kick",
+			Description: "Kick a player from your current match.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "reason",
+					Description: "Reason for the kick",
+					Required:    false,
+					MaxLength:   KickReasonMaxLength,
+				},
 			},
-		*/
+		},
 		{
 			Name:        "trigger-cv",
 			Description: "Force user to go through community values in the social lobby.",
@@ -328,6 +647,49 @@ var (
 				},
 			},
 		},
+		{
+			Name:        "report-player",
+			Description: "Report a player to this guild's moderators.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The player to report",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "reason",
+					Description: "What happened",
+					Required:    true,
+					MaxLength:   PlayerReportReasonMaxLength,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "match-id",
+					Description: "The match it happened in",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "spectate",
+			Description: "Spectate a player's public match (or a match by ID) in your next lobby.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The player to spectate",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "match-id",
+					Description: "The match to spectate",
+					Required:    false,
+				},
+			},
+		},
 		{
 			Name:        "join-player",
 			Description: "Join a player's session as a moderator.",
@@ -365,32 +727,91 @@ var (
 			},
 		},
 		{
-			Name:        "jersey-number",
-			Description: "Set your in-game jersey number.",
+			Name:        "set-display-name",
+			Description: "Override a player's in-game display name in every guild (global moderators only).",
 			Options: []*discordgo.ApplicationCommandOption{
 				{
-					Type:        discordgo.ApplicationCommandOptionInteger,
-					Name:        "number",
-					Description: "Your jersey number, that will be displayed when you select loadout number as your decal.",
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "Target user",
 					Required:    true,
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "name",
+					Description: "The display name to use (leave empty to clear the override)",
+					Required:    false,
+					MaxLength:   20,
+				},
 			},
 		},
 		{
-			Name:        "badges",
-			Description: "manage badge entitlements",
+			Name:        "purge-display-name-history",
+			Description: "Remove a player's display name history in this guild.",
 			Options: []*discordgo.ApplicationCommandOption{
 				{
-					Name:        "assign",
-					Description: "assign badges to a player",
-					Type:        discordgo.ApplicationCommandOptionSubCommand,
-					Options: []*discordgo.ApplicationCommandOption{
-						{
-							Type:        discordgo.ApplicationCommandOptionUser,
-							Name:        "user",
-							Description: "target user",
-							Required:    true,
-						},
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "Target user",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "keep",
+					Description: "Number of the most recent names to keep (default 0)",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "match-kick-all",
+			Description: "Kick every player from a match in this guild, and shut it down.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "match-id",
+					Description: "The match to clear",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "jersey-number",
+			Description: "Set your in-game jersey number.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "number",
+					Description: "Your jersey number, that will be displayed when you select loadout number as your decal.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "set-decal",
+					Description: "Also set your decal to loadout number, so the number is displayed.",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "combat-loadout",
+			Description: "View and select your Echo Combat loadout.",
+		},
+		{
+			Name:        "badges",
+			Description: "manage badge entitlements",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "assign",
+					Description: "assign badges to a player",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Name:        "user",
+							Description: "target user",
+							Required:    true,
+						},
 						{
 							Type:        discordgo.ApplicationCommandOptionString,
 							Name:        "badges",
@@ -401,9 +822,31 @@ var (
 				},
 			},
 		},
+		{
+			Name:        "queue-status",
+			Description: "Summarize the sessions that are currently matchmaking.",
+		},
+		{
+			Name:        "inactive-cleanup",
+			Description: "Report party groups whose members have been inactive, and optionally clear them.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "days",
+					Description: fmt.Sprintf("Days since any member was active (default: %d).", InactivePartyGroupDefaultDays),
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "clear",
+					Description: "Clear the party group of the inactive groups' members.",
+					Required:    false,
+				},
+			},
+		},
 		{
 			Name:        "stream-list",
-			Description: "list presences for a stream",
+			Description: "list presences for a stream (moderators: this guild's streams only)",
 			Options: []*discordgo.ApplicationCommandOption{
 				{
 					Type:        discordgo.ApplicationCommandOptionInteger,
@@ -475,6 +918,28 @@ var (
 				},
 			},
 		},
+		{
+			Name:        "account-merge",
+			Description: "Merge a secondary account into a primary account.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "primary",
+					Description: "Account to keep",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "secondary",
+					Description: "Account to merge and disable",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "guild-config",
+			Description: "Show this guild's configuration.",
+		},
 		{
 			Name:        "set-roles",
 			Description: "link roles to Echo VR features. Non-members can only join private matches.",
@@ -555,7 +1020,7 @@ var (
 					Type:        discordgo.ApplicationCommandOptionString,
 					Name:        "mode",
 					Description: "Game mode",
-					Required:    true,
+					Required:    false,
 					Choices: []*discordgo.ApplicationCommandOptionChoice{
 						{
 							Name:  "Echo Arena Private",
@@ -605,6 +1070,133 @@ var (
 				},
 			},
 		},
+		{
+			Name:        "server-reserve",
+			Description: "Reserve a game server for a match, without starting it.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "mode",
+					Description: "Game mode",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{
+							Name:  "Echo Arena Private",
+							Value: "echo_arena_private",
+						},
+						{
+							Name:  "Echo Combat Private",
+							Value: "echo_combat_private",
+						},
+						{
+							Name:  "Social Private",
+							Value: "social_2.0_private",
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "region",
+					Description: "Region to reserve the game server in",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "server-start",
+			Description: "Start a match on a reserved game server.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "match-id",
+					Description: "Match ID (or spark link ID)",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "server-region-override",
+			Description: "Override the regions a game server registers with.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "server-id",
+					Description: "The server ID of the game server.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "regions",
+					Description: "Comma-separated region codes (omit to clear the override).",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "server-allocate-bulk",
+			Description: "Allocate multiple sessions on game servers in a specific region",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "mode",
+					Description: "Game mode",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{
+							Name:  "Echo Arena Private",
+							Value: "echo_arena_private",
+						},
+						{
+							Name:  "Echo Combat Private",
+							Value: "echo_combat_private",
+						},
+						{
+							Name:  "Social Private",
+							Value: "social_2.0_private",
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "region",
+					Description: "Region to allocate the sessions in",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "count",
+					Description: fmt.Sprintf("Number of sessions to allocate (max %d)", BulkAllocateMaxCount),
+					Required:    true,
+					MaxValue:    BulkAllocateMaxCount,
+				},
+			},
+		},
+		{
+			Name:        "find-match",
+			Description: "Join the best open public lobby next, or start matchmaking if there is none.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "mode",
+					Description: "Game mode",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{
+							Name:  "Public Arena Match",
+							Value: "echo_arena",
+						},
+						{
+							Name:  "Public Combat Match",
+							Value: "echo_combat",
+						},
+						{
+							Name:  "Public Social Lobby",
+							Value: "social_2.0",
+						},
+					},
+				},
+			},
+		},
 		{
 			Name:        "create",
 			Description: "Create an EVR game session on a game server in a specific region",
@@ -613,7 +1205,7 @@ var (
 					Type:        discordgo.ApplicationCommandOptionString,
 					Name:        "mode",
 					Description: "Game mode",
-					Required:    true,
+					Required:    false,
 					Choices: []*discordgo.ApplicationCommandOptionChoice{
 						{
 							Name:  "Private Arena Match",
@@ -693,42 +1285,240 @@ var (
 						return choices
 					}(),
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "rounds",
+					Description: "Number of rounds after which the match ends (private matches only)",
+					Required:    false,
+					MaxValue:    MaxPrivateMatchRounds,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "hold-seconds",
+					Description: "How long to hold slots for your party while they join",
+					Required:    false,
+					MaxValue:    MaxCreateHoldSeconds,
+				},
 			},
 		},
 		{
-			Name:        "region-status",
-			Description: "Get the status of game servers in a specific region",
+			Name:        "lock-match",
+			Description: "Lock a match to prevent new players from joining.",
 			Options: []*discordgo.ApplicationCommandOption{
 				{
 					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "region",
-					Description: "Region to check the status of",
+					Name:        "match-id",
+					Description: "Match ID (or spark link ID)",
 					Required:    true,
 				},
 			},
 		},
 		{
-			Name:        "party",
-			Description: "Manage EchoVR parties.",
+			Name:        "unlock-match",
+			Description: "Unlock a match to allow new players to join.",
 			Options: []*discordgo.ApplicationCommandOption{
 				{
-					Name:        "group",
-					Description: "Set your matchmaking group name.",
-					Type:        discordgo.ApplicationCommandOptionSubCommand,
-					Options: []*discordgo.ApplicationCommandOption{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "match-id",
+					Description: "Match ID (or spark link ID)",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "match-label-edit",
+			Description: "Correct the regions or tags of a running match.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "match-id",
+					Description: "Match ID (or spark link ID)",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "regions",
+					Description: "Comma-separated regions to replace the match's regions (or `none` to clear).",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "tags",
+					Description: "Comma-separated tags to replace the match's tags (or `none` to clear).",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "region-status",
+			Description: "Get the status of game servers in a specific region",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "region",
+					Description: "Region to check the status of",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "all",
+					Description: "Show an overview of every region",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "cleanup",
+					Description: "Delete the tracked region status messages (developers only)",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
 						{
-							Type:        discordgo.ApplicationCommandOptionString,
-							Name:        "group-name",
-							Description: "Your matchmaking group name.",
-							Required:    true,
+							Name:  "This guild",
+							Value: "guild",
+						},
+						{
+							Name:  "All guilds",
+							Value: "global",
 						},
 					},
 				},
+			},
+		},
+		{
+			Name:        "set-region-status-channel",
+			Description: "Automatically post and update a region status board in a channel.",
+			Options: []*discordgo.ApplicationCommandOption{
 				{
-					Name:        "members",
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "region",
+					Description: "Region to post the status of",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionChannel,
+					Name:        "channel",
+					Description: "Channel to post the status board in (omit to remove the board)",
+					Required:    false,
+					ChannelTypes: []discordgo.ChannelType{
+						discordgo.ChannelTypeGuildText,
+					},
+				},
+			},
+		},
+		{
+			Name:        "set-audit-channel",
+			Description: "Set the channel that receives this guild's audit log.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionChannel,
+					Name:        "channel",
+					Description: "Channel to send the audit log to (omit to disable)",
+					Required:    false,
+					ChannelTypes: []discordgo.ChannelType{
+						discordgo.ChannelTypeGuildText,
+					},
+				},
+			},
+		},
+		{
+			Name:        "set-match-pacing",
+			Description: "Tune the round timing of this guild's public arena matches (0 restores the default).",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "round-duration",
+					Description: fmt.Sprintf("Length of a round in seconds (%d-%d)", MinRoundDurationSecs, MaxRoundDurationSecs),
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "after-goal",
+					Description: fmt.Sprintf("Pause after a goal in seconds (%d-%d)", MinAfterGoalDurationSecs, MaxAfterGoalDurationSecs),
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "pre-match-wait",
+					Description: fmt.Sprintf("Wait before the first catapult in seconds (%d-%d)", MinPreMatchWaitSecs, MaxPreMatchWaitSecs),
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "set-debug-channel",
+			Description: "Set the channel that receives debug output for this guild.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionChannel,
+					Name:        "channel",
+					Description: "Channel to send debug output to (omit to disable)",
+					Required:    false,
+					ChannelTypes: []discordgo.ChannelType{
+						discordgo.ChannelTypeGuildText,
+					},
+				},
+			},
+		},
+		{
+			Name:        "merge-party",
+			Description: "Move the members of another party group into yours.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "group-name",
+					Description: "The other party's group name.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "transfer-party-leader",
+			Description: "Make another member of your party the party leader.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The party member to make leader.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "party",
+			Description: "Manage EchoVR parties.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "group",
+					Description: "Set your matchmaking group name.",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "group-name",
+							Description: "Your matchmaking group name.",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Name:        "members",
 					Description: "See members of your party.",
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 				},
+				{
+					Name:        "status",
+					Description: "Status of your party.",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+				},
+				{
+					Name:        "leave",
+					Description: "Leave your party group.",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+				},
+				{
+					Name:        "help",
+					Description: "Help with party commands.",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+				},
 				/*
 					{
 						Name:        "invite",
@@ -770,39 +1560,11 @@ var (
 							},
 						},
 					},
-					{
-						Name:        "transfer",
-						Description: "Make another user the party leader.",
-						Type:        discordgo.ApplicationCommandOptionSubCommand,
-						Options: []*discordgo.ApplicationCommandOption{
-							{
-								Type:        discordgo.ApplicationCommandOptionUser,
-								Name:        "user-option",
-								Description: "User to transfer party to.",
-								Required:    true,
-							},
-						},
-					},
-					{
-						Name:        "help",
-						Description: "Help with party commands.",
-						Type:        discordgo.ApplicationCommandOptionSubCommand,
-					},
-					{
-						Name:        "status",
-						Description: "Status of your party.",
-						Type:        discordgo.ApplicationCommandOptionSubCommand,
-					},
 					{
 						Name:        "warp",
 						Description: "Warp your party to your lobby.",
 						Type:        discordgo.ApplicationCommandOptionSubCommand,
 					},
-					{
-						Name:        "leave",
-						Description: "Leave the party.",
-						Type:        discordgo.ApplicationCommandOptionSubCommand,
-					},
 				*/
 			},
 		},
@@ -834,7 +1596,7 @@ var (
 	}
 )
 
-// InitializeDiscordBot initializes the discord bot and synchronizes the guilds with nakama groups. It also registers the bot's handlers.
+// InitializeDiscordBot opens the discord bot's connection. The slash commands are registered once the bot is ready.
 func (d *DiscordAppBot) InitializeDiscordBot() error {
 
 	bot := d.dg
@@ -842,6 +1604,10 @@ func (d *DiscordAppBot) InitializeDiscordBot() error {
 		return nil
 	}
 
+	if err := bot.Open(); err != nil {
+		return fmt.Errorf("failed to open discord bot connection: %w", err)
+	}
+
 	return nil
 }
 
@@ -877,14 +1643,77 @@ func (d *DiscordAppBot) UnregisterCommands(ctx context.Context, logger runtime.L
 
 type DiscordCommandHandlerFn func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error
 
+// RegisterSlashCommands registers the command handlers and updates the slash commands. Once a call succeeds, later calls have no effect.
 func (d *DiscordAppBot) RegisterSlashCommands() error {
+	d.registerCommandsMu.Lock()
+	defer d.registerCommandsMu.Unlock()
+	if d.registerCommandsDone {
+		d.logger.Debug("Slash commands are already registered.")
+		return nil
+	}
+	if err := d.registerSlashCommands(); err != nil {
+		return err
+	}
+	d.registerCommandsDone = true
+	return nil
+}
+
+func (d *DiscordAppBot) registerSlashCommands() error {
 	ctx := d.ctx
 	nk := d.nk
 	db := d.db
 	dg := d.dg
 
+	matchLockHandlerFn := func(opCode SignalOpCode) DiscordCommandHandlerFn {
+		return func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			options := i.ApplicationCommandData().Options
+			if len(options) == 0 {
+				return errors.New("no options provided")
+			}
+
+			if user == nil {
+				return nil
+			}
+
+			matchID, err := d.parseMatchIDOption(options[0].StringValue())
+			if err != nil {
+				return err
+			}
+
+			label, err := MatchLabelByID(ctx, nk, matchID)
+			if err != nil || label == nil {
+				return errors.New("match not found")
+			}
+
+			if label.GetGroupID().String() != groupID {
+				return errors.New("match is not from this guild")
+			}
+
+			payload, err := SignalMatch(ctx, nk, matchID, opCode, nil)
+			if err != nil {
+				return fmt.Errorf("failed to signal match: %w", err)
+			}
+
+			if err := json.Unmarshal([]byte(payload), label); err != nil {
+				return fmt.Errorf("failed to unmarshal match label: %w", err)
+			}
+
+			state := "open"
+			if label.IsLocked() {
+				state = "closed"
+			}
+
+			_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("<@%s> set [%s](%s) match to %s.", user.ID, label.Mode.String(), d.sparkLink(label.ID), state), false)
+
+			return simpleInteractionResponse(s, i, fmt.Sprintf("Match `%s` is now **%s** to new players.", label.ID.UUID.String(), state))
+		}
+	}
+
 	commandHandlers := map[string]DiscordCommandHandlerFn{
 
+		"lock-match":   matchLockHandlerFn(SignalLockSession),
+		"unlock-match": matchLockHandlerFn(SignalUnlockSession),
+
 		"hash": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
 			options := i.ApplicationCommandData().Options
 			if len(options) == 0 {
@@ -951,6 +1780,7 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 				return errors.New("invalid link code: link code must be (4) letters long (i.e. ABCD)")
 			}
 
+			alreadyMember := false
 			if err := func() error {
 
 				// Exchange the link code for a device auth.
@@ -965,28 +1795,38 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 					if err != nil {
 						return fmt.Errorf("failed to authenticate (or create) user %s: %w", user.ID, err)
 					}
+				} else if memberships, err := GetGuildGroupMemberships(ctx, nk, userID); err != nil {
+					return fmt.Errorf("failed to get guild group memberships: %w", err)
+				} else {
+					_, alreadyMember = memberships[groupID]
 				}
 
 				if err := d.nk.GroupUserJoin(ctx, groupID, userID, user.Username); err != nil {
 					return fmt.Errorf("error joining group: %w", err)
 				}
+				InvalidateUserGuildGroups(userID)
 
-				if err := nk.LinkDevice(ctx, userID, ticket.XPID.Token()); err != nil {
+				// Linking can fail transiently under contention, so retry before reporting the failure.
+				if err := RetryWithBackoff(ctx, LinkHeadsetRetryAttempts, LinkHeadsetRetryBackoff, func() error {
+					return nk.LinkDevice(ctx, userID, ticket.XPID.Token())
+				}); err != nil {
 					return fmt.Errorf("failed to link headset: %w", err)
 				}
 
 				// Set the client IP as authorized in the LoginHistory
-				history, err := LoginHistoryLoad(ctx, nk, userID)
-				if err != nil {
-					return fmt.Errorf("failed to load login history: %w", err)
-				}
+				return RetryWithBackoff(ctx, LinkHeadsetRetryAttempts, LinkHeadsetRetryBackoff, func() error {
+					history, err := LoginHistoryLoad(ctx, nk, userID)
+					if err != nil {
+						return fmt.Errorf("failed to load login history: %w", err)
+					}
 
-				history.AuthorizeIP(ticket.ClientIP)
+					history.AuthorizeIP(ticket.ClientIP)
 
-				if err := LoginHistoryStore(ctx, nk, userID, history); err != nil {
-					return fmt.Errorf("failed to save login history: %w", err)
-				}
-				return nil
+					if err := LoginHistoryStore(ctx, nk, userID, history); err != nil {
+						return fmt.Errorf("failed to save login history: %w", err)
+					}
+					return nil
+				})
 			}(); err != nil {
 				logger.WithFields(map[string]interface{}{
 					"discord_id": user.ID,
@@ -996,7 +1836,17 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 				return err
 			}
 
-			content := "Your headset has been linked. Restart EchoVR."
+			guildName := "this guild"
+			if guild, err := s.Guild(i.GuildID); err != nil {
+				logger.Warn("Failed to get guild: %v", err)
+			} else {
+				guildName = fmt.Sprintf("**%s**", guild.Name)
+			}
+
+			content := fmt.Sprintf("Your headset has been linked, and you have joined %s. Restart EchoVR.", guildName)
+			if alreadyMember {
+				content = fmt.Sprintf("Your headset has been linked. You are already a member of %s. Restart EchoVR.", guildName)
+			}
 
 			d.cache.QueueSyncMember(i.GuildID, user.ID)
 
@@ -1009,136 +1859,150 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 				},
 			})
 		},
-		"kick": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
-			return fmt.Errorf("not implemented")
-			/*
-				response := &discordgo.InteractionResponse{
-					Type: discordgo.InteractionResponseChannelMessageWithSource,
-					Data: &discordgo.InteractionResponseData{
-						Content: "Select a player to kick",
-						Flags:   discordgo.MessageFlagsEphemeral,
-						Components: []discordgo.MessageComponent{
-							discordgo.ActionsRow{
-								Components: []discordgo.MessageComponent{
-									discordgo.SelectMenu{
-										CustomID:    "select",
-										Placeholder: "<select a player to kick>",
-										Options:     options,
-									},
-								},
-							},
-							discordgo.ActionsRow{
-								Components: []discordgo.MessageComponent{
-									discordgo.SelectMenu{
-										CustomID:    "trigger_cv",
-										Placeholder: "Send user through Community Values?",
-										Options: []discordgo.SelectMenuOption{
-											{
-												Label: "Yes",
-												Value: "yes",
-											},
-											{
-												Label: "No",
-												Value: "no",
-											},
-										},
-									},
-								},
-							},
-							discordgo.ActionsRow{
-								Components: []discordgo.MessageComponent{
-									discordgo.SelectMenu{
-										CustomID:    "reason",
-										Placeholder: "<select a reason>",
-										Options: []discordgo.SelectMenuOption{
-											{
-												Label: "Toxicity",
-												Value: "toxicity",
-											},
-											{
-												Label: "Poor Sportsmanship",
-												Value: "poor_sportsmanship",
-											},
-											{
-												Label: "Other (see below)",
-												Value: "custom_reason",
-											},
-										},
-									},
-								},
-							},
-							discordgo.ActionsRow{
-								Components: []discordgo.MessageComponent{
-									discordgo.TextInput{
-										CustomID:    "custom_reason_input",
-										Label:       "Custom Reason",
-										Style:       discordgo.TextInputParagraph,
-										Placeholder: "Enter custom reason here...",
-										Required:    false,
-									},
-								},
-							},
-						},
-					},
+		"login-link": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			// Authenticate/create an account.
+			if userID == "" {
+				var err error
+				userID, _, _, err = d.nk.AuthenticateCustom(ctx, user.ID, user.Username, true)
+				if err != nil {
+					return fmt.Errorf("failed to authenticate (or create) user %s: %w", user.ID, err)
 				}
-				return s.InteractionRespond(i.Interaction, response)
-				// Show the players currently in the same match as the user
-				// Get the user's current match
 
-					presences, err := nk.StreamUserList(StreamModeService, userID, "", StreamLabelMatchService, false, true)
-					if err != nil {
-						logger.Error("Failed to get user list", zap.Error(err))
-					}
+				if err := d.nk.GroupUserJoin(ctx, groupID, userID, user.Username); err != nil {
+					return fmt.Errorf("error joining group: %w", err)
+				}
+				InvalidateUserGuildGroups(userID)
+			}
 
-					if len(presences) == 0 {
-						if err := simpleInteractionResponse(s, i, "You are not in a match"); err != nil {
-							return
-						}
-					}
+			ticket, err := CreateLoginTicket(ctx, nk, userID)
+			if err != nil {
+				return fmt.Errorf("failed to create login ticket: %w", err)
+			}
 
-					// Get the match label
-					label, err := MatchLabelByID(ctx, d.nk, MatchIDFromStringOrNil(presences[0].GetStatus()))
-					if err != nil {
-						logger.Error("Failed to get match label", zap.Error(err))
-					}
+			content := strings.Join([]string{
+				fmt.Sprintf("Your one-time login code is `%s`. It expires <t:%d:R>.", ticket.Code, ticket.ExpiresAt.Unix()),
+				fmt.Sprintf("Add `&logincode=%s` to the end of the `loginservice_host` URL in the headset's EchoVR `config.json`, then start EchoVR.", ticket.Code),
+				"Do not share this code; it links the headset to your account.",
+			}, "\n")
 
-					if label == nil {
-						if err := simpleInteractionResponse(s, i, "You are not in a match"); err != nil {
-							return
-						}
-					}
+			return simpleInteractionResponse(s, i, content)
+		},
+		"link-status": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
 
-					choices = make([]*discordgo.ApplicationCommandOptionChoice, len(presences))
-					for i, player := range label.Players {
-						choices[i] = &discordgo.ApplicationCommandOptionChoice{
-							Name:  player.DisplayName,
-							Value: player.UserID,
-						}
-					}
-			*/
+			embed, err := d.linkStatusEmbed(ctx, userID)
+			if err != nil {
+				return err
+			}
+
+			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Flags:  discordgo.MessageFlagsEphemeral,
+					Embeds: []*discordgo.MessageEmbed{embed},
+				},
+			})
 		},
-		"unlink-headset": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
-			options := i.ApplicationCommandData().Options
-			if len(options) == 0 {
+		"kick": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
 
-				account, err := nk.AccountGetId(ctx, userID)
-				if err != nil {
-					logger.Error("Failed to get account", zap.Error(err))
-					return err
-				}
-				if len(account.Devices) == 0 {
-					return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-						Type: discordgo.InteractionResponseChannelMessageWithSource,
-						Data: &discordgo.InteractionResponseData{
-							Flags:   discordgo.MessageFlagsEphemeral,
-							Content: "No headsets are linked to this account.",
-						},
-					})
+			reason := ""
+			for _, o := range i.ApplicationCommandData().Options {
+				if o.Name == "reason" {
+					reason = strings.TrimSpace(o.StringValue())
 				}
+			}
 
-				loginHistory, err := LoginHistoryLoad(ctx, nk, userID)
-				if err != nil {
-					return err
+			// Show the players currently in the same match as the user
+			presences, err := nk.StreamUserList(StreamModeService, userID, "", StreamLabelMatchService, false, true)
+			if err != nil {
+				return fmt.Errorf("failed to get user list: %w", err)
+			}
+
+			var label *MatchLabel
+			for _, p := range presences {
+				if label, _ = MatchLabelByID(ctx, nk, MatchIDFromStringOrNil(p.GetStatus())); label != nil {
+					break
+				}
+			}
+
+			if label == nil {
+				return simpleInteractionResponse(s, i, "You are not in a match.")
+			}
+
+			if label.GetGroupID().String() != groupID {
+				return simpleInteractionResponse(s, i, "Your match is not from this guild.")
+			}
+
+			options := make([]discordgo.SelectMenuOption, 0, len(label.Players))
+			for _, p := range label.Players {
+				if p.UserID == userID || p.IsReservation {
+					continue
+				}
+				options = append(options, discordgo.SelectMenuOption{
+					Label:       p.DisplayName,
+					Value:       p.UserID,
+					Description: p.Username,
+				})
+				// Discord limits select menus to 25 options
+				if len(options) == 25 {
+					break
+				}
+			}
+
+			if len(options) == 0 {
+				return simpleInteractionResponse(s, i, "There are no other players in your match.")
+			}
+
+			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: "Select a player to kick",
+					Flags:   discordgo.MessageFlagsEphemeral,
+					Components: []discordgo.MessageComponent{
+						discordgo.ActionsRow{
+							Components: []discordgo.MessageComponent{
+								discordgo.SelectMenu{
+									CustomID:    "kick_player:" + reason,
+									Placeholder: "<select a player to kick>",
+									Options:     options,
+								},
+							},
+						},
+					},
+				},
+			})
+		},
+		"unlink-headset": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			options := i.ApplicationCommandData().Options
+			if len(options) == 0 {
+
+				account, err := nk.AccountGetId(ctx, userID)
+				if err != nil {
+					logger.Error("Failed to get account", zap.Error(err))
+					return err
+				}
+				if len(account.Devices) == 0 {
+					return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+						Type: discordgo.InteractionResponseChannelMessageWithSource,
+						Data: &discordgo.InteractionResponseData{
+							Flags:   discordgo.MessageFlagsEphemeral,
+							Content: "No headsets are linked to this account.",
+						},
+					})
+				}
+
+				loginHistory, err := LoginHistoryLoad(ctx, nk, userID)
+				if err != nil {
+					return err
 				}
 
 				options := make([]discordgo.SelectMenuOption, 0, len(account.Devices))
@@ -1232,6 +2096,7 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 
 			}
 			target := options[0].StringValue()
+			matchConfig := d.config.GetMatch()
 
 			// 1.1.1.1[:6792[-6820]]
 			parts := strings.SplitN(target, ":", 2)
@@ -1282,9 +2147,9 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 					}
 				}
 			} else {
-				// If no port range is specified, scan the default port
-				startPort = 6792
-				endPort = 6820
+				// If no port range is specified, scan the default port range
+				startPort = matchConfig.CheckServerPortStart
+				endPort = matchConfig.CheckServerPortEnd
 			}
 
 			// Do some basic validation
@@ -1298,8 +2163,8 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 			case startPort > endPort:
 				return errors.New("start port must be less than or equal to end port")
 
-			case endPort-startPort > 100:
-				return errors.New("port range must be less than or equal to 100")
+			case endPort-startPort > matchConfig.CheckServerMaxPortRange:
+				return fmt.Errorf("port range must be less than or equal to %d", matchConfig.CheckServerMaxPortRange)
 
 			case startPort < 1024:
 				return errors.New("start port must be greater than or equal to 1024")
@@ -1419,7 +2284,16 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 			if len(options) == 0 {
 				return errors.New("no options provided")
 			}
-			number := int(options[0].IntValue())
+			number := -1
+			setDecal := false
+			for _, o := range options {
+				switch o.Name {
+				case "number":
+					number = int(o.IntValue())
+				case "set-decal":
+					setDecal = o.BoolValue()
+				}
+			}
 			if number < 0 || number > 99 {
 				return errors.New("invalid number. Must be between 0 and 99")
 			}
@@ -1436,6 +2310,16 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 			// Update the jersey number
 			profile.SetJerseyNumber(number)
 
+			content := fmt.Sprintf("Your jersey number has been set to %d.", number)
+
+			// The number is only shown in-game when the decal is set to loadout number.
+			if setDecal {
+				profile.EquipJerseyNumberDecal()
+				content += " Your decal has been set to **loadout number**, so it will be displayed."
+			} else if !profile.JerseyNumberDisplayed() {
+				content += fmt.Sprintf("\nYour current decal (`%s`) does not display the number. Select **loadout number** as your decal in-game, or run this command again with `set-decal:true`.", profile.Server.EquippedCosmetics.Instances.Unified.Slots.Decal)
+			}
+
 			// Save the profile
 			if err := d.profileRegistry.SaveAndCache(ctx, uid, profile); err != nil {
 				return fmt.Errorf("failed to save profile: %w", err)
@@ -1446,7 +2330,29 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
 				Data: &discordgo.InteractionResponseData{
 					Flags:   discordgo.MessageFlagsEphemeral,
-					Content: fmt.Sprintf("Your jersey number has been set to %d", number),
+					Content: content,
+				},
+			})
+		},
+
+		"combat-loadout": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if userID == "" {
+				return errors.New("no user ID")
+			}
+
+			profile, err := d.profileRegistry.Load(ctx, uuid.FromStringOrNil(userID))
+			if err != nil {
+				return fmt.Errorf("failed to load profile: %w", err)
+			}
+
+			content, components := combatLoadoutMessage(profile.GetClient())
+
+			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Flags:      discordgo.MessageFlagsEphemeral,
+					Content:    content,
+					Components: components,
 				},
 			})
 		},
@@ -1487,50 +2393,104 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 
 				// Get the badge name
 				badgeCodestr := options[1].StringValue()
-				badgeCodes := strings.Split(strings.ToLower(badgeCodestr), ",")
-
-				changeset := make(map[string]int64, len(badgeCodes))
+				badgeCodes := make([]string, 0)
+				for _, c := range strings.Split(strings.ToLower(badgeCodestr), ",") {
+					if c = strings.TrimSpace(c); c != "" && !slices.Contains(badgeCodes, c) {
+						badgeCodes = append(badgeCodes, c)
+					}
+				}
 
+				groupNames := make([]string, 0, len(badgeCodes))
 				for _, c := range badgeCodes {
-
-					c := strings.TrimSpace(c)
-					if c == "" {
-						continue
-					}
 					groupName, ok := vrmlMap[c]
 					if !ok {
 						return status.Errorf(codes.InvalidArgument, "badge `%s` not found", c)
 					}
 
+					groupNames = append(groupNames, groupName)
+				}
+
+				// Only grant the badges the user does not already have
+				account, err := nk.AccountGetId(ctx, targetUserID)
+				if err != nil {
+					return status.Error(codes.Internal, "failed to get target account")
+				}
+				wallet := make(map[string]int64)
+				if account.GetWallet() != "" {
+					if err := json.Unmarshal([]byte(account.GetWallet()), &wallet); err != nil {
+						return status.Error(codes.Internal, "failed to unmarshal wallet")
+					}
+				}
+
+				assignment := &BadgeAssignment{
+					AssignerUserID:    userID,
+					AssignerDiscordID: user.ID,
+					TargetUserID:      targetUserID,
+					TargetDiscordID:   target.ID,
+					GroupID:           groupID,
+					BadgeCodes:        badgeCodes,
+					Granted:           make([]string, 0, len(groupNames)),
+					AlreadyOwned:      make([]string, 0),
+					AssignedAt:        time.Now().UTC(),
+				}
+
+				changeset := make(map[string]int64, len(groupNames))
+				for _, groupName := range groupNames {
+					if _, ok := changeset[groupName]; ok {
+						continue
+					}
+					if wallet[groupName] > 0 {
+						assignment.AlreadyOwned = append(assignment.AlreadyOwned, groupName)
+						continue
+					}
+					// Claim the badge first, so that concurrent assignments can not both grant it.
+					if claimed, err := BadgeGrantClaim(ctx, nk, assignment, groupName); err != nil {
+						_ = BadgeGrantRelease(ctx, nk, targetUserID, assignment.Granted)
+						return status.Error(codes.Internal, "failed to claim badge")
+					} else if !claimed {
+						assignment.AlreadyOwned = append(assignment.AlreadyOwned, groupName)
+						continue
+					}
 					changeset[groupName] = 1
+					assignment.Granted = append(assignment.Granted, groupName)
+				}
+
+				if len(changeset) == 0 {
+					return simpleInteractionResponse(s, i, fmt.Sprintf("User `%s` already has VRML cosmetics `%s`", target.Username, badgeCodestr))
 				}
 
-				assignerID := d.cache.DiscordIDToUserID(user.ID)
 				metadata := map[string]interface{}{
-					"assigner_id": assignerID,
+					"assigner_id": userID,
 					"discord_id":  target.ID,
 				}
 
-				nk.WalletUpdate(ctx, targetUserID, changeset, metadata, true)
+				if _, _, err := nk.WalletUpdate(ctx, targetUserID, changeset, metadata, true); err != nil {
+					if err := BadgeGrantRelease(ctx, nk, targetUserID, assignment.Granted); err != nil {
+						logger.WithField("error", err).Error("Failed to release badge claims")
+					}
+					return status.Error(codes.Internal, "failed to update wallet")
+				}
+
+				if err := BadgeAssignmentStore(ctx, nk, assignment); err != nil {
+					logger.WithField("error", err).Error("Failed to store badge assignment")
+				}
 
 				// Log the action
 				logger.WithFields(map[string]interface{}{
 					"badges":     badgeCodestr,
+					"granted":    assignment.Granted,
 					"user":       target.Username,
 					"discord_id": target.ID,
 					"assigner":   user.ID,
 				}).Debug("assign badges")
 
-				// Send a message to the channel
-
-				channel := "1232462244797874247"
-				_, err = s.ChannelMessageSend(channel, fmt.Sprintf("%s assigned VRML cosmetics `%s` to user `%s`", user.Mention(), badgeCodestr, target.Username))
-				if err != nil {
+				// Send a message to the guild's audit channel
+				if _, err := d.LogAuditMessage(ctx, groupID, fmt.Sprintf("%s assigned VRML cosmetics `%s` to user `%s`", user.Mention(), strings.Join(assignment.Granted, ", "), target.Username), false); err != nil {
 					logger.WithFields(map[string]interface{}{
 						"error": err,
 					}).Error("Failed to send badge channel update message")
 				}
-				simpleInteractionResponse(s, i, fmt.Sprintf("Assigned VRML cosmetics `%s` to user `%s`", badgeCodestr, target.Username))
+				simpleInteractionResponse(s, i, fmt.Sprintf("Assigned VRML cosmetics `%s` to user `%s`", strings.Join(assignment.Granted, ", "), target.Username))
 
 			case "set-vrml-username":
 				options = options[0].Options
@@ -1650,6 +2610,31 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 			}
 			return nil
 		},
+		"resend-verification": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if userID == "" {
+				return simpleInteractionResponse(s, i, "You do not have an account. Log in to EchoVR first.")
+			}
+
+			history, err := LoginHistoryLoad(ctx, nk, userID)
+			if err != nil {
+				return fmt.Errorf("failed to load login history: %w", err)
+			}
+
+			entry := history.LastUnauthorizedEntry(time.Now().Add(-ResendVerificationMaxAge))
+			if entry == nil {
+				return simpleInteractionResponse(s, i, "You have no recent logins waiting for verification.")
+			}
+
+			if !d.loadVerificationRateLimiter(userID).Allow() {
+				return simpleInteractionResponse(s, i, "A verification request was sent recently. Please check your DMs, or try again later.")
+			}
+
+			if err := d.SendIPApprovalRequest(ctx, userID, entry.ClientIP, nil); err != nil {
+				return fmt.Errorf("failed to send IP approval request: %w", err)
+			}
+
+			return simpleInteractionResponse(s, i, fmt.Sprintf("The verification request for the login from `%s` (<t:%d:R>) was sent to your DMs.", entry.ClientIP, entry.UpdatedAt.Unix()))
+		},
 		"whoami": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
 
 			if user == nil {
@@ -1658,16 +2643,34 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 			if member == nil {
 				return errors.New("this command must be used from a guild")
 			}
+			exportFile := false
+			for _, o := range i.ApplicationCommandData().Options {
+				if o.Name == "export" {
+					exportFile = o.BoolValue()
+				}
+			}
+
 			// check for the with-detail boolean option
 			d.cache.Purge(user.ID)
 			d.cache.QueueSyncMember(i.GuildID, user.ID)
 
-			err := d.handleProfileRequest(ctx, logger, nk, s, i, user.ID, user.Username, true, true)
+			err := d.handleProfileRequest(ctx, logger, nk, s, i, user.ID, user.Username, true, true, exportFile)
 			logger.Debug("whoami", zap.String("discord_id", user.ID), zap.String("discord_username", user.Username), zap.Error(err))
 			return err
 		},
 
 		"set-lobby": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userIDStr string, groupID string) error {
+			reset := false
+			for _, o := range i.ApplicationCommandData().Options {
+				if o.Name == "reset" {
+					reset = o.BoolValue()
+				}
+			}
+
+			if reset {
+				return d.resetActiveGroup(ctx, s, i, userIDStr)
+			}
+
 			if member == nil {
 				return fmt.Errorf("this command must be used from a guild")
 			}
@@ -1786,7 +2789,7 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 
 			d.cache.Purge(target.ID)
 
-			return d.handleProfileRequest(ctx, logger, nk, s, i, target.ID, target.Username, isGuildModerator, isGlobalModerator)
+			return d.handleProfileRequest(ctx, logger, nk, s, i, target.ID, target.Username, isGuildModerator, isGlobalModerator, false)
 		},
 		"search": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userIDStr string, groupID string) error {
 
@@ -1800,127 +2803,90 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 				return errors.New("no options provided")
 			}
 
-			partial := options[0].StringValue()
+			partial := ""
+			guildOnly := true
+			for _, o := range options {
+				switch o.Name {
+				case "pattern":
+					partial = o.StringValue()
+				case "guild-only":
+					guildOnly = o.BoolValue()
+				}
+			}
 
 			partial = sanitizeDisplayName(strings.ToLower(partial))
+			if partial == "" {
+				return simpleInteractionResponse(s, i, "Invalid search pattern.")
+			}
 
-			pattern := fmt.Sprintf(".*%s.*", partial)
-
-			if len(partial) <= 3 {
-				// exact match only
-				pattern = partial
+			if !guildOnly {
+				if isGlobalModerator, err := CheckSystemGroupMembership(ctx, db, userIDStr, GroupGlobalModerators); err != nil {
+					return errors.New("error checking global moderator status")
+				} else if !isGlobalModerator {
+					return simpleInteractionResponse(s, i, "You must be a global moderator to search all guilds.")
+				}
 			}
 
-			histories, err := DisplayNameCacheRegexSearch(ctx, nk, pattern, 10)
+			results, err := d.searchDisplayNames(ctx, partial, groupID, !guildOnly)
 			if err != nil {
 				logger.Error("Failed to search display name history", zap.Error(err))
-				return fmt.Errorf("failed to search display name history: %w", err)
+				return err
 			}
 
-			if len(histories) == 0 {
+			if len(results) == 0 {
 				return simpleInteractionResponse(s, i, "No results found")
 			}
 
-			exactOnly := false
-			/*
-				if len(histories) > 10 {
-
-					// limit to exact hits
-					exactOnly = true
-				}
-			*/
-
-			resultsByUserID := make(map[string][]DisplayNameHistoryEntry, len(histories))
-
-			for userID, journal := range histories {
-
-				// Only search this guild
-				history, ok := journal.Histories[groupID]
-				if !ok {
-					continue
-				}
-
-				for _, e := range history {
+			embed, components := searchResultsPage(partial, results, 0, !guildOnly)
 
-					if exactOnly && strings.ToLower(e.DisplayName) != partial {
-						continue
-					}
-
-					if !strings.Contains(strings.ToLower(e.DisplayName), partial) {
-						continue
-					}
-
-					resultsByUserID[userID] = append(resultsByUserID[userID], e)
-				}
+			// Send the response
+			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Flags:      discordgo.MessageFlagsEphemeral,
+					Embeds:     []*discordgo.MessageEmbed{embed},
+					Components: components,
+				},
+			})
+		},
+		"find-match": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			options := i.ApplicationCommandData().Options
+			if len(options) == 0 {
+				return simpleInteractionResponse(s, i, "no options provided")
 			}
 
-			if len(resultsByUserID) == 0 {
-				return simpleInteractionResponse(s, i, "No results found")
+			mode := evr.ToSymbol(options[0].StringValue())
+			switch mode {
+			case evr.ModeArenaPublic, evr.ModeCombatPublic, evr.ModeSocialPublic:
+			default:
+				return fmt.Errorf("invalid mode `%s`", mode)
 			}
 
-			// Sort each players results by update time
-			for userID, r := range resultsByUserID {
-				sort.Slice(r, func(i, j int) bool {
-					return r[i].UpdateTime.After(r[j].UpdateTime)
-				})
+			label, rttMs, err := d.handleFindMatch(ctx, logger, userID, mode)
+			if err != nil {
+				return err
+			}
 
-				// Remove older duplicates
-				seen := make(map[string]struct{})
-				unique := make([]DisplayNameHistoryEntry, 0, len(r))
-				for _, e := range r {
-					if _, ok := seen[e.DisplayName]; ok {
-						continue
-					}
-					seen[e.DisplayName] = struct{}{}
-					unique = append(unique, e)
+			if label == nil {
+				if mode == evr.ModeSocialPublic {
+					return simpleInteractionResponse(s, i, "No open social lobbies were found. One will be created when you load into the lobby.")
 				}
-				resultsByUserID[userID] = unique
-
-				// Limit the results to the most recent 10
+				// Fall back to matchmaking for the mode.
+				return d.handleQueueMatchmaking(ctx, logger, s, i, userID, mode)
 			}
 
-			// Create the embed
-			embed := &discordgo.MessageEmbed{
-				Title:  "Search Results for `" + partial + "`",
-				Color:  0x9656ce,
-				Fields: make([]*discordgo.MessageEmbedField, 0, len(resultsByUserID)),
+			if err := SetNextMatchID(ctx, nk, userID, label.ID, AnyTeam, ""); err != nil {
+				logger.Error("Failed to set next match ID", zap.Error(err))
+				return fmt.Errorf("failed to set next match ID: %w", err)
 			}
 
-			for userID, results := range resultsByUserID {
-
-				// Get the discord ID
-				discordID := d.cache.UserIDToDiscordID(userID)
-				if discordID == "" {
-					logger.Warn("Failed to get discord ID", zap.Error(err))
-					continue
-				}
-
-				embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-					Name:   "Player",
-					Value:  fmt.Sprintf("<@%s>", discordID),
-					Inline: true,
-				})
-
-				displayNames := make([]string, 0, len(results))
-				for _, r := range results {
-					displayNames = append(displayNames, fmt.Sprintf("%s <t:%d:R>", EscapeDiscordMarkdown(r.DisplayName), r.UpdateTime.UTC().Unix()))
-				}
-
-				embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-					Name:   "In-Game Names",
-					Value:  strings.Join(displayNames, "\n"),
-					Inline: false,
-				})
-			}
+			logger.WithFields(map[string]any{
+				"match_id": label.ID.String(),
+				"rtt_ms":   rttMs,
+			}).Info("Match found.")
 
-			// Send the response
-			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{
-					Flags:  discordgo.MessageFlagsEphemeral,
-					Embeds: []*discordgo.MessageEmbed{embed},
-				},
-			})
+			content := fmt.Sprintf("Found a %s match with %d/%d players (%dms). Click play or start matchmaking to join it.", mode.String(), label.PlayerCount, label.PlayerLimit, rttMs)
+			return simpleInteractionResponse(s, i, content)
 		},
 		"create": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
 			options := i.ApplicationCommandData().Options
@@ -1930,13 +2896,15 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 
 			}
 
-			if len(options) == 0 {
-				return simpleInteractionResponse(s, i, "no options provided")
+			md, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+			if err != nil {
+				return fmt.Errorf("failed to get guild group metadata: %w", err)
 			}
 
-			mode := evr.ModeArenaPrivate
-			region := evr.DefaultRegion
+			mode, region := md.DefaultModeRegion()
 			level := evr.LevelUnspecified
+			maxRounds := 0
+			holdSeconds := 0
 			for _, o := range options {
 				switch o.Name {
 				case "region":
@@ -1945,9 +2913,21 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 					mode = evr.ToSymbol(o.StringValue())
 				case "level":
 					level = evr.ToSymbol(o.StringValue())
+				case "rounds":
+					maxRounds = int(o.IntValue())
+				case "hold-seconds":
+					holdSeconds = int(o.IntValue())
 				}
 			}
 
+			if holdSeconds < 0 || holdSeconds > MaxCreateHoldSeconds {
+				return fmt.Errorf("hold-seconds must be between 0 and %d", MaxCreateHoldSeconds)
+			}
+
+			if err := validateMatchRounds(mode, maxRounds); err != nil {
+				return err
+			}
+
 			if levels, ok := evr.LevelsByMode[mode]; !ok {
 				return fmt.Errorf("invalid mode `%s`", mode)
 			} else if level != evr.LevelUnspecified && !slices.Contains(levels, level) {
@@ -1965,7 +2945,7 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 				"startTime": startTime,
 			})
 
-			label, rttMs, err := d.handleCreateMatch(ctx, logger, userID, i.GuildID, region, mode, level, startTime)
+			label, rttMs, err := d.handleCreateMatch(ctx, logger, userID, i.GuildID, region, mode, level, startTime, maxRounds, time.Duration(holdSeconds)*time.Second)
 			if err != nil {
 				return err
 			}
@@ -2007,6 +2987,15 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 			if err != nil {
 				logger.Error("Failed to get guild", zap.Error(err))
 			}
+
+			startField := &discordgo.MessageEmbedField{
+				Name:  "Match Start",
+				Value: matchStartStatus(label),
+			}
+			participantsField := &discordgo.MessageEmbedField{
+				Name:  "Participants",
+				Value: "No participants yet",
+			}
 			responseContent := &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
 				Data: &discordgo.InteractionResponseData{
@@ -2042,44 +3031,53 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 							},
 							{
 								Name:   "Spark Link",
-								Value:  fmt.Sprintf("[Spark Link](https://echo.taxi/spark://c/%s)", strings.ToUpper(label.ID.UUID.String())),
+								Value:  fmt.Sprintf("[Spark Link](%s)", d.sparkLink(label.ID)),
 								Inline: false,
 							},
-							{
-								Name:  "Participants",
-								Value: "No participants yet",
-							},
+							startField,
+							participantsField,
 						},
 					}},
 				},
 			}
 
 			go func() {
+				// The join button is posted to the channel, since the interaction response is only visible to the creator.
+				var joinMessage *discordgo.Message
 
 				// Monitor the match and update the interaction
+				updateTicker := time.NewTicker(15 * time.Second)
+				defer updateTicker.Stop()
+
 				for {
-					startCheckTimer := time.NewTicker(15 * time.Second)
-					updateTicker := time.NewTicker(30 * time.Second)
 					select {
-					case <-startCheckTimer.C:
+					case <-d.ctx.Done():
+						return
 					case <-updateTicker.C:
 					}
 
 					presences, err := d.nk.StreamUserList(StreamModeMatchAuthoritative, label.ID.UUID.String(), "", label.ID.Node, false, true)
 					if err != nil {
 						logger.Error("Failed to get user list", zap.Error(err))
+						continue
 					}
 					if len(presences) == 0 {
-						// Match is gone. update the response.
+						// Match is gone. update the response, and stop monitoring.
 						responseContent.Data.Embeds[0].Title = "Match Over"
 						responseContent.Data.Embeds[0].Description = "The match expired/ended."
 
+						if joinMessage != nil {
+							if err := s.ChannelMessageDelete(joinMessage.ChannelID, joinMessage.ID); err != nil {
+								logger.Warn("Failed to delete the join button", zap.Error(err))
+							}
+						}
+
 						if _, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 							Embeds: &responseContent.Data.Embeds,
 						}); err != nil {
 							logger.Error("Failed to update interaction", zap.Error(err))
-							return
 						}
+						return
 					}
 
 					// Update the list of players in the interaction response
@@ -2090,7 +3088,35 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 						}
 						players.WriteString(fmt.Sprintf("<@%s>\n", d.cache.UserIDToDiscordID(p.GetUserId())))
 					}
-					responseContent.Data.Embeds[0].Fields[4].Value = players.String()
+					participantsField.Value = players.String()
+
+					// Count down to the level load, then show the match as in progress.
+					if label, err := MatchLabelByID(ctx, d.nk, label.ID); err != nil {
+						logger.Warn("Failed to get match label", zap.Error(err))
+					} else {
+						startField.Value = matchStartStatus(label)
+					}
+
+					// Once the match is live, post a button to the channel so that others can (re)join it.
+					if len(presences) > 0 && joinMessage == nil {
+						joinMessage, err = s.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
+							Content: fmt.Sprintf("%s's %s is live.", user.Mention(), prettyName),
+							Components: []discordgo.MessageComponent{
+								discordgo.ActionsRow{
+									Components: []discordgo.MessageComponent{
+										discordgo.Button{
+											Label:    "Join This Match",
+											Style:    discordgo.PrimaryButton,
+											CustomID: "join_match:" + label.ID.String(),
+										},
+									},
+								},
+							},
+						})
+						if err != nil {
+							logger.Warn("Failed to post the join button", zap.Error(err))
+						}
+					}
 
 					if _, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 						Embeds: &responseContent.Data.Embeds,
@@ -2111,8 +3137,13 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 				return simpleInteractionResponse(s, i, "this command must be used from a guild")
 
 			}
-			mode := evr.ModeArenaPrivate
-			region := evr.DefaultRegion
+
+			md, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+			if err != nil {
+				return fmt.Errorf("failed to get guild group metadata: %w", err)
+			}
+
+			mode, region := md.DefaultModeRegion()
 			level := evr.LevelUnspecified
 			for _, o := range options {
 				switch o.Name {
@@ -2148,23 +3179,262 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 			}
 
 			logger.WithField("label", label).Info("Match prepared")
-			return simpleInteractionResponse(s, i, fmt.Sprintf("Match prepared with label ```json\n%s\n```\nhttps://echo.taxi/spark://c/%s", label.GetLabelIndented(), strings.ToUpper(label.ID.UUID.String())))
+			return simpleInteractionResponse(s, i, d.matchPreparedMessage(label))
 		},
-		"trigger-cv": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+		"server-reserve": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if member == nil {
+				return simpleInteractionResponse(s, i, "this command must be used from a guild")
+			}
 
-			options := i.ApplicationCommandData().Options
+			md, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+			if err != nil {
+				return fmt.Errorf("failed to get guild group metadata: %w", err)
+			}
 
-			if len(options) == 0 {
-				return errors.New("no options provided")
+			mode := evr.ModeArenaPrivate
+			_, region := md.DefaultModeRegion()
+			for _, o := range i.ApplicationCommandData().Options {
+				switch o.Name {
+				case "region":
+					region = evr.ToSymbol(o.StringValue())
+				case "mode":
+					mode = evr.ToSymbol(o.StringValue())
+				}
 			}
 
-			if user == nil {
-				return nil
+			if _, ok := evr.LevelsByMode[mode]; !ok {
+				return fmt.Errorf("invalid mode `%s`", mode)
 			}
 
-			target := options[0].UserValue(s)
+			// The match is held until it is started with /server-start (or a player joins it).
+			startTime := time.Now().Add(ServerReserveHoldDuration)
 
-			targetUserID := d.cache.DiscordIDToUserID(target.ID)
+			logger = logger.WithFields(map[string]interface{}{
+				"userID":  userID,
+				"guildID": i.GuildID,
+				"region":  region.String(),
+				"mode":    mode.String(),
+			})
+
+			label, _, err := d.handleAllocateMatch(ctx, logger, userID, i.GuildID, region, mode, evr.LevelUnspecified, startTime)
+			if err != nil {
+				return err
+			}
+
+			logger.WithField("label", label).Info("Server reserved")
+			_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("%s reserved a game server for a `%s` match (`%s`).", user.Mention(), mode.String(), label.ID.UUID.String()), false)
+
+			return simpleInteractionResponse(s, i, strings.Join([]string{
+				fmt.Sprintf("Reserved a game server for match `%s`.", label.ID.UUID.String()),
+				fmt.Sprintf("Start it with `/server-start match-id:%s`. Otherwise it starts on its own <t:%d:R>, or when a player joins.", label.ID.UUID.String(), label.StartTime.Unix()),
+			}, "\n"))
+		},
+		"server-start": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			options := i.ApplicationCommandData().Options
+			if len(options) == 0 {
+				return errors.New("no options provided")
+			}
+
+			if user == nil {
+				return nil
+			}
+
+			matchID, err := d.parseMatchIDOption(options[0].StringValue())
+			if err != nil {
+				return err
+			}
+
+			label, err := MatchLabelByID(ctx, nk, matchID)
+			if err != nil || label == nil {
+				return errors.New("match not found")
+			}
+
+			if label.GetGroupID().String() != groupID {
+				return errors.New("match is not from this guild")
+			}
+
+			if label.LobbyType == UnassignedLobby {
+				return errors.New("match has not been reserved")
+			}
+
+			if label.Started() {
+				return errors.New("match has already started")
+			}
+
+			if _, err := SignalMatch(ctx, nk, matchID, SignalStartSession, nil); err != nil {
+				return fmt.Errorf("failed to start match: %w", err)
+			}
+
+			_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("%s started [%s](%s) match.", user.Mention(), label.Mode.String(), d.sparkLink(label.ID)), false)
+
+			return simpleInteractionResponse(s, i, fmt.Sprintf("Match `%s` is starting.", label.ID.UUID.String()))
+		},
+		"server-region-override": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			var serverIDStr, regionsStr string
+			for _, o := range i.ApplicationCommandData().Options {
+				switch o.Name {
+				case "server-id":
+					serverIDStr = o.StringValue()
+				case "regions":
+					regionsStr = o.StringValue()
+				}
+			}
+
+			serverID, err := strconv.ParseUint(strings.TrimSpace(serverIDStr), 10, 64)
+			if err != nil {
+				return errors.New("invalid server ID")
+			}
+
+			// Global developers may override any server; guild allocators only the servers their guild owns.
+			isDeveloper, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers)
+			if err != nil {
+				return errors.New("failed to check group membership")
+			}
+			if !isDeveloper {
+				guildGroups, err := UserGuildGroupsList(ctx, nk, userID)
+				if err != nil {
+					return errors.New("failed to get guild groups")
+				}
+				g, ok := guildGroups[groupID]
+				if !ok || !g.PermissionsUser(userID).IsAllocator {
+					return errors.New("you must be a guild allocator to use this command")
+				}
+				if ok, err := d.serverOwnedByGuild(ctx, serverID, g); err != nil {
+					return err
+				} else if !ok {
+					return errors.New("allocators may only override the regions of online servers operated by this guild's server hosts")
+				}
+			}
+
+			regions := make([]evr.Symbol, 0)
+			for _, r := range strings.Split(regionsStr, ",") {
+				if r = strings.ToLower(strings.TrimSpace(r)); r != "" {
+					regions = append(regions, evr.ToSymbol(r))
+				}
+			}
+			if len(regions) > ServerRegionOverrideMaxRegions {
+				return fmt.Errorf("a server may have at most %d override regions", ServerRegionOverrideMaxRegions)
+			}
+
+			overrides, err := LoadServerRegionOverrides(ctx, nk)
+			if err != nil {
+				return err
+			}
+			overrides.Set(serverID, regions)
+			if err := StoreServerRegionOverrides(ctx, nk, overrides); err != nil {
+				return err
+			}
+
+			var content string
+			if len(regions) == 0 {
+				content = fmt.Sprintf("Cleared the region override for server `%d`.", serverID)
+			} else {
+				content = fmt.Sprintf("Set the regions of server `%d` to `%s`.", serverID, strings.Join(lo.Map(regions, func(r evr.Symbol, _ int) string { return r.String() }), ", "))
+			}
+			_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("%s: %s", user.Mention(), content), false)
+
+			return simpleInteractionResponse(s, i, content+" It applies the next time the server registers.")
+		},
+		"server-allocate-bulk": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			options := i.ApplicationCommandData().Options
+
+			if member == nil {
+				return simpleInteractionResponse(s, i, "this command must be used from a guild")
+			}
+
+			mode := evr.ModeArenaPrivate
+			region := evr.DefaultRegion
+			count := 1
+			for _, o := range options {
+				switch o.Name {
+				case "region":
+					region = evr.ToSymbol(o.StringValue())
+				case "mode":
+					mode = evr.ToSymbol(o.StringValue())
+				case "count":
+					count = int(o.IntValue())
+				}
+			}
+
+			if _, ok := evr.LevelsByMode[mode]; !ok {
+				return fmt.Errorf("invalid mode `%s`", mode)
+			}
+
+			if count < 1 || count > BulkAllocateMaxCount {
+				return fmt.Errorf("count must be between 1 and %d", BulkAllocateMaxCount)
+			}
+
+			logger = logger.WithFields(map[string]interface{}{
+				"userID":  userID,
+				"guildID": i.GuildID,
+				"region":  region.String(),
+				"mode":    mode.String(),
+				"count":   count,
+			})
+
+			// Allocations are rate limited, so the response is deferred.
+			if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Flags: discordgo.MessageFlagsEphemeral,
+				},
+			}); err != nil {
+				return err
+			}
+
+			limiter := d.loadPrepareMatchRateLimiter(userID, groupID)
+
+			links := make([]string, 0, count)
+			var allocateErr error
+			for n := 0; n < count; n++ {
+				// Wait for the rate limiter to allow the next allocation
+				if err := limiter.Wait(ctx); err != nil {
+					allocateErr = err
+					break
+				}
+
+				label, _, err := d.allocateMatch(ctx, logger, userID, i.GuildID, region, mode, evr.LevelUnspecified, time.Now())
+				if err != nil {
+					allocateErr = err
+					break
+				}
+				links = append(links, fmt.Sprintf("`%s` %s", label.ID.String(), d.sparkLink(label.ID)))
+			}
+
+			logger.WithField("prepared", len(links)).Info("Matches prepared")
+
+			content := fmt.Sprintf("Prepared %d/%d `%s` matches in `%s`:\n%s", len(links), count, mode.String(), region.String(), strings.Join(links, "\n"))
+			if allocateErr != nil {
+				content += fmt.Sprintf("\n\nStopped early: %s", allocateErr.Error())
+			}
+
+			// The response was deferred, so this edit is the only reply; an error must not trigger another response.
+			if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Content: &content,
+			}); err != nil {
+				logger.WithField("error", err).Warn("Failed to edit interaction response")
+			}
+			return nil
+		},
+		"trigger-cv": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+
+			options := i.ApplicationCommandData().Options
+
+			if len(options) == 0 {
+				return errors.New("no options provided")
+			}
+
+			if user == nil {
+				return nil
+			}
+
+			target := options[0].UserValue(s)
+
+			targetUserID := d.cache.DiscordIDToUserID(target.ID)
 			if targetUserID == "" {
 				return errors.New("failed to get target user ID")
 			}
@@ -2208,7 +3478,7 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 						if err := KickPlayerFromMatch(ctx, d.nk, label.ID, targetUserID); err != nil {
 							return err
 						}
-						_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("%s kicked player %s from [%s](https://echo.taxi/spark://c/%s) match.", user.Mention(), target.Mention(), label.Mode.String(), strings.ToUpper(label.ID.UUID.String())), false)
+						_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("%s kicked player %s from [%s](%s) match.", user.Mention(), target.Mention(), label.Mode.String(), d.sparkLink(label.ID)), false)
 						disconnectDelay = 15
 					}
 
@@ -2269,7 +3539,7 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 						if err := KickPlayerFromMatch(ctx, d.nk, label.ID, targetUserID); err != nil {
 							return err
 						}
-						_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("<@%s> kicked player <@%s> from [%s](https://echo.taxi/spark://c/%s) match.", user.ID, target.ID, label.Mode.String(), strings.ToUpper(label.ID.UUID.String())), false)
+						_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("<@%s> kicked player <@%s> from [%s](%s) match.", user.ID, target.ID, label.Mode.String(), d.sparkLink(label.ID)), false)
 						disconnectDelay = 15
 					}
 
@@ -2288,143 +3558,794 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 			}
 			return simpleInteractionResponse(s, i, fmt.Sprintf("Disconnected %d sessions.", cnt))
 		},
-		"join-player": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
-
+		"set-display-name": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
 			if user == nil {
 				return nil
 			}
 
-			options := i.ApplicationCommandData().Options
-			if len(options) == 0 {
-				return errors.New("no options provided")
+			var target *discordgo.User
+			name := ""
+			for _, o := range i.ApplicationCommandData().Options {
+				switch o.Name {
+				case "user":
+					target = o.UserValue(s)
+				case "name":
+					name = o.StringValue()
+				}
+			}
+			if target == nil {
+				return errors.New("no user provided")
+			}
+
+			// The override applies in every guild, so it is limited to global moderators.
+			if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalModerators); err != nil {
+				return errors.New("failed to check group membership")
+			} else if !ok {
+				return errors.New("you must be a global moderator to override a display name")
 			}
 
-			target := options[0].UserValue(s)
 			targetUserID := d.cache.DiscordIDToUserID(target.ID)
 			if targetUserID == "" {
 				return errors.New("failed to get target user ID")
 			}
 
-			presences, err := d.nk.StreamUserList(StreamModeService, targetUserID, "", StreamLabelMatchService, false, true)
-			if err != nil {
-				return err
+			override := ""
+			if name != "" {
+				if override = sanitizeDisplayName(name); override == "" {
+					return simpleInteractionResponse(s, i, fmt.Sprintf("`%s` is not a valid display name.", EscapeDiscordMarkdown(name)))
+				}
 			}
 
-			if len(presences) == 0 {
-				return simpleInteractionResponse(s, i, "No sessions found.")
+			md, err := GetAccountMetadata(ctx, nk, targetUserID)
+			if err != nil {
+				return fmt.Errorf("failed to get account metadata: %w", err)
 			}
-			presence := presences[0]
-			if label, _ := MatchLabelByID(ctx, d.nk, MatchIDFromStringOrNil(presence.GetStatus())); label != nil {
+			previous := md.DisplayNameOverride
+			md.DisplayNameOverride = override
 
-				if label.GetGroupID().String() != groupID {
-					return errors.New("user's match is not from this guild")
-				}
+			if err := nk.AccountUpdateId(ctx, targetUserID, "", md.MarshalMap(), md.GetActiveGroupDisplayName(), "", "", "", ""); err != nil {
+				return fmt.Errorf("failed to update account metadata: %w", err)
+			}
 
-				if err := SetNextMatchID(ctx, d.nk, userID, label.ID, Moderator, ""); err != nil {
-					return fmt.Errorf("failed to set next match ID: %w", err)
-				}
+			d.cache.QueueSyncMember(i.GuildID, target.ID)
 
-				_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("<@%s> join player <@%s> at [%s](https://echo.taxi/spark://c/%s) match.", user.ID, target.ID, label.Mode.String(), strings.ToUpper(label.ID.UUID.String())), false)
-				content := fmt.Sprintf("Joining %s [%s](https://echo.taxi/spark://c/%s) match next.", target.Mention(), label.Mode.String(), strings.ToUpper(label.ID.UUID.String()))
-				return simpleInteractionResponse(s, i, content)
+			content := fmt.Sprintf("<@%s> set the display name override of <@%s> to `%s` (was `%s`).", user.ID, target.ID, override, previous)
+			if override == "" {
+				content = fmt.Sprintf("<@%s> cleared the display name override of <@%s> (was `%s`).", user.ID, target.ID, previous)
 			}
-			return simpleInteractionResponse(s, i, "No match found.")
-		},
-		"set-roles": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
-			options := i.ApplicationCommandData().Options
+			_, _ = d.LogAuditMessage(ctx, groupID, content, false)
 
-			// Ensure the user is the owner of the guild
-			if user == nil || i.Member == nil || i.Member.User.ID == "" || i.GuildID == "" {
+			return simpleInteractionResponse(s, i, content+" It will take effect on their next login.")
+		},
+		"purge-display-name-history": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
 				return nil
 			}
 
-			guild, err := s.Guild(i.GuildID)
-			if err != nil || guild == nil {
-				return errors.New("failed to get guild")
+			var target *discordgo.User
+			keep := 0
+			for _, o := range i.ApplicationCommandData().Options {
+				switch o.Name {
+				case "user":
+					target = o.UserValue(s)
+				case "keep":
+					keep = int(o.IntValue())
+				}
+			}
+			if target == nil {
+				return errors.New("no user provided")
+			}
+			if keep < 0 {
+				return errors.New("keep must be 0 or more")
 			}
 
-			if guild.OwnerID != user.ID {
-				// Check if the user is a global developer
-				if ok, err := CheckSystemGroupMembership(ctx, db, userID, GroupGlobalDevelopers); err != nil {
-					return errors.New("failed to check group membership")
-				} else if !ok {
-					return errors.New("you do not have permission to use this command")
-				}
+			targetUserID := d.cache.DiscordIDToUserID(target.ID)
+			if targetUserID == "" {
+				return errors.New("failed to get target user ID")
 			}
 
-			// Get the metadata
-			metadata, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+			history, err := DisplayNameHistoryLoad(ctx, nk, targetUserID)
 			if err != nil {
-				return errors.New("failed to get guild group metadata")
-
-			}
-			roles := metadata.Roles
-			for _, o := range options {
-				roleID := o.RoleValue(s, guild.ID).ID
-				switch o.Name {
-				case "moderator":
-					roles.Moderator = roleID
-				case "serverhost":
-					roles.ServerHost = roleID
-				case "suspension":
-					roles.Suspended = roleID
-				case "member":
-					roles.Member = roleID
-				case "allocator":
-					roles.Allocator = roleID
-				case "is_linked":
-					roles.AccountLinked = roleID
-				}
+				return err
 			}
 
-			data, err := metadata.MarshalToMap()
-			if err != nil {
-				return fmt.Errorf("error marshalling group data: %w", err)
+			entries := history.Histories[groupID]
+			if len(entries) <= keep {
+				return simpleInteractionResponse(s, i, fmt.Sprintf("%s has %d display names in this guild's history; nothing to remove.", target.Mention(), len(entries)))
 			}
 
-			if err := nk.GroupUpdate(ctx, groupID, SystemUserID, "", "", "", "", "", false, data, 1000000); err != nil {
-				return fmt.Errorf("error updating group: %w", err)
+			names := make([]string, 0, len(entries)-keep)
+			for _, e := range entries[:len(entries)-keep] {
+				names = append(names, EscapeDiscordMarkdown(e.DisplayName))
 			}
 
-			return simpleInteractionResponse(s, i, "roles set!")
+			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Flags:   discordgo.MessageFlagsEphemeral,
+					Content: fmt.Sprintf("Remove %d display names of %s from this guild's history?\n%s", len(names), target.Mention(), strings.Join(names, ", ")),
+					Components: []discordgo.MessageComponent{
+						discordgo.ActionsRow{
+							Components: []discordgo.MessageComponent{
+								discordgo.Button{
+									Label:    "Purge",
+									Style:    discordgo.DangerButton,
+									CustomID: fmt.Sprintf("purge_display_names:%s:%d", targetUserID, keep),
+								},
+							},
+						},
+					},
+				},
+			})
 		},
-
-		"region-status": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
-			options := i.ApplicationCommandData().Options
-
+		"report-player": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
 			if user == nil {
 				return nil
 			}
 
-			regionStr := options[0].StringValue()
-			if regionStr == "" {
-				return errors.New("no region provided")
+			var target *discordgo.User
+			reason := ""
+			matchID := MatchID{}
+			for _, o := range i.ApplicationCommandData().Options {
+				switch o.Name {
+				case "user":
+					target = o.UserValue(s)
+				case "reason":
+					reason = strings.TrimSpace(o.StringValue())
+				case "match-id":
+					id, err := d.parseMatchIDOption(o.StringValue())
+					if err != nil {
+						return err
+					}
+					matchID = id
+				}
 			}
 
-			return d.createRegionStatusEmbed(ctx, logger, regionStr, i.Interaction.ChannelID, nil)
-		},
-		"stream-list": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
-			options := i.ApplicationCommandData().Options
+			if target == nil || reason == "" {
+				return errors.New("a user and a reason are required")
+			}
+			if len(reason) > PlayerReportReasonMaxLength {
+				reason = reason[:PlayerReportReasonMaxLength]
+			}
 
-			if user == nil {
-				return nil
+			targetUserID := d.cache.DiscordIDToUserID(target.ID)
+			if targetUserID == "" {
+				return errors.New("that player does not have an account")
+			}
+			if targetUserID == userID {
+				return errors.New("you cannot report yourself")
 			}
 
-			// Limit access to global developers
-			if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers); err != nil {
-				return errors.New("failed to check group membership")
-			} else if !ok {
-				return errors.New("you do not have permission to use this command")
+			if !d.loadReportRateLimiter(userID).Allow() {
+				return simpleInteractionResponse(s, i, "You are sending reports too quickly. Please try again later.")
 			}
 
-			var subject, subcontext, label string
-			var mode, limit int64
-			for _, o := range options {
-				switch o.Name {
-				case "mode":
-					mode = o.IntValue()
-				case "subject":
-					subject = o.StringValue()
+			report := PlayerReport{
+				ReporterID: userID,
+				GroupID:    groupID,
+				Reason:     reason,
+				CreatedAt:  time.Now().UTC(),
+			}
+
+			var label *MatchLabel
+			if !matchID.IsNil() {
+				label, _ = MatchLabelByID(ctx, nk, matchID)
+				report.MatchID = matchID.String()
+			}
+
+			reports, err := LoadPlayerReports(ctx, nk, targetUserID)
+			if err != nil {
+				return err
+			}
+			reports.Add(report)
+			if err := StorePlayerReports(ctx, nk, targetUserID, reports); err != nil {
+				return err
+			}
+
+			metadata, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+			if err != nil {
+				return fmt.Errorf("failed to get guild group metadata: %w", err)
+			}
+
+			channelID := metadata.ModerationChannelID
+			if channelID == "" {
+				channelID = metadata.AuditChannelID
+			}
+
+			embed := &discordgo.MessageEmbed{
+				Title:       "Player Report",
+				Description: EscapeDiscordMarkdown(reason),
+				Color:       0xff9900,
+				Fields: []*discordgo.MessageEmbedField{
+					{
+						Name:   "Player",
+						Value:  target.Mention(),
+						Inline: true,
+					},
+					{
+						Name:   "Reported By",
+						Value:  user.Mention(),
+						Inline: true,
+					},
+					{
+						Name:   "Reports In This Guild",
+						Value:  strconv.Itoa(reports.CountByGroup(groupID)),
+						Inline: true,
+					},
+				},
+				Timestamp: report.CreatedAt.Format(time.RFC3339),
+			}
+
+			if !matchID.IsNil() {
+				value := fmt.Sprintf("`%s` (no longer running)", matchID.UUID.String())
+				if label != nil {
+					value = fmt.Sprintf("[%s](%s) with %d players", label.Mode.String(), d.sparkLink(label.ID), label.GetPlayerCount())
+				}
+				embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+					Name:  "Match",
+					Value: value,
+				})
+			}
+
+			if channelID != "" {
+				if _, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+					Embeds:          []*discordgo.MessageEmbed{embed},
+					AllowedMentions: &discordgo.MessageAllowedMentions{},
+				}); err != nil {
+					logger.Warn("Failed to send player report to channel %s: %v", channelID, err)
+				}
+			}
+
+			return simpleInteractionResponse(s, i, fmt.Sprintf("Thank you. Your report of %s has been sent to the moderators.", target.Mention()))
+		},
+		"spectate": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			var target *discordgo.User
+			matchID := MatchID{}
+			for _, o := range i.ApplicationCommandData().Options {
+				switch o.Name {
+				case "user":
+					target = o.UserValue(s)
+				case "match-id":
+					id, err := d.parseMatchIDOption(o.StringValue())
+					if err != nil {
+						return err
+					}
+					matchID = id
+				}
+			}
+
+			if target != nil {
+				targetUserID := d.cache.DiscordIDToUserID(target.ID)
+				if targetUserID == "" {
+					return errors.New("failed to get target user ID")
+				}
+
+				presences, err := d.nk.StreamUserList(StreamModeService, targetUserID, "", StreamLabelMatchService, false, true)
+				if err != nil {
+					return err
+				}
+				if len(presences) == 0 {
+					return simpleInteractionResponse(s, i, fmt.Sprintf("%s is not in a match.", target.Mention()))
+				}
+				matchID = MatchIDFromStringOrNil(presences[0].GetStatus())
+			}
+
+			if matchID.IsNil() {
+				return errors.New("provide a user or a match ID to spectate")
+			}
+
+			label, err := MatchLabelByID(ctx, d.nk, matchID)
+			if err != nil || label == nil {
+				return simpleInteractionResponse(s, i, "No match found.")
+			}
+
+			if label.GetGroupID().String() != groupID {
+				return errors.New("that match is not from this guild")
+			}
+
+			if label.Mode != evr.ModeArenaPublic && label.Mode != evr.ModeCombatPublic {
+				return errors.New("only public arena and combat matches can be spectated")
+			}
+
+			if err := SetNextMatchID(ctx, d.nk, userID, label.ID, Spectator, ""); errors.Is(err, ErrNextMatchFull) {
+				return simpleInteractionResponse(s, i, "That match has no open spectator slots.")
+			} else if err != nil {
+				return fmt.Errorf("failed to set next match ID: %w", err)
+			}
+
+			return simpleInteractionResponse(s, i, fmt.Sprintf("Spectating [%s](%s) match next.", label.Mode.String(), d.sparkLink(label.ID)))
+		},
+		"match-kick-all": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			matchID, err := d.parseMatchIDOption(i.ApplicationCommandData().Options[0].StringValue())
+			if err != nil {
+				return err
+			}
+
+			label, err := MatchLabelByID(ctx, d.nk, matchID)
+			if err != nil || label == nil {
+				return simpleInteractionResponse(s, i, "No match found.")
+			}
+
+			if label.GetGroupID().String() != groupID {
+				return errors.New("that match is not from this guild")
+			}
+
+			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Flags:   discordgo.MessageFlagsEphemeral,
+					Content: fmt.Sprintf("Kick all %d players from the [%s](%s) match, and shut it down?", len(label.Players), label.Mode.String(), d.sparkLink(label.ID)),
+					Components: []discordgo.MessageComponent{
+						discordgo.ActionsRow{
+							Components: []discordgo.MessageComponent{
+								discordgo.Button{
+									Label:    "Kick All",
+									Style:    discordgo.DangerButton,
+									CustomID: fmt.Sprintf("match_kick_all:%s", label.ID.String()),
+								},
+							},
+						},
+					},
+				},
+			})
+		},
+		"join-player": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+
+			if user == nil {
+				return nil
+			}
+
+			options := i.ApplicationCommandData().Options
+			if len(options) == 0 {
+				return errors.New("no options provided")
+			}
+
+			target := options[0].UserValue(s)
+			targetUserID := d.cache.DiscordIDToUserID(target.ID)
+			if targetUserID == "" {
+				return errors.New("failed to get target user ID")
+			}
+
+			presences, err := d.nk.StreamUserList(StreamModeService, targetUserID, "", StreamLabelMatchService, false, true)
+			if err != nil {
+				return err
+			}
+
+			if len(presences) == 0 {
+				return simpleInteractionResponse(s, i, "No sessions found.")
+			}
+			presence := presences[0]
+			if label, _ := MatchLabelByID(ctx, d.nk, MatchIDFromStringOrNil(presence.GetStatus())); label != nil {
+
+				if label.GetGroupID().String() != groupID {
+					return errors.New("user's match is not from this guild")
+				}
+
+				if err := SetNextMatchID(ctx, d.nk, userID, label.ID, Moderator, ""); err != nil {
+					return fmt.Errorf("failed to set next match ID: %w", err)
+				}
+
+				_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("<@%s> join player <@%s> at [%s](%s) match.", user.ID, target.ID, label.Mode.String(), d.sparkLink(label.ID)), false)
+				content := fmt.Sprintf("Joining %s [%s](%s) match next.", target.Mention(), label.Mode.String(), d.sparkLink(label.ID))
+				return simpleInteractionResponse(s, i, content)
+			}
+			return simpleInteractionResponse(s, i, "No match found.")
+		},
+		"guild-config": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil || i.GuildID == "" {
+				return nil
+			}
+
+			guild, err := s.Guild(i.GuildID)
+			if err != nil || guild == nil {
+				return errors.New("failed to get guild")
+			}
+
+			// Ensure the user is the owner of the guild, or a global developer
+			if guild.OwnerID != user.ID {
+				if ok, err := CheckSystemGroupMembership(ctx, db, userID, GroupGlobalDevelopers); err != nil {
+					return errors.New("failed to check group membership")
+				} else if !ok {
+					return errors.New("you do not have permission to use this command")
+				}
+			}
+
+			metadata, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+			if err != nil {
+				return errors.New("failed to get guild group metadata")
+			}
+
+			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Flags:  discordgo.MessageFlagsEphemeral,
+					Embeds: []*discordgo.MessageEmbed{guildConfigEmbed(guild.Name, metadata)},
+				},
+			})
+		},
+		"set-roles": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			options := i.ApplicationCommandData().Options
+
+			// Ensure the user is the owner of the guild
+			if user == nil || i.Member == nil || i.Member.User.ID == "" || i.GuildID == "" {
+				return nil
+			}
+
+			guild, err := s.Guild(i.GuildID)
+			if err != nil || guild == nil {
+				return errors.New("failed to get guild")
+			}
+
+			if guild.OwnerID != user.ID {
+				// Check if the user is a global developer
+				if ok, err := CheckSystemGroupMembership(ctx, db, userID, GroupGlobalDevelopers); err != nil {
+					return errors.New("failed to check group membership")
+				} else if !ok {
+					return errors.New("you do not have permission to use this command")
+				}
+			}
+
+			// Get the metadata
+			metadata, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+			if err != nil {
+				return errors.New("failed to get guild group metadata")
+
+			}
+			roles := metadata.Roles
+			for _, o := range options {
+				roleID := o.RoleValue(s, guild.ID).ID
+				switch o.Name {
+				case "moderator":
+					roles.Moderator = roleID
+				case "serverhost":
+					roles.ServerHost = roleID
+				case "suspension":
+					roles.Suspended = roleID
+				case "member":
+					roles.Member = roleID
+				case "allocator":
+					roles.Allocator = roleID
+				case "is_linked":
+					roles.AccountLinked = roleID
+				}
+			}
+
+			data, err := metadata.MarshalToMap()
+			if err != nil {
+				return fmt.Errorf("error marshalling group data: %w", err)
+			}
+
+			if err := nk.GroupUpdate(ctx, groupID, SystemUserID, "", "", "", "", "", false, data, 1000000); err != nil {
+				return fmt.Errorf("error updating group: %w", err)
+			}
+
+			return simpleInteractionResponse(s, i, "roles set!")
+		},
+
+		"region-status": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			options := i.ApplicationCommandData().Options
+
+			if user == nil {
+				return nil
+			}
+
+			regionStr := ""
+			all := false
+			cleanup := ""
+			for _, o := range options {
+				switch o.Name {
+				case "region":
+					regionStr = strings.TrimSpace(o.StringValue())
+				case "all":
+					all = o.BoolValue()
+				case "cleanup":
+					cleanup = o.StringValue()
+				}
+			}
+
+			if cleanup != "" {
+				if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers); err != nil {
+					return errors.New("failed to check group membership")
+				} else if !ok {
+					return errors.New("you do not have permission to use this command")
+				}
+
+				guildID := i.GuildID
+				if cleanup == "global" {
+					guildID = ""
+				}
+				deleted, err := d.cleanupRegionStatusPins(ctx, logger, guildID)
+				if err != nil {
+					return fmt.Errorf("failed to clean up region status messages: %w", err)
+				}
+				return simpleInteractionResponse(s, i, fmt.Sprintf("Deleted %d region status message(s). Boards set with `/set-region-status-channel` will be reposted until they are removed.", deleted))
+			}
+
+			if all {
+				matches, err := d.listMatches(ctx, 1000, nil, nil, "")
+				if err != nil {
+					return fmt.Errorf("failed to list matches: %w", err)
+				}
+
+				return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+					Type: discordgo.InteractionResponseChannelMessageWithSource,
+					Data: &discordgo.InteractionResponseData{
+						Flags:  discordgo.MessageFlagsEphemeral,
+						Embeds: []*discordgo.MessageEmbed{regionOverviewEmbed(logger, matches)},
+					},
+				})
+			}
+
+			if regionStr == "" {
+				return errors.New("no region provided")
+			}
+
+			return d.createRegionStatusEmbed(ctx, logger, regionStr, i.Interaction.ChannelID, nil)
+		},
+		"set-region-status-channel": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			options := i.ApplicationCommandData().Options
+
+			// Ensure the user is the owner of the guild
+			if user == nil || i.Member == nil || i.Member.User.ID == "" || i.GuildID == "" {
+				return nil
+			}
+
+			guild, err := s.Guild(i.GuildID)
+			if err != nil || guild == nil {
+				return errors.New("failed to get guild")
+			}
+
+			if guild.OwnerID != user.ID {
+				// Check if the user is a global developer
+				if ok, err := CheckSystemGroupMembership(ctx, db, userID, GroupGlobalDevelopers); err != nil {
+					return errors.New("failed to check group membership")
+				} else if !ok {
+					return errors.New("you do not have permission to use this command")
+				}
+			}
+
+			var regionStr, channelID string
+			for _, o := range options {
+				switch o.Name {
+				case "region":
+					regionStr = strings.TrimSpace(o.StringValue())
+				case "channel":
+					channelID = o.ChannelValue(s).ID
+				}
+			}
+
+			if regionStr == "" {
+				return errors.New("no region provided")
+			}
+
+			metadata, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+			if err != nil {
+				return errors.New("failed to get guild group metadata")
+			}
+
+			// A board may only be added for a region that a game server is registered in; an existing one may always be removed.
+			if _, found := metadata.RegionStatusChannelIDs[regionStr]; channelID != "" || !found {
+				regions, err := d.registeredRegions(ctx)
+				if err != nil {
+					return err
+				}
+				if _, ok := regions[evr.ToSymbol(regionStr)]; !ok {
+					return fmt.Errorf("no game servers are registered in region `%s`", regionStr)
+				}
+			}
+
+			if metadata.RegionStatusChannelIDs == nil {
+				metadata.RegionStatusChannelIDs = make(map[string]string)
+			}
+
+			content := fmt.Sprintf("Region `%s` status board removed.", regionStr)
+			if channelID == "" {
+				delete(metadata.RegionStatusChannelIDs, regionStr)
+			} else {
+				metadata.RegionStatusChannelIDs[regionStr] = channelID
+				content = fmt.Sprintf("Region `%s` status board will be posted in <#%s>.", regionStr, channelID)
+			}
+
+			data, err := metadata.MarshalToMap()
+			if err != nil {
+				return fmt.Errorf("error marshalling group data: %w", err)
+			}
+
+			if err := nk.GroupUpdate(ctx, groupID, SystemUserID, "", "", "", "", "", false, data, 1000000); err != nil {
+				return fmt.Errorf("error updating group: %w", err)
+			}
+
+			return simpleInteractionResponse(s, i, content)
+		},
+		"set-audit-channel": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			options := i.ApplicationCommandData().Options
+
+			if user == nil || i.GuildID == "" {
+				return nil
+			}
+
+			guild, err := s.Guild(i.GuildID)
+			if err != nil || guild == nil {
+				return errors.New("failed to get guild")
+			}
+
+			// Limit access to the guild owner, and global developers
+			if guild.OwnerID != user.ID {
+				if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers); err != nil {
+					return errors.New("failed to check group membership")
+				} else if !ok {
+					return errors.New("you do not have permission to use this command")
+				}
+			}
+
+			channelID := ""
+			for _, o := range options {
+				if o.Name == "channel" {
+					channelID = o.ChannelValue(s).ID
+				}
+			}
+
+			metadata, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+			if err != nil {
+				return errors.New("failed to get guild group metadata")
+			}
+
+			metadata.AuditChannelID = channelID
+
+			data, err := metadata.MarshalToMap()
+			if err != nil {
+				return fmt.Errorf("error marshalling group data: %w", err)
+			}
+
+			if err := nk.GroupUpdate(ctx, groupID, SystemUserID, "", "", "", "", "", false, data, 1000000); err != nil {
+				return fmt.Errorf("error updating group: %w", err)
+			}
+
+			content := "Audit log disabled."
+			if channelID != "" {
+				content = fmt.Sprintf("The audit log will be sent to <#%s>.", channelID)
+				_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("%s set this as the audit log channel.", user.Mention()), false)
+			}
+
+			return simpleInteractionResponse(s, i, content)
+		},
+		"set-match-pacing": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			options := i.ApplicationCommandData().Options
+
+			// Ensure the user is the owner of the guild
+			if user == nil || i.Member == nil || i.Member.User.ID == "" || i.GuildID == "" {
+				return nil
+			}
+
+			guild, err := s.Guild(i.GuildID)
+			if err != nil || guild == nil {
+				return errors.New("failed to get guild")
+			}
+
+			if guild.OwnerID != user.ID {
+				// Check if the user is a global developer
+				if ok, err := CheckSystemGroupMembership(ctx, db, userID, GroupGlobalDevelopers); err != nil {
+					return errors.New("failed to check group membership")
+				} else if !ok {
+					return errors.New("you do not have permission to use this command")
+				}
+			}
+
+			metadata, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+			if err != nil {
+				return errors.New("failed to get guild group metadata")
+			}
+
+			// Only the options that were passed are changed.
+			pacing := MatchPacing{}
+			if metadata.MatchPacing != nil {
+				pacing = *metadata.MatchPacing
+			}
+			for _, o := range options {
+				switch o.Name {
+				case "round-duration":
+					pacing.RoundDurationSecs = int(o.IntValue())
+				case "after-goal":
+					pacing.AfterGoalDurationSecs = int(o.IntValue())
+				case "pre-match-wait":
+					pacing.PreMatchWaitSecs = int(o.IntValue())
+				}
+			}
+
+			if err := pacing.Validate(); err != nil {
+				return err
+			}
+
+			if pacing == (MatchPacing{}) {
+				metadata.MatchPacing = nil
+			} else {
+				metadata.MatchPacing = &pacing
+			}
+
+			data, err := metadata.MarshalToMap()
+			if err != nil {
+				return fmt.Errorf("error marshalling group data: %w", err)
+			}
+
+			if err := nk.GroupUpdate(ctx, groupID, SystemUserID, "", "", "", "", "", false, data, 1000000); err != nil {
+				return fmt.Errorf("error updating group: %w", err)
+			}
+
+			content := fmt.Sprintf("Public arena pacing: %s rounds, %s pause after goals, round clock starts %s after the match. New matches will use these timings.",
+				pacing.RoundDuration(), pacing.AfterGoalDuration(), pacing.PublicMatchWaitTime())
+			return simpleInteractionResponse(s, i, content)
+		},
+		"set-debug-channel": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			options := i.ApplicationCommandData().Options
+
+			if user == nil || i.GuildID == "" {
+				return nil
+			}
+
+			// Limit access to global developers
+			if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers); err != nil {
+				return errors.New("failed to check group membership")
+			} else if !ok {
+				return errors.New("you do not have permission to use this command")
+			}
+
+			channelID := ""
+			for _, o := range options {
+				if o.Name == "channel" {
+					channelID = o.ChannelValue(s).ID
+				}
+			}
+
+			metadata, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+			if err != nil {
+				return errors.New("failed to get guild group metadata")
+			}
+
+			metadata.DebugChannelID = channelID
+
+			data, err := metadata.MarshalToMap()
+			if err != nil {
+				return fmt.Errorf("error marshalling group data: %w", err)
+			}
+
+			if err := nk.GroupUpdate(ctx, groupID, SystemUserID, "", "", "", "", "", false, data, 1000000); err != nil {
+				return fmt.Errorf("error updating group: %w", err)
+			}
+
+			content := "Debug output disabled."
+			if channelID == "" {
+				d.debugChannels.Delete(groupID)
+			} else {
+				d.debugChannels.Store(groupID, channelID)
+				content = fmt.Sprintf("Debug output will be sent to <#%s>.", channelID)
+			}
+
+			return simpleInteractionResponse(s, i, content)
+		},
+		"stream-list": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			options := i.ApplicationCommandData().Options
+
+			if user == nil {
+				return nil
+			}
+
+			var subject, subcontext, label string
+			var mode uint8
+			var limit int64
+			for _, o := range options {
+				switch o.Name {
+				case "mode":
+					mode = uint8(o.IntValue())
+				case "subject":
+					subject = o.StringValue()
 				case "subcontext":
 					subcontext = o.StringValue()
 				case "label":
@@ -2434,82 +4355,803 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 				}
 			}
 
-			includeHidden := true
-			includeOffline := true
+			// Global developers may list any stream; guild moderators are limited to their own guild's streams.
+			isDeveloper, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers)
+			if err != nil {
+				return errors.New("failed to check group membership")
+			}
+
+			if !isDeveloper {
+				guildGroups, err := UserGuildGroupsList(ctx, nk, userID)
+				if err != nil {
+					return errors.New("failed to get guild groups")
+				}
+				if g, ok := guildGroups[groupID]; !ok || !g.PermissionsUser(userID).IsModerator {
+					return errors.New("you do not have permission to use this command")
+				}
+				if ok, err := d.guildStreamVisible(ctx, mode, subject, label, groupID); err != nil {
+					return err
+				} else if !ok {
+					return errors.New("guild moderators may only list this guild's match, party, and guild group streams")
+				}
+			}
+
+			includeHidden := true
+			includeOffline := true
+
+			presences, err := nk.StreamUserList(mode, subject, subcontext, label, includeHidden, includeOffline)
+			if err != nil {
+				return errors.New("failed to list stream users")
+
+			}
+
+			if !isDeveloper && mode == StreamModeParty {
+				// Only show the party members that belong to this guild.
+				filtered := presences[:0]
+				for _, p := range presences {
+					if ok, err := CheckGroupMembershipByID(ctx, d.db, p.GetUserId(), groupID, GuildGroupLangTag); err != nil {
+						return errors.New("failed to check group membership")
+					} else if ok {
+						filtered = append(filtered, p)
+					}
+				}
+				presences = filtered
+			}
+			if len(presences) == 0 {
+				return errors.New("no stream users found")
+			}
+			channel, err := s.UserChannelCreate(user.ID)
+			if err != nil {
+				return errors.New("failed to create user channel")
+			}
+			if err := simpleInteractionResponse(s, i, "Sending stream list to your DMs"); err != nil {
+				return errors.New("failed to send interaction response")
+			}
+			if limit == 0 {
+				limit = 10
+			}
+
+			var b strings.Builder
+			if len(presences) > int(limit) {
+				presences = presences[:limit]
+			}
+
+			type presenceMessage struct {
+				UserID    string
+				Username  string
+				SessionID string
+				Status    any
+			}
+
+			messages := make([]string, 0)
+			for _, presence := range presences {
+				m := presenceMessage{
+					UserID:    presence.GetUserId(),
+					Username:  presence.GetUsername(),
+					SessionID: presence.GetSessionId(),
+				}
+				// Try to unmarshal the status
+				status := make(map[string]any, 0)
+				if err := json.Unmarshal([]byte(presence.GetStatus()), &status); err != nil {
+					m.Status = presence.GetStatus()
+				}
+				m.Status = status
+
+				data, err := json.MarshalIndent(m, "", "  ")
+				if err != nil {
+					return errors.New("failed to marshal presence data")
+				}
+				if b.Len()+len(data) > 1900 {
+					messages = append(messages, b.String())
+					b.Reset()
+				}
+				_, err = b.WriteString(fmt.Sprintf("```json\n%s\n```\n", data))
+				if err != nil {
+					return errors.New("failed to write presence data")
+				}
+			}
+			messages = append(messages, b.String())
+
+			go func() {
+				if err := d.dms.Send(ctx, channel.ID, messages...); err != nil {
+					logger.Warn("Failed to send message", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+
+		"notify": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			enabled := false
+			for _, o := range i.ApplicationCommandData().Options {
+				if o.Name == "enabled" {
+					enabled = o.BoolValue()
+				}
+			}
+
+			settings, err := LoadMatchmakingSettings(ctx, nk, userID)
+			if err != nil {
+				return fmt.Errorf("failed to load matchmaking settings: %w", err)
+			}
+
+			settings.NotifyOnMatch = enabled
+
+			if err := StoreMatchmakingSettings(ctx, nk, userID, settings); err != nil {
+				return fmt.Errorf("failed to save matchmaking settings: %w", err)
+			}
+
+			if enabled {
+				return simpleInteractionResponse(s, i, "You will receive a DM when your match is made.")
+			}
+			return simpleInteractionResponse(s, i, "Match notifications disabled.")
+		},
+		"self-unsuspend": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil || member == nil {
+				return simpleInteractionResponse(s, i, "This command must be used from a guild.")
+			}
+
+			appeal := ""
+			for _, o := range i.ApplicationCommandData().Options {
+				if o.Name == "appeal" {
+					appeal = strings.TrimSpace(o.StringValue())
+				}
+			}
+
+			suspensions, err := d.activeSuspensions(ctx, userID, i.GuildID)
+			if err != nil {
+				return err
+			}
+
+			guildGroups, err := UserGuildGroupsList(ctx, nk, userID)
+			if err != nil {
+				return fmt.Errorf("failed to get guild groups: %w", err)
+			}
+			roleSuspended := false
+			if g, ok := guildGroups[groupID]; ok {
+				roleSuspended = g.PermissionsUser(userID).IsSuspended
+			}
+
+			if len(suspensions) == 0 && !roleSuspended {
+				return simpleInteractionResponse(s, i, "You are not suspended in this guild.")
+			}
+
+			lines := make([]string, 0, len(suspensions)+1)
+			for _, status := range suspensions {
+				reason := status.Reason
+				if reason == "" {
+					reason = "no reason given"
+				}
+				lines = append(lines, fmt.Sprintf("- `%s` until <t:%d:f> (<t:%d:R>): %s", status.RoleName, status.Expiry.Unix(), status.Expiry.Unix(), EscapeDiscordMarkdown(reason)))
+			}
+			if len(suspensions) == 0 {
+				lines = append(lines, "- You have the suspended role, but no expiry or reason was recorded.")
+			}
+			summary := strings.Join(lines, "\n")
+
+			if appeal == "" {
+				return simpleInteractionResponse(s, i, fmt.Sprintf("Your suspensions in this guild:\n%s\n\nTo appeal, use `/self-unsuspend appeal:<message>`.", summary))
+			}
+
+			if !d.loadAppealRateLimiter(userID).Allow() {
+				return simpleInteractionResponse(s, i, fmt.Sprintf("Your suspensions in this guild:\n%s\n\nYou have already sent an appeal recently. Please wait before sending another.", summary))
+			}
+
+			if _, err := d.LogAuditMessage(ctx, groupID, fmt.Sprintf("Suspension appeal from <@%s>:\n%s\n\n> %s", user.ID, summary, strings.ReplaceAll(appeal, "\n", "\n> ")), false); err != nil {
+				logger.Warn("Failed to log suspension appeal: %v", err)
+				return simpleInteractionResponse(s, i, "Your appeal could not be delivered. Please contact a moderator directly.")
+			}
+
+			return simpleInteractionResponse(s, i, fmt.Sprintf("Your suspensions in this guild:\n%s\n\nYour appeal has been sent to the moderators.", summary))
+		},
+		"arena-stats":  d.handleStatCard(StatGroupArena),
+		"combat-stats": d.handleStatCard(StatGroupCombat),
+		"disconnect-user": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			// Limit access to global developers
+			if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers); err != nil {
+				return errors.New("failed to check group membership")
+			} else if !ok {
+				return errors.New("you do not have permission to use this command")
+			}
+
+			var target *discordgo.User
+			reason := ""
+			for _, o := range i.ApplicationCommandData().Options {
+				switch o.Name {
+				case "user":
+					target = o.UserValue(s)
+				case "reason":
+					reason = o.StringValue()
+				}
+			}
+
+			if target == nil {
+				return errors.New("no user provided")
+			}
+
+			targetUserID := d.cache.DiscordIDToUserID(target.ID)
+			if targetUserID == "" {
+				return errors.New("target user not found")
+			}
+
+			cnt, err := DisconnectUserID(ctx, nk, targetUserID)
+			if err != nil {
+				logger.Warn("Failed to disconnect user", zap.Error(err))
+			}
+
+			_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("%s disconnected %s (%d sessions): %s", user.Mention(), target.Mention(), cnt, reason), false)
+
+			if err != nil {
+				return fmt.Errorf("disconnected %d sessions before failing: %w", cnt, err)
+			}
+
+			return simpleInteractionResponse(s, i, fmt.Sprintf("Disconnected %d sessions of %s.", cnt, target.Mention()))
+		},
+		"set-default": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil || i.GuildID == "" {
+				return nil
+			}
+
+			mode, region, reset := "", "", false
+			for _, o := range i.ApplicationCommandData().Options {
+				switch o.Name {
+				case "mode":
+					mode = o.StringValue()
+				case "region":
+					region = strings.TrimSpace(o.StringValue())
+				case "reset":
+					reset = o.BoolValue()
+				}
+			}
+
+			if mode != "" {
+				if _, ok := evr.LevelsByMode[evr.ToSymbol(mode)]; !ok {
+					return fmt.Errorf("invalid mode `%s`", mode)
+				}
+			}
+
+			if region != "" {
+				regions, err := d.registeredRegions(ctx)
+				if err != nil {
+					return err
+				}
+				if _, ok := regions[evr.ToSymbol(region)]; !ok {
+					return fmt.Errorf("no game servers are registered in region `%s`", region)
+				}
+			}
+
+			metadata, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+			if err != nil {
+				return fmt.Errorf("failed to get guild group metadata: %w", err)
+			}
+
+			// Only the options that were passed are changed.
+			if reset {
+				metadata.DefaultMode = ""
+				metadata.DefaultRegion = ""
+			}
+			if mode != "" {
+				metadata.DefaultMode = mode
+			}
+			if region != "" {
+				metadata.DefaultRegion = region
+			}
+
+			data, err := metadata.MarshalToMap()
+			if err != nil {
+				return fmt.Errorf("failed to marshal guild group metadata: %w", err)
+			}
+
+			if err := nk.GroupUpdate(ctx, groupID, SystemUserID, "", "", "", "", "", false, data, 1000000); err != nil {
+				return fmt.Errorf("failed to update guild group metadata: %w", err)
+			}
+
+			defaultMode, defaultRegion := metadata.DefaultModeRegion()
+			_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("%s set the default mode to `%s` and region to `%s`.", user.Mention(), defaultMode.String(), defaultRegion.String()), false)
+
+			return simpleInteractionResponse(s, i, fmt.Sprintf("Default mode is `%s` and region is `%s`.", defaultMode.String(), defaultRegion.String()))
+		},
+		"arena-queue": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+			return d.handleQueueMatchmaking(ctx, logger, s, i, userID, evr.ModeArenaPublic)
+		},
+		"combat-queue": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+			return d.handleQueueMatchmaking(ctx, logger, s, i, userID, evr.ModeCombatPublic)
+		},
+		"arena-teamsize": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil || i.GuildID == "" {
+				return nil
+			}
+			return d.handleSetPublicTeamSize(ctx, s, i, user, groupID, evr.ModeArenaPublic)
+		},
+		"combat-teamsize": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil || i.GuildID == "" {
+				return nil
+			}
+			return d.handleSetPublicTeamSize(ctx, s, i, user, groupID, evr.ModeCombatPublic)
+		},
+		"trace": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			// Limit access to global developers
+			if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers); err != nil {
+				return errors.New("failed to check group membership")
+			} else if !ok {
+				return errors.New("you do not have permission to use this command")
+			}
+
+			options := i.ApplicationCommandData().Options
+			if len(options) == 0 {
+				return errors.New("no options provided")
+			}
+			target := options[0].UserValue(s)
+
+			targetUserID := d.cache.DiscordIDToUserID(target.ID)
+			if targetUserID == "" {
+				return errors.New("target user not found")
+			}
+
+			data, err := json.MarshalIndent(d.traceUser(ctx, targetUserID), "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal trace: %w", err)
+			}
+
+			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Flags:   discordgo.MessageFlagsEphemeral,
+					Content: fmt.Sprintf("Trace of %s", target.Mention()),
+					Files: []*discordgo.File{
+						{
+							Name:        fmt.Sprintf("trace-%s.json", targetUserID),
+							ContentType: "application/json",
+							Reader:      bytes.NewReader(data),
+						},
+					},
+				},
+			})
+		},
+		"occupancy": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalModerators); err != nil {
+				return errors.New("failed to check group membership")
+			} else if !ok {
+				return errors.New("you do not have permission to use this command")
+			}
+
+			hours := OccupancyDefaultHours
+			for _, o := range i.ApplicationCommandData().Options {
+				if o.Name == "hours" {
+					hours = int(o.IntValue())
+				}
+			}
+			hours = max(1, min(hours, int(OccupancyHistoryWindow/time.Hour)))
+
+			history, err := LoadOccupancyHistory(ctx, nk)
+			if err != nil {
+				return err
+			}
+
+			start := time.Now().UTC().Truncate(time.Hour).Add(-time.Duration(hours-1) * time.Hour)
+
+			return simpleInteractionResponse(s, i, occupancySummary(history.Since(start), start, hours))
+		},
+		"evr-symbol-cache": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers); err != nil {
+				return errors.New("failed to check group membership")
+			} else if !ok {
+				return errors.New("you do not have permission to use this command")
+			}
 
-			presences, err := nk.StreamUserList(uint8(mode), subject, subcontext, label, includeHidden, includeOffline)
+			tokens := make([]string, 0)
+			for _, o := range i.ApplicationCommandData().Options {
+				if o.Name == "add" {
+					for _, t := range strings.Split(o.StringValue(), ",") {
+						if t = strings.TrimSpace(t); t != "" {
+							tokens = append(tokens, t)
+						}
+					}
+				}
+			}
+
+			added := evr.AddSymbolTokens(tokens...)
+			generated, runtimeCount := evr.SymbolCacheSize()
+
+			var b strings.Builder
+			fmt.Fprintf(&b, "Symbol cache: %d generated, %d added at runtime.\n", generated, runtimeCount)
+			if len(tokens) > 0 {
+				fmt.Fprintf(&b, "Added %d of %d tokens (the rest were already cached).\n", len(added), len(tokens))
+			}
+
+			if recent := evr.RecentSymbolTokens(20); len(recent) > 0 {
+				b.WriteString("Recently added:\n```\n")
+				for _, t := range recent {
+					fmt.Fprintf(&b, "%s %s\n", t.Symbol().HexString(), t)
+				}
+				b.WriteString("```")
+			}
+
+			return simpleInteractionResponse(s, i, b.String())
+		},
+		"version": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			versionLock := evr.Symbol(VersionLock)
+
+			fields := []*discordgo.MessageEmbedField{
+				{Name: "Server Version Lock", Value: fmt.Sprintf("`%s` (`%s`)", versionLock.HexString(), versionLock.Token()), Inline: false},
+			}
+
+			// The version lock is only sent when looking for a match, so report the last login's build for reference.
+			status := "No logins found."
+			if loginHistory, err := LoginHistoryLoad(ctx, nk, userID); err != nil {
+				logger.Warn("Failed to load login history: %v", err)
+			} else if e := loginHistory.LastEntry(); e != nil && e.LoginData != nil {
+				status = fmt.Sprintf("Build `%d`, lobby version `%s` (<t:%d:R>)", e.LoginData.BuildVersion, evr.Symbol(e.LoginData.LobbyVersion).HexString(), e.UpdatedAt.Unix())
+			}
+			fields = append(fields, &discordgo.MessageEmbedField{Name: "Your Last Client", Value: status, Inline: false})
+
+			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Flags: discordgo.MessageFlagsEphemeral,
+					Embeds: []*discordgo.MessageEmbed{
+						{
+							Title:  "Version",
+							Color:  0xCCCCCC,
+							Fields: fields,
+						},
+					},
+				},
+			})
+		},
+		"match-label-edit": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			// Limit access to global developers
+			if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers); err != nil {
+				return errors.New("failed to check group membership")
+			} else if !ok {
+				return errors.New("you do not have permission to use this command")
+			}
+
+			var matchIDStr string
+			payload := SignalEditLabelPayload{}
+			for _, o := range i.ApplicationCommandData().Options {
+				switch o.Name {
+				case "match-id":
+					matchIDStr = o.StringValue()
+				case "regions":
+					regions := make([]evr.Symbol, 0)
+					for _, r := range parseLabelEditList(o.StringValue()) {
+						regions = append(regions, evr.ToSymbol(r))
+					}
+					payload.Regions = &regions
+				case "tags":
+					tags := parseLabelEditList(o.StringValue())
+					payload.Tags = &tags
+				}
+			}
+			if payload.Regions == nil && payload.Tags == nil {
+				return errors.New("specify the regions or tags to edit")
+			}
+
+			matchID, err := d.parseMatchIDOption(matchIDStr)
 			if err != nil {
-				return errors.New("failed to list stream users")
+				return err
+			}
 
+			label, err := MatchLabelByID(ctx, nk, matchID)
+			if err != nil || label == nil {
+				return errors.New("match not found")
 			}
-			if len(presences) == 0 {
-				return errors.New("no stream users found")
+			previous := fmt.Sprintf("regions `%v`, tags `%v`", label.Broadcaster.Regions, label.Broadcaster.Tags)
+
+			data, err := SignalMatch(ctx, nk, matchID, SignalEditLabel, payload)
+			if err != nil {
+				return fmt.Errorf("failed to signal match: %w", err)
+			}
+			if err := json.Unmarshal([]byte(data), label); err != nil {
+				return fmt.Errorf("failed to unmarshal match label: %w", err)
+			}
+			current := fmt.Sprintf("regions `%v`, tags `%v`", label.Broadcaster.Regions, label.Broadcaster.Tags)
+
+			_, _ = d.LogAuditMessage(ctx, label.GetGroupID().String(), fmt.Sprintf("<@%s> edited [%s](%s) match label from %s to %s.", user.ID, label.Mode.String(), d.sparkLink(label.ID), previous, current), false)
+
+			return simpleInteractionResponse(s, i, fmt.Sprintf("Match `%s` label now has %s.", label.ID.UUID.String(), current))
+		},
+		"queue-status": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
 			}
+
+			// Limit access to global developers
+			if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers); err != nil {
+				return errors.New("failed to check group membership")
+			} else if !ok {
+				return errors.New("you do not have permission to use this command")
+			}
+
+			channel, err := s.UserChannelCreate(user.ID)
+			if err != nil {
+				return errors.New("failed to create user channel")
+			}
+			if err := simpleInteractionResponse(s, i, "Sending queue status to your DMs"); err != nil {
+				return errors.New("failed to send interaction response")
+			}
+
+			messages := d.queueStatusMessages(d.queueStatus())
+
+			go func() {
+				if err := d.dms.Send(ctx, channel.ID, messages...); err != nil {
+					logger.Warn("Failed to send message", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+
+		"inactive-cleanup": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			// Limit access to global developers
+			if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers); err != nil {
+				return errors.New("failed to check group membership")
+			} else if !ok {
+				return errors.New("you do not have permission to use this command")
+			}
+
+			days := InactivePartyGroupDefaultDays
+			clearGroups := false
+			for _, o := range i.ApplicationCommandData().Options {
+				switch o.Name {
+				case "days":
+					days = int(o.IntValue())
+				case "clear":
+					clearGroups = o.BoolValue()
+				}
+			}
+			if days < 1 {
+				return errors.New("days must be at least 1")
+			}
+
 			channel, err := s.UserChannelCreate(user.ID)
 			if err != nil {
 				return errors.New("failed to create user channel")
 			}
-			if err := simpleInteractionResponse(s, i, "Sending stream list to your DMs"); err != nil {
-				return errors.New("failed to send interaction response")
+			if err := simpleInteractionResponse(s, i, "Sending inactive party groups to your DMs"); err != nil {
+				return errors.New("failed to send interaction response")
+			}
+
+			go func() {
+				members, err := listPartyGroupMemberActivity(ctx, d.db)
+				if err != nil {
+					logger.WithField("error", err).Warn("Failed to list party group members")
+					return
+				}
+
+				groups := stalePartyGroups(members, time.Now().AddDate(0, 0, -days))
+				messages := []string{stalePartyGroupsMessage(groups, days)}
+
+				if clearGroups && len(groups) > 0 {
+					cleared, err := d.clearPartyGroups(ctx, groups)
+					if err != nil {
+						logger.WithField("error", err).Warn("Failed to clear party groups")
+						messages = append(messages, fmt.Sprintf("Failed to clear party groups after %d members: %v", cleared, err))
+					} else {
+						logger.WithFields(map[string]any{"groups": len(groups), "members": cleared}).Info("Cleared inactive party groups.")
+						messages = append(messages, fmt.Sprintf("Cleared the party group of %d members.", cleared))
+					}
+				}
+
+				if err := d.dms.Send(ctx, channel.ID, messages...); err != nil {
+					logger.WithField("error", err).Warn("Failed to send message")
+				}
+			}()
+			return nil
+		},
+
+		"account-merge": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			options := i.ApplicationCommandData().Options
+
+			if user == nil {
+				return nil
+			}
+
+			// Limit access to global developers
+			if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers); err != nil {
+				return errors.New("failed to check group membership")
+			} else if !ok {
+				return errors.New("you do not have permission to use this command")
+			}
+
+			var primary, secondary *discordgo.User
+			for _, o := range options {
+				switch o.Name {
+				case "primary":
+					primary = o.UserValue(s)
+				case "secondary":
+					secondary = o.UserValue(s)
+				}
+			}
+
+			if primary == nil || secondary == nil {
+				return errors.New("you must specify a primary and secondary user")
+			}
+
+			if primary.ID == secondary.ID {
+				return errors.New("primary and secondary must be different users")
+			}
+
+			primaryUserID := d.cache.DiscordIDToUserID(primary.ID)
+			if primaryUserID == "" {
+				return errors.New("primary user not found")
+			}
+
+			secondaryUserID := d.cache.DiscordIDToUserID(secondary.ID)
+			if secondaryUserID == "" {
+				return errors.New("secondary user not found")
+			}
+
+			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Flags:   discordgo.MessageFlagsEphemeral,
+					Content: fmt.Sprintf("Merge %s into %s? The devices of %s will be relinked and the account will be disabled.", secondary.Mention(), primary.Mention(), secondary.Mention()),
+					Components: []discordgo.MessageComponent{
+						discordgo.ActionsRow{
+							Components: []discordgo.MessageComponent{
+								discordgo.Button{
+									Label:    "Merge Accounts",
+									Style:    discordgo.DangerButton,
+									CustomID: fmt.Sprintf("account_merge:%s:%s", primaryUserID, secondaryUserID),
+								},
+							},
+						},
+					},
+					AllowedMentions: &discordgo.MessageAllowedMentions{},
+				},
+			})
+		},
+
+		"merge-party": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			otherGroupName := strings.ToLower(strings.TrimSpace(i.ApplicationCommandData().Options[0].StringValue()))
+			if !partyGroupIDPattern.MatchString(otherGroupName) {
+				return errors.New("invalid group ID. It must be alphanumeric")
+			}
+
+			settings, err := LoadMatchmakingSettings(ctx, nk, userID)
+			if err != nil {
+				return fmt.Errorf("failed to load matchmaking settings: %w", err)
+			}
+			groupName := settings.LobbyGroupName
+			if groupName == "" {
+				return errors.New("set a group ID first with `/party group`")
+			}
+			if groupName == otherGroupName {
+				return errors.New("you are already in that party group")
+			}
+
+			// The party leader must be online to confirm the merge.
+			if leaderID := d.partyGroupLeaderID(groupName); leaderID == "" {
+				return errors.New("your party must be online in-game to merge parties")
+			} else if leaderID != userID {
+				return errors.New("only the party leader can merge parties")
+			}
+
+			moved, remaining, err := d.mergePartyGroup(ctx, groupName, otherGroupName)
+			if err != nil {
+				return err
+			}
+
+			if len(moved) == 0 {
+				return simpleInteractionResponse(s, i, fmt.Sprintf("No members were moved; party group `%s` is empty or yours is full.", otherGroupName))
+			}
+
+			notice := fmt.Sprintf("%s merged your party into party group `%s`. Everyone must matchmake at the same time (~15-30 seconds).", user.Mention(), groupName)
+			for _, id := range moved {
+				if discordID := d.cache.UserIDToDiscordID(id); discordID != "" {
+					if err := d.dms.SendUser(ctx, discordID, notice); err != nil {
+						logger.Warn("Failed to send party merge notice to %s: %v", discordID, err)
+					}
+				}
+			}
+
+			mentions := make([]string, 0, len(moved))
+			for _, id := range moved {
+				if discordID := d.cache.UserIDToDiscordID(id); discordID != "" {
+					mentions = append(mentions, "<@"+discordID+">")
+				}
+			}
+			if unknown := len(moved) - len(mentions); unknown > 0 {
+				mentions = append(mentions, fmt.Sprintf("%d player(s) without a linked Discord account", unknown))
+			}
+
+			content := fmt.Sprintf("Moved %s into party group `%s`.", strings.Join(mentions, ", "), groupName)
+			if len(remaining) > 0 {
+				content += fmt.Sprintf("\n%d member(s) stayed in `%s`; a party group is limited to %d members.", len(remaining), otherGroupName, PartyGroupMaxSize)
+			}
+			return simpleInteractionResponse(s, i, content)
+		},
+		"transfer-party-leader": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+			if user == nil {
+				return nil
+			}
+
+			target := i.ApplicationCommandData().Options[0].UserValue(s)
+			if target == nil {
+				return errors.New("user not found")
 			}
-			if limit == 0 {
-				limit = 10
+			if target.ID == user.ID {
+				return errors.New("you are already the party leader")
 			}
 
-			var b strings.Builder
-			if len(presences) > int(limit) {
-				presences = presences[:limit]
+			targetUserID := d.cache.DiscordIDToUserID(target.ID)
+			if targetUserID == "" {
+				return errors.New("that user does not have an account")
 			}
 
-			type presenceMessage struct {
-				UserID    string
-				Username  string
-				SessionID string
-				Status    any
+			settings, err := LoadMatchmakingSettings(ctx, nk, userID)
+			if err != nil {
+				return fmt.Errorf("failed to load matchmaking settings: %w", err)
+			}
+			groupName := settings.LobbyGroupName
+			if groupName == "" {
+				return errors.New("set a group ID first with `/party group`")
 			}
 
-			messages := make([]string, 0)
-			for _, presence := range presences {
-				m := presenceMessage{
-					UserID:    presence.GetUserId(),
-					Username:  presence.GetUsername(),
-					SessionID: presence.GetSessionId(),
-				}
-				// Try to unmarshal the status
-				status := make(map[string]any, 0)
-				if err := json.Unmarshal([]byte(presence.GetStatus()), &status); err != nil {
-					m.Status = presence.GetStatus()
-				}
-				m.Status = status
+			if err := d.transferPartyLeader(groupName, userID, targetUserID); err != nil {
+				return err
+			}
 
-				data, err := json.MarshalIndent(m, "", "  ")
-				if err != nil {
-					return errors.New("failed to marshal presence data")
-				}
-				if b.Len()+len(data) > 1900 {
-					messages = append(messages, b.String())
-					b.Reset()
+			// Notify the rest of the party group
+			memberIDs, err := GetPartyGroupUserIDs(ctx, nk, groupName)
+			if err != nil {
+				logger.Warn("Failed to get party group user IDs: %v", err)
+			}
+			notice := fmt.Sprintf("%s made %s the leader of party group `%s`.", user.Mention(), target.Mention(), groupName)
+			for _, id := range memberIDs {
+				if id == userID {
+					continue
 				}
-				_, err = b.WriteString(fmt.Sprintf("```json\n%s\n```\n", data))
-				if err != nil {
-					return errors.New("failed to write presence data")
+				if discordID := d.cache.UserIDToDiscordID(id); discordID != "" {
+					if err := d.dms.SendUser(ctx, discordID, notice); err != nil {
+						logger.Warn("Failed to send party leader notice to %s: %v", discordID, err)
+					}
 				}
 			}
-			messages = append(messages, b.String())
 
-			go func() {
-				for _, m := range messages {
-					if _, err := s.ChannelMessageSend(channel.ID, m); err != nil {
-						logger.Warn("Failed to send message", zap.Error(err))
-						return
-					}
-					// Ensure it's stays below 25 messages per second
-					time.Sleep(time.Millisecond * 50)
-				}
-			}()
-			return nil
+			return simpleInteractionResponse(s, i, fmt.Sprintf("%s is now the party leader.", target.Mention()))
 		},
-
 		"party": func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
 
 			if user == nil {
@@ -2554,34 +5196,18 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 				if err != nil {
 					return fmt.Errorf("failed to get party group ID: %w", err)
 				}
-				// Look for presences
 
-				partyMembers, err := nk.StreamUserList(StreamModeParty, partyUUID.String(), "", d.pipeline.node, false, true)
+				activeUserIDs, inactiveUserIDs, err := d.partyGroupMembers(ctx, groupName, partyUUID)
 				if err != nil {
-					return fmt.Errorf("failed to list stream users: %w", err)
-				}
-
-				activeIDs := make([]string, 0, len(partyMembers))
-				for _, partyMember := range partyMembers {
-					activeIDs = append(activeIDs, d.cache.UserIDToDiscordID(partyMember.GetUserId()))
+					return err
 				}
 
-				// Get a list of the all the inactive users in the party group
-				userIDs, err := GetPartyGroupUserIDs(ctx, d.nk, groupName)
-				if err != nil {
-					return fmt.Errorf("failed to get party group user IDs: %w", err)
+				activeIDs := make([]string, 0, len(activeUserIDs))
+				for _, u := range activeUserIDs {
+					activeIDs = append(activeIDs, d.cache.UserIDToDiscordID(u))
 				}
-
-				// remove the partymembers from the inactive list
-				inactiveIDs := make([]string, 0, len(userIDs))
-
-			OuterLoop:
-				for _, u := range userIDs {
-					for _, partyMember := range partyMembers {
-						if partyMember.GetUserId() == u {
-							continue OuterLoop
-						}
-					}
+				inactiveIDs := make([]string, 0, len(inactiveUserIDs))
+				for _, u := range inactiveUserIDs {
 					inactiveIDs = append(inactiveIDs, d.cache.UserIDToDiscordID(u))
 				}
 
@@ -2621,6 +5247,108 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 					},
 				})
 
+			case "status":
+
+				groupName, partyUUID, err := GetLobbyGroupID(ctx, d.db, userID)
+				if err != nil && status.Code(err) != codes.NotFound {
+					return fmt.Errorf("failed to get party group ID: %w", err)
+				}
+				if groupName == "" {
+					return simpleInteractionResponse(s, i, "You are not in a party group. Set one with `/party group`.")
+				}
+
+				activeUserIDs, inactiveUserIDs, err := d.partyGroupMembers(ctx, groupName, partyUUID)
+				if err != nil {
+					return err
+				}
+
+				matchmakingUserIDs := d.matchmakingUserIDs()
+				mentions := func(userIDs []string) string {
+					if len(userIDs) == 0 {
+						return "None"
+					}
+					strs := make([]string, 0, len(userIDs))
+					for _, u := range userIDs {
+						m := "<@" + d.cache.UserIDToDiscordID(u) + ">"
+						if _, ok := matchmakingUserIDs[u]; ok {
+							m += " (matchmaking)"
+						}
+						strs = append(strs, m)
+					}
+					return strings.Join(strs, "\n")
+				}
+
+				isMatchmaking := "No"
+				for _, u := range append(activeUserIDs, inactiveUserIDs...) {
+					if _, ok := matchmakingUserIDs[u]; ok {
+						isMatchmaking = "Yes"
+						break
+					}
+				}
+
+				return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+					Type: discordgo.InteractionResponseChannelMessageWithSource,
+					Data: &discordgo.InteractionResponseData{
+						Flags: discordgo.MessageFlagsEphemeral,
+						Embeds: []*discordgo.MessageEmbed{{
+							Title: "Party Status",
+							Color: 0x9656ce,
+							Fields: []*discordgo.MessageEmbedField{
+								{Name: "Group", Value: fmt.Sprintf("`%s`", groupName), Inline: true},
+								{Name: "Matchmaking", Value: isMatchmaking, Inline: true},
+								{Name: "Active Members", Value: mentions(activeUserIDs)},
+								{Name: "Inactive Members", Value: mentions(inactiveUserIDs)},
+							},
+						}},
+					},
+				})
+
+			case "leave":
+
+				groupName, partyUUID, err := GetLobbyGroupID(ctx, d.db, userID)
+				if err != nil && status.Code(err) != codes.NotFound {
+					return fmt.Errorf("failed to get party group ID: %w", err)
+				}
+				if groupName == "" {
+					return simpleInteractionResponse(s, i, "You are not in a party group.")
+				}
+
+				settings, err := LoadMatchmakingSettings(ctx, nk, userID)
+				if err != nil {
+					return fmt.Errorf("failed to load matchmaking settings: %w", err)
+				}
+				settings.LobbyGroupName = ""
+				if _, err := SaveToStorage(ctx, nk, userID, settings); err != nil {
+					return fmt.Errorf("failed to save matchmaking settings: %w", err)
+				}
+
+				if err := LeavePartyGroup(ctx, nk, d.pipeline.node, userID, partyUUID); err != nil {
+					return err
+				}
+
+				return simpleInteractionResponse(s, i, fmt.Sprintf("You have left the party group `%s`.", groupName))
+
+			case "help":
+
+				return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+					Type: discordgo.InteractionResponseChannelMessageWithSource,
+					Data: &discordgo.InteractionResponseData{
+						Flags: discordgo.MessageFlagsEphemeral,
+						Embeds: []*discordgo.MessageEmbed{{
+							Title: "Party Commands",
+							Color: 0x9656ce,
+							Description: "A party group keeps you together with your friends when matchmaking. " +
+								"Everyone in the group must set the same group name, then matchmake at the same time (~15-30 seconds).",
+							Fields: []*discordgo.MessageEmbedField{
+								{Name: "/party group <group-name>", Value: "Set your party group name (alphanumeric, up to 12 characters)."},
+								{Name: "/party members", Value: "List the members of your party group."},
+								{Name: "/party status", Value: "Show your party group, its active and inactive members, and who is matchmaking."},
+								{Name: "/party leave", Value: "Leave your party group."},
+							},
+						}},
+					},
+				})
+
 			case "group":
 
 				options := options[0].Options
@@ -2690,7 +5418,7 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 		},
 	}
 
-	dg.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	removeHandler := dg.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		user, _ := getScopedUserMember(i)
 
 		logger := d.logger.WithFields(map[string]any{
@@ -2811,12 +5539,16 @@ func (d *DiscordAppBot) RegisterSlashCommands() error {
 
 	d.logger.Info("Registering slash commands.")
 	// Register global guild commands
-	d.updateSlashCommands(dg, d.logger, "")
+	if err := d.updateSlashCommands(dg, d.logger, ""); err != nil {
+		// Remove the handler so that the next attempt does not add a second one.
+		removeHandler()
+		return err
+	}
 	d.logger.Info("%d Slash commands registered/updated in %d guilds.", len(mainSlashCommands), len(dg.State.Guilds))
 
 	return nil
 }
-func (d *DiscordAppBot) updateSlashCommands(s *discordgo.Session, logger runtime.Logger, guildID string) {
+func (d *DiscordAppBot) updateSlashCommands(s *discordgo.Session, logger runtime.Logger, guildID string) error {
 	// create a map of current commands
 	currentCommands := make(map[string]*discordgo.ApplicationCommand, 0)
 	for _, command := range mainSlashCommands {
@@ -2826,8 +5558,7 @@ func (d *DiscordAppBot) updateSlashCommands(s *discordgo.Session, logger runtime
 	// Get the bot's current global application commands
 	commands, err := s.ApplicationCommands(s.State.Application.ID, guildID)
 	if err != nil {
-		logger.WithField("err", err).Error("Failed to get application commands.")
-		return
+		return fmt.Errorf("failed to get application commands: %w", err)
 	}
 
 	// Create a map for comparison
@@ -2837,6 +5568,7 @@ func (d *DiscordAppBot) updateSlashCommands(s *discordgo.Session, logger runtime
 	}
 
 	add, remove := lo.Difference(lo.Keys(currentCommands), lo.Keys(registeredCommands))
+	failed := 0
 
 	// Remove any commands that are not in the mainSlashCommands
 	for _, name := range remove {
@@ -2853,6 +5585,7 @@ func (d *DiscordAppBot) updateSlashCommands(s *discordgo.Session, logger runtime
 		logger.Debug("Creating %s command: %s", guildID, command.Name)
 		if _, err := s.ApplicationCommandCreate(s.State.Application.ID, guildID, command); err != nil {
 			logger.WithField("err", err).Error("Failed to create application command: %s", command.Name)
+			failed++
 		}
 	}
 
@@ -2864,10 +5597,316 @@ func (d *DiscordAppBot) updateSlashCommands(s *discordgo.Session, logger runtime
 				logger.Debug("Updating %s command: %s", guildID, command.Name)
 				if _, err := s.ApplicationCommandEdit(s.State.Application.ID, guildID, registered.ID, command); err != nil {
 					logger.WithField("err", err).Error("Failed to edit application command: %s", command.Name)
+					failed++
 				}
 			}
 		}
 	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to create or update %d application commands", failed)
+	}
+	return nil
+}
+
+// partyGroupLeaderID returns the user ID of the leader of the party group's active party, if it has one.
+func (d *DiscordAppBot) partyGroupLeaderID(groupName string) string {
+	partyRegistry, ok := d.pipeline.partyRegistry.(*LocalPartyRegistry)
+	if !ok {
+		return ""
+	}
+	ph, found := partyRegistry.parties.Load(uuid.NewV5(EntrantIDSalt, groupName))
+	if !found {
+		return ""
+	}
+	if leader := (&LobbyGroup{ph: ph}).GetLeader(); leader != nil {
+		return leader.GetUserId()
+	}
+	return ""
+}
+
+// matchStartStatus describes when the match will start, as a live Discord countdown, or that it is in progress.
+func matchStartStatus(label *MatchLabel) string {
+	switch {
+	case label.Started():
+		return "In progress"
+	case label.StartTime.IsZero():
+		return "Waiting for the server"
+	default:
+		return fmt.Sprintf("Level loads <t:%d:R>", label.StartTime.Unix())
+	}
+}
+
+// serverOwnedByGuild reports whether the game server is online, hosting for the guild group, and operated by one of the guild's server hosts.
+// Hosting for a guild is not enough; a server may host for many guilds, but only the guild its operator hosts for may change it.
+func (d *DiscordAppBot) serverOwnedByGuild(ctx context.Context, serverID uint64, g *GuildGroup) (bool, error) {
+	matches, err := d.listMatches(ctx, 1000, nil, nil, "")
+	if err != nil {
+		return false, fmt.Errorf("failed to list matches: %w", err)
+	}
+
+	for _, m := range matches {
+		label := &MatchLabel{}
+		if err := json.Unmarshal([]byte(m.GetLabel().GetValue()), label); err != nil {
+			continue
+		}
+		if label.Broadcaster.ServerID != serverID {
+			continue
+		}
+		if slices.Contains(label.Broadcaster.GroupIDs, g.ID()) && g.IsServerHost(label.Broadcaster.OperatorID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// guildConfigEmbed renders the guild's configuration.
+func guildConfigEmbed(guildName string, md *GroupMetadata) *discordgo.MessageEmbed {
+	orNone := func(s string) string {
+		if s == "" {
+			return "_not set_"
+		}
+		return s
+	}
+	channel := func(id string) string {
+		if id == "" {
+			return "_not set_"
+		}
+		return "<#" + id + ">"
+	}
+	role := func(id string) string {
+		if id == "" {
+			return "_not set_"
+		}
+		return "<@&" + id + ">"
+	}
+	channelMap := func(m map[string]string) string {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		lines := make([]string, 0, len(keys))
+		for _, k := range keys {
+			lines = append(lines, fmt.Sprintf("`%s`: %s", k, channel(m[k])))
+		}
+		return orNone(strings.Join(lines, "\n"))
+	}
+
+	roles := md.Roles
+	if roles == nil {
+		roles = &GuildGroupRoles{}
+	}
+
+	membersOnlySocial := strconv.FormatBool(md.IsMembersOnly(evr.ModeSocialPublic))
+	if md.MembersOnlySocial == nil {
+		membersOnlySocial += " (follows matchmaking)"
+	}
+
+	moderationChannelID := md.ModerationChannelID
+	if moderationChannelID == "" {
+		moderationChannelID = md.AuditChannelID
+	}
+
+	minPartySizes := make([]string, 0, len(md.MinPartySizeByMode))
+	for mode, size := range md.MinPartySizeByMode {
+		minPartySizes = append(minPartySizes, fmt.Sprintf("`%s`: %d", mode, size))
+	}
+	slices.Sort(minPartySizes)
+
+	defaultMode, defaultRegion := md.DefaultModeRegion()
+	rulesText := md.RulesText
+	if r := []rune(rulesText); len(r) > 1000 {
+		rulesText = string(r[:1000]) + "..."
+	}
+
+	return &discordgo.MessageEmbed{
+		Title: guildName + " Configuration",
+		Color: 0x9656ce,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name: "Roles",
+				Value: strings.Join([]string{
+					"Member: " + role(roles.Member),
+					"Moderator: " + role(roles.Moderator),
+					"Server Host: " + role(roles.ServerHost),
+					"Allocator: " + role(roles.Allocator),
+					"Suspended: " + role(roles.Suspended),
+					"API Access: " + role(roles.APIAccess),
+					"Account Age Bypass: " + role(roles.AccountAgeBypass),
+					"VPN Bypass: " + role(roles.VPNBypass),
+					"Headset Linked: " + role(roles.AccountLinked),
+				}, "\n"),
+			},
+			{
+				Name: "Channels",
+				Value: strings.Join([]string{
+					"Audit: " + channel(md.AuditChannelID),
+					"Moderation: " + channel(moderationChannelID),
+					"Debug: " + channel(md.DebugChannelID),
+					"Error: " + channel(md.ErrorChannelID),
+				}, "\n"),
+			},
+			{
+				Name:  "Matchmaking Channels",
+				Value: channelMap(md.MatchmakingChannelIDs),
+			},
+			{
+				Name:  "Region Status Boards",
+				Value: channelMap(md.RegionStatusChannelIDs),
+			},
+			{
+				Name: "Access",
+				Value: strings.Join([]string{
+					fmt.Sprintf("Members-only matchmaking: %t", md.MembersOnlyMatchmaking),
+					"Members-only social lobbies: " + membersOnlySocial,
+					fmt.Sprintf("Minimum account age: %d days", md.MinimumAccountAgeDays),
+					fmt.Sprintf("Block VPN users: %t (fraud score threshold %d)", md.BlockVPNUsers, md.FraudScoreThreshold),
+					fmt.Sprintf("Log alternate accounts: %t", md.LogAlternateAccounts),
+					fmt.Sprintf("Community values required: %d user(s)", len(md.CommunityValuesUserIDs)),
+				}, "\n"),
+			},
+			{
+				Name: "Matches",
+				Value: strings.Join([]string{
+					fmt.Sprintf("/create disabled: %t", md.DisableCreateCommand),
+					fmt.Sprintf("Default mode/region: `%s` / `%s`", defaultMode.String(), defaultRegion.String()),
+					fmt.Sprintf("Public arena team size: %d", md.PublicTeamSize(evr.ModeArenaPublic)),
+					fmt.Sprintf("Public combat team size: %d", md.PublicTeamSize(evr.ModeCombatPublic)),
+					fmt.Sprintf("Allow large private teams: %t", md.AllowLargePrivateTeams),
+					fmt.Sprintf("Custom match pacing: %t", md.MatchPacing != nil),
+					"Minimum party sizes: " + orNone(strings.Join(minPartySizes, ", ")),
+					"Allowed features: " + orNone(strings.Join(md.AllowedFeatures, ", ")),
+				}, "\n"),
+			},
+			{
+				Name:  "Rules Text",
+				Value: orNone(rulesText),
+			},
+		},
+	}
+}
+
+// transferPartyLeader makes the target user the leader of the party group's active party. The target must be an online member of the party.
+func (d *DiscordAppBot) transferPartyLeader(groupName, leaderUserID, targetUserID string) error {
+	partyRegistry, ok := d.pipeline.partyRegistry.(*LocalPartyRegistry)
+	if !ok {
+		return errors.New("party registry is not available")
+	}
+	ph, found := partyRegistry.parties.Load(uuid.NewV5(EntrantIDSalt, groupName))
+	if !found {
+		return errors.New("your party is not active; the party must be online to change leaders")
+	}
+
+	ph.RLock()
+	leader := ph.leader
+	ph.RUnlock()
+	if leader == nil || leader.UserPresence.GetUserId() != leaderUserID {
+		return errors.New("only the party leader can transfer leadership")
+	}
+
+	for _, member := range ph.members.List() {
+		if member.UserPresence.GetUserId() == targetUserID {
+			return ph.Promote(leader.PresenceID.SessionID.String(), leader.PresenceID.Node, member.UserPresence)
+		}
+	}
+	return errors.New("that user is not an online member of your party")
+}
+
+// partyGroupMembers returns the user IDs of the party group's members that are in the party (active), and those that are not (inactive).
+func (d *DiscordAppBot) partyGroupMembers(ctx context.Context, groupName string, partyID uuid.UUID) (active []string, inactive []string, err error) {
+	partyMembers, err := d.nk.StreamUserList(StreamModeParty, partyID.String(), "", d.pipeline.node, false, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list stream users: %w", err)
+	}
+
+	active = make([]string, 0, len(partyMembers))
+	for _, p := range partyMembers {
+		active = append(active, p.GetUserId())
+	}
+
+	// Get a list of the all the users in the party group
+	userIDs, err := GetPartyGroupUserIDs(ctx, d.nk, groupName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get party group user IDs: %w", err)
+	}
+
+	inactive = make([]string, 0, len(userIDs))
+	for _, u := range userIDs {
+		if !slices.Contains(active, u) {
+			inactive = append(inactive, u)
+		}
+	}
+	return active, inactive, nil
+}
+
+// matchmakingUserIDs returns the IDs of the users that are matchmaking, in any group.
+func (d *DiscordAppBot) matchmakingUserIDs() map[string]struct{} {
+	userIDs := make(map[string]struct{})
+	mode := uint8(StreamModeMatchmaking)
+	for stream := range d.pipeline.tracker.CountByStreamModeFilter(map[uint8]*uint8{StreamModeMatchmaking: &mode}) {
+		for _, p := range d.pipeline.tracker.ListByStream(*stream, true, true) {
+			userIDs[p.UserID.String()] = struct{}{}
+		}
+	}
+	return userIDs
+}
+
+// mergePartyGroup moves the members of the other party group into the party group, up to the party group size limit.
+// It returns the user IDs that were moved, and those that remain in the other party group.
+func (d *DiscordAppBot) mergePartyGroup(ctx context.Context, groupName, otherGroupName string) (moved []string, remaining []string, err error) {
+	members, err := GetPartyGroupUserIDs(ctx, d.nk, groupName)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, nil, fmt.Errorf("failed to get party group user IDs: %w", err)
+	}
+
+	others, err := GetPartyGroupUserIDs(ctx, d.nk, otherGroupName)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to get party group user IDs: %w", err)
+	}
+
+	room := max(PartyGroupMaxSize-len(members), 0)
+	ops := make([]*runtime.StorageWrite, 0, min(room, len(others)))
+	for _, id := range others {
+		if len(ops) >= room {
+			remaining = append(remaining, id)
+			continue
+		}
+
+		version, settings, err := LoadMatchmakingSettingsWithVersion(ctx, d.nk, id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load matchmaking settings: %w", err)
+		}
+		settings.LobbyGroupName = groupName
+
+		data, err := json.Marshal(settings)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal matchmaking settings: %w", err)
+		}
+		storageID := settings.GetStorageID()
+		ops = append(ops, &runtime.StorageWrite{
+			UserID:          id,
+			Collection:      storageID.Collection,
+			Key:             storageID.Key,
+			Value:           string(data),
+			Version:         version,
+			PermissionRead:  0,
+			PermissionWrite: 0,
+		})
+		moved = append(moved, id)
+	}
+
+	// Move everyone in one write, so a failure leaves both party groups as they were.
+	if len(ops) > 0 {
+		if _, err := d.nk.StorageWrite(ctx, ops); err != nil {
+			return nil, nil, fmt.Errorf("failed to save matchmaking settings: %w", err)
+		}
+	}
+
+	return moved, remaining, nil
 }
 
 func (d *DiscordAppBot) getPartyDiscordIds(ctx context.Context, partyHandler *PartyHandler) (map[string]string, error) {
@@ -3062,11 +6101,91 @@ func (d *DiscordAppBot) sendPartyInvite(ctx context.Context, s *discordgo.Sessio
 		},
 	})
 
-	// Set a timer to delete the messages after 30 seconds
-	time.AfterFunc(30*time.Second, func() {
-		s.ChannelMessageDelete(inviter.ID, i.ID)
-	})
-	return nil
+	// Set a timer to delete the messages after 30 seconds
+	time.AfterFunc(30*time.Second, func() {
+		s.ChannelMessageDelete(inviter.ID, i.ID)
+	})
+	return nil
+}
+
+// handleSetPublicTeamSize sets (or resets) the team size of the guild's public matches in the mode.
+func (d *DiscordAppBot) handleSetPublicTeamSize(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, groupID string, mode evr.Symbol) error {
+	size := 0
+	for _, o := range i.ApplicationCommandData().Options {
+		if o.Name == "size" {
+			size = int(o.IntValue())
+		}
+	}
+
+	if size != 0 && (size < MinPublicTeamSize || size > MaxPublicTeamSize) {
+		return fmt.Errorf("team size must be between %d and %d", MinPublicTeamSize, MaxPublicTeamSize)
+	}
+
+	metadata, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to get guild group metadata: %w", err)
+	}
+
+	switch mode {
+	case evr.ModeArenaPublic:
+		metadata.PublicArenaTeamSize = size
+	case evr.ModeCombatPublic:
+		metadata.PublicCombatTeamSize = size
+	}
+
+	data, err := metadata.MarshalToMap()
+	if err != nil {
+		return fmt.Errorf("failed to marshal guild group metadata: %w", err)
+	}
+
+	if err := d.nk.GroupUpdate(ctx, groupID, SystemUserID, "", "", "", "", "", false, data, 1000000); err != nil {
+		return fmt.Errorf("failed to update guild group metadata: %w", err)
+	}
+
+	teamSize := metadata.PublicTeamSize(mode)
+	_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("%s set the `%s` team size to %d.", user.Mention(), mode.String(), teamSize), false)
+
+	return simpleInteractionResponse(s, i, fmt.Sprintf("Public `%s` matches will be %dv%d.", mode.String(), teamSize, teamSize))
+}
+
+// resetActiveGroup clears the user's default lobby (and the game profile's channel), so the next login selects their largest guild.
+func (d *DiscordAppBot) resetActiveGroup(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, userID string) error {
+	md, err := GetAccountMetadata(ctx, d.nk, userID)
+	if err != nil {
+		return err
+	}
+	md.SetActiveGroupID(uuid.Nil)
+
+	if err := d.nk.AccountUpdateId(ctx, userID, "", md.MarshalMap(), "", "", "", "", ""); err != nil {
+		return err
+	}
+
+	profile, err := d.profileRegistry.Load(ctx, uuid.FromStringOrNil(userID))
+	if err != nil {
+		return err
+	}
+
+	profile.SetChannel(evr.GUID(uuid.Nil))
+
+	if err := d.profileRegistry.SaveAndCache(ctx, uuid.FromStringOrNil(userID), profile); err != nil {
+		return err
+	}
+
+	return simpleInteractionResponse(s, i, "EchoVR lobby reset. Your largest guild will be used at your next login.")
+}
+
+// parseMatchIDOption parses a match ID, accepting either a full match ID or a bare UUID (i.e. from a spark link).
+func (d *DiscordAppBot) parseMatchIDOption(s string) (MatchID, error) {
+	s = strings.TrimSpace(s)
+	if matchID, err := MatchIDFromString(s); err == nil && !matchID.IsNil() {
+		return matchID, nil
+	}
+
+	id := uuid.FromStringOrNil(s)
+	if id.IsNil() {
+		return MatchID{}, fmt.Errorf("invalid match ID `%s`", s)
+	}
+	return NewMatchID(id, d.pipeline.node)
 }
 
 func getScopedUser(i *discordgo.InteractionCreate) *discordgo.User {
@@ -3105,12 +6224,107 @@ func simpleInteractionResponse(s *discordgo.Session, i *discordgo.InteractionCre
 }
 
 func (d *DiscordAppBot) createRegionStatusEmbed(ctx context.Context, logger runtime.Logger, regionStr string, channelID string, existingMessage *discordgo.Message) error {
+	if !d.discordEnabled() {
+		return ErrDiscordDisabled
+	}
+
 	// list all the matches
 
-	matches, err := d.nk.MatchList(ctx, 100, true, "", nil, nil, "")
+	matches, err := d.listMatches(ctx, 100, nil, nil, "")
+	if err != nil {
+		return err
+	}
+
+	embed, err := d.regionStatusEmbed(ctx, logger, regionStr, matches)
 	if err != nil {
 		return err
 	}
+	if len(embed.Fields) == 0 {
+		return fmt.Errorf("no matches found in region %s", regionStr)
+	}
+
+	if existingMessage != nil {
+		t, err := discordgo.SnowflakeTimestamp(existingMessage.ID)
+		if err != nil {
+			return err
+		}
+
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Expires %s", t.Format(time.RFC1123)),
+		}
+		// Update the message for the given region
+		_, err = d.dg.ChannelMessageEditEmbed(channelID, existingMessage.ID, embed)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	} else {
+		// Create the message and update it regularly
+		msg, err := d.dg.ChannelMessageSendEmbed(channelID, embed)
+		if err != nil {
+			return err
+		}
+		d.trackRegionStatusPin(ctx, logger, channelID, msg.ID, regionStr)
+
+		go func() {
+			timer := time.NewTimer(24 * time.Hour)
+			defer timer.Stop()
+			ticker := time.NewTicker(30 * time.Second)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-d.ctx.Done():
+					// Delete the message
+					if err := d.dg.ChannelMessageDelete(channelID, msg.ID); err != nil {
+						logger.Error("Failed to delete region status message: %s", err.Error())
+
+					}
+					return
+				case <-timer.C:
+					// Delete the message
+					if err := d.dg.ChannelMessageDelete(channelID, msg.ID); err != nil {
+						logger.Error("Failed to delete region status message: %s", err.Error())
+					}
+					d.untrackRegionStatusPin(ctx, logger, channelID, msg.ID)
+					return
+				case <-ticker.C:
+					// Update the message
+					err := d.createRegionStatusEmbed(ctx, logger, regionStr, channelID, msg)
+					if err != nil {
+						logger.Error("Failed to update region status message: %s", err.Error())
+						return
+					}
+				}
+			}
+		}()
+	}
+	return nil
+}
+
+// regionStatusEmbed builds the region status embed from the given matches. The embed has no fields if there are no matches in the region.
+// registeredRegions returns the regions of the registered game servers.
+func (d *DiscordAppBot) registeredRegions(ctx context.Context) (map[evr.Symbol]struct{}, error) {
+	matches, err := d.listMatches(ctx, 1000, nil, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matches: %w", err)
+	}
+
+	regions := make(map[evr.Symbol]struct{})
+	for _, match := range matches {
+		label := &MatchLabel{}
+		if err := json.Unmarshal([]byte(match.GetLabel().GetValue()), label); err != nil {
+			continue
+		}
+		for _, r := range label.Broadcaster.Regions {
+			regions[r] = struct{}{}
+		}
+	}
+	return regions, nil
+}
+
+func (d *DiscordAppBot) regionStatusEmbed(ctx context.Context, logger runtime.Logger, regionStr string, matches []*api.Match) (*discordgo.MessageEmbed, error) {
+	var err error
 
 	regionSymbol := evr.ToSymbol(regionStr)
 
@@ -3131,9 +6345,6 @@ func (d *DiscordAppBot) createRegionStatusEmbed(ctx context.Context, logger runt
 			}
 		}
 	}
-	if len(tracked) == 0 {
-		return fmt.Errorf("no matches found in region %s", regionStr)
-	}
 
 	// Create a message embed that contains a table of the server, the creation time, the number of players, and the spark link
 	embed := &discordgo.MessageEmbed{
@@ -3176,61 +6387,75 @@ func (d *DiscordAppBot) createRegionStatusEmbed(ctx context.Context, logger runt
 		})
 	}
 
-	if existingMessage != nil {
-		t, err := discordgo.SnowflakeTimestamp(existingMessage.ID)
-		if err != nil {
-			return err
-		}
+	return embed, nil
+}
 
-		embed.Footer = &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("Expires %s", t.Format(time.RFC1123)),
+type regionSummary struct {
+	region  string
+	servers int
+	matches int
+	players int
+}
+
+// regionOverviewEmbed summarizes the servers, active matches, and players of every region. Servers are counted in each of their regions.
+func regionOverviewEmbed(logger runtime.Logger, matches []*api.Match) *discordgo.MessageEmbed {
+	summaries := make(map[evr.Symbol]*regionSummary)
+
+	for _, match := range matches {
+		state := &MatchLabel{}
+		if err := json.Unmarshal([]byte(match.GetLabel().GetValue()), state); err != nil {
+			logger.Error("Failed to unmarshal match label", zap.Error(err))
+			continue
 		}
-		// Update the message for the given region
-		_, err = d.dg.ChannelMessageEditEmbed(channelID, existingMessage.ID, embed)
-		if err != nil {
-			return err
+
+		for _, r := range state.Broadcaster.Regions {
+			summary, ok := summaries[r]
+			if !ok {
+				summary = &regionSummary{region: r.String()}
+				summaries[r] = summary
+			}
+			summary.servers++
+			if state.LobbyType != UnassignedLobby {
+				summary.matches++
+				summary.players += state.PlayerCount
+			}
 		}
+	}
 
-		return nil
-	} else {
-		// Create the message and update it regularly
-		msg, err := d.dg.ChannelMessageSendEmbed(channelID, embed)
-		if err != nil {
-			return err
+	sorted := make([]*regionSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		sorted = append(sorted, summary)
+	}
+	slices.SortFunc(sorted, func(a, b *regionSummary) int {
+		if a.players != b.players {
+			return b.players - a.players
 		}
+		return strings.Compare(a.region, b.region)
+	})
 
-		go func() {
-			timer := time.NewTimer(24 * time.Hour)
-			defer timer.Stop()
-			ticker := time.NewTicker(30 * time.Second)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-d.ctx.Done():
-					// Delete the message
-					if err := d.dg.ChannelMessageDelete(channelID, msg.ID); err != nil {
-						logger.Error("Failed to delete region status message: %s", err.Error())
+	embed := &discordgo.MessageEmbed{
+		Title:       "All Regions",
+		Description: fmt.Sprintf("updated <t:%d:f>", time.Now().UTC().Unix()),
+		Fields:      make([]*discordgo.MessageEmbedField, 0, len(sorted)),
+	}
 
-					}
-					return
-				case <-timer.C:
-					// Delete the message
-					if err := d.dg.ChannelMessageDelete(channelID, msg.ID); err != nil {
-						logger.Error("Failed to delete region status message: %s", err.Error())
-					}
-					return
-				case <-ticker.C:
-					// Update the message
-					err := d.createRegionStatusEmbed(ctx, logger, regionStr, channelID, msg)
-					if err != nil {
-						logger.Error("Failed to update region status message: %s", err.Error())
-						return
-					}
-				}
-			}
-		}()
+	for _, summary := range sorted {
+		// Discord limits embeds to 25 fields
+		if len(embed.Fields) == 25 {
+			break
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   summary.region,
+			Value:  fmt.Sprintf("%d servers, %d matches, %d players", summary.servers, summary.matches, summary.players),
+			Inline: true,
+		})
 	}
-	return nil
+
+	if len(embed.Fields) == 0 {
+		embed.Description = "No game servers are online."
+	}
+
+	return embed
 }
 
 var discordMarkdownEscapeReplacer = strings.NewReplacer(
@@ -3251,6 +6476,10 @@ func EscapeDiscordMarkdown(s string) string {
 }
 
 func (d *DiscordAppBot) SendIPApprovalRequest(ctx context.Context, userID, ip string, ipqs *IPQSResponse) error {
+	if !d.discordEnabled() {
+		return ErrDiscordDisabled
+	}
+
 	// Get the user's discord ID
 	discordID, err := GetDiscordIDByUserID(ctx, d.db, userID)
 	if err != nil {
@@ -3318,3 +6547,174 @@ func (d *DiscordAppBot) SendIPApprovalRequest(ctx context.Context, userID, ip st
 
 	return nil
 }
+
+func (d *DiscordAppBot) SendLocationAnomalyNotice(ctx context.Context, userID string, anomaly *LoginLocationAnomaly) error {
+	if !d.discordEnabled() {
+		return ErrDiscordDisabled
+	}
+
+	discordID, err := GetDiscordIDByUserID(ctx, d.db, userID)
+	if err != nil {
+		return err
+	}
+
+	channel, err := d.dg.UserChannelCreate(discordID)
+	if err != nil {
+		return err
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Unusual Login Location",
+		Description: "Your account was logged into from a location that is unusually far from your previous login.",
+		Color:       0xff9900,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Previous Location",
+				Value:  fmt.Sprintf("%s <t:%d:R>", anomaly.PreviousLocation, anomaly.PreviousLoginAt.Unix()),
+				Inline: true,
+			},
+			{
+				Name:   "New Location",
+				Value:  fmt.Sprintf("%s <t:%d:R>", anomaly.Location, anomaly.DetectedAt.Unix()),
+				Inline: true,
+			},
+			{
+				Name:   "Note",
+				Value:  "If this was not you, reset your password with `/reset-password` and report it to EchoVRCE.",
+				Inline: false,
+			},
+		},
+	}
+
+	_, err = d.dg.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{embed},
+	})
+	return err
+}
+
+// SendFirstLoginNotice informs the user that their first login was authorized, and that later logins from new locations will need approval.
+func (d *DiscordAppBot) SendFirstLoginNotice(ctx context.Context, userID, ip string) error {
+	if !d.discordEnabled() {
+		return ErrDiscordDisabled
+	}
+
+	discordID, err := GetDiscordIDByUserID(ctx, d.db, userID)
+	if err != nil {
+		return err
+	}
+
+	channel, err := d.dg.UserChannelCreate(discordID)
+	if err != nil {
+		return err
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Welcome to EchoVRCE",
+		Description: "We noticed your first login. This location has been authorized automatically.",
+		Color:       0x00cc00,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "IP Address",
+				Value:  ip,
+				Inline: true,
+			},
+			{
+				Name:   "Note",
+				Value:  "Logins from new locations will need to be approved here in your DMs. If this was not you, report it to EchoVRCE.",
+				Inline: false,
+			},
+		},
+	}
+
+	_, err = d.dg.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{embed},
+	})
+	return err
+}
+
+// guildStreamVisible reports whether a guild moderator may list the stream. Match streams must belong to
+// the guild's matches, guild group streams must be for the guild, and party streams are filtered by the caller.
+func (d *DiscordAppBot) guildStreamVisible(ctx context.Context, mode uint8, subject, label, groupID string) (bool, error) {
+	switch mode {
+	case StreamModeMatchAuthoritative:
+		matchID, err := NewMatchID(uuid.FromStringOrNil(subject), label)
+		if err != nil {
+			return false, errors.New("invalid match stream subject or label")
+		}
+		matchLabel, err := MatchLabelByID(ctx, d.nk, matchID)
+		if err != nil {
+			return false, errors.New("failed to get match label")
+		}
+		return matchLabel.GetGroupID().String() == groupID, nil
+	case StreamModeGuildGroup:
+		return subject == groupID, nil
+	case StreamModeParty:
+		return true, nil
+	}
+	return false, nil
+}
+
+// listMatches lists the authoritative matches. Concurrent callers with the same arguments share a single listing.
+// The shared listing runs on the bot's context, so one caller giving up does not fail the others.
+func (d *DiscordAppBot) listMatches(ctx context.Context, limit int, minSize, maxSize *int, query string) ([]*api.Match, error) {
+	key := fmt.Sprintf("%d:%s:%s:%s", limit, optionalIntString(minSize), optionalIntString(maxSize), query)
+	ch := d.matchListGroup.DoChan(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(d.ctx, MatchListTimeout)
+		defer cancel()
+		return d.nk.MatchList(ctx, limit, true, "", minSize, maxSize, query)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-ch:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.([]*api.Match), nil
+	}
+}
+
+func optionalIntString(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
+
+// parseLabelEditList splits a comma-separated list of label values; `none` is an empty list.
+func parseLabelEditList(value string) []string {
+	values := make([]string, 0)
+	if strings.EqualFold(strings.TrimSpace(value), "none") {
+		return values
+	}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// matchPreparedMessage describes a prepared match, compacting the label to fit within Discord's message limit.
+func (d *DiscordAppBot) matchPreparedMessage(label *MatchLabel) string {
+	link := d.sparkLink(label.ID)
+
+	for _, labelJSON := range []string{label.GetLabelIndented(), label.GetLabel()} {
+		if content := fmt.Sprintf("Match prepared with label ```json\n%s\n```\n%s", labelJSON, link); len(content) <= 2000 {
+			return content
+		}
+	}
+
+	// The label is too long to show in full; summarize the key fields.
+	summary := strings.Join([]string{
+		fmt.Sprintf("ID: `%s`", label.ID.String()),
+		fmt.Sprintf("Mode: `%s`", label.Mode.String()),
+		fmt.Sprintf("Level: `%s`", label.Level.String()),
+		fmt.Sprintf("Group: `%s`", label.GetGroupID().String()),
+		fmt.Sprintf("Players: %d/%d (size %d/%d)", label.PlayerCount, label.PlayerLimit, label.Size, label.MaxSize),
+		fmt.Sprintf("Server: `%s` (%s)", label.Broadcaster.Endpoint.GetExternalIP(), label.Broadcaster.Location),
+		fmt.Sprintf("Start Time: <t:%d:R>", label.StartTime.Unix()),
+	}, "\n")
+
+	return fmt.Sprintf("Match prepared (the full label is too long to display and has been logged):\n%s\n%s", summary, link)
+}
@@ -1,5 +1,33 @@
 package server
 
+import (
+	"testing"
+
+	"github.com/heroiclabs/nakama/v3/server/evr"
+)
+
+func TestValidateMatchRounds(t *testing.T) {
+	tests := []struct {
+		mode    evr.Symbol
+		rounds  int
+		wantErr bool
+	}{
+		{evr.ModeArenaPrivate, -1, true},
+		{evr.ModeArenaPrivate, 0, false},
+		{evr.ModeArenaPrivate, 1, false},
+		{evr.ModeArenaPrivate, MaxPrivateMatchRounds, false},
+		{evr.ModeArenaPrivate, MaxPrivateMatchRounds + 1, true},
+		{evr.ModeArenaPublic, 0, false},
+		{evr.ModeArenaPublic, 3, true},
+		{evr.ModeCombatPublic, 3, true},
+	}
+	for _, tt := range tests {
+		if err := validateMatchRounds(tt.mode, tt.rounds); (err != nil) != tt.wantErr {
+			t.Errorf("validateMatchRounds(%s, %d) error = %v, wantErr %v", tt.mode, tt.rounds, err, tt.wantErr)
+		}
+	}
+}
+
 /*
 func Test_parseTime(t *testing.T) {
 	type args struct {
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/time/rate"
+)
+
+const (
+	DirectMessageChunkSize     = 1900 // The largest message sent (Discord's limit is 2000 characters)
+	DirectMessageRatePerSecond = 25   // The most direct messages sent per second, across all channels
+	DirectMessageChannelBurst  = 5    // Discord allows 5 messages per 5 seconds in a channel
+)
+
+// DirectMessageSender sends direct messages, splitting long content into chunks, within Discord's rate limits.
+type DirectMessageSender struct {
+	sync.Mutex
+
+	dg          *discordgo.Session
+	global      *rate.Limiter
+	channels    *MapOf[string, *rate.Limiter] // map[channelID]limiter
+	pausedUntil time.Time                     // Set when Discord reports that a rate limit was hit
+}
+
+func NewDirectMessageSender(dg *discordgo.Session) *DirectMessageSender {
+	s := &DirectMessageSender{
+		dg:       dg,
+		global:   rate.NewLimiter(DirectMessageRatePerSecond, 1),
+		channels: &MapOf[string, *rate.Limiter]{},
+	}
+
+	dg.AddHandler(func(_ *discordgo.Session, m *discordgo.RateLimit) {
+		if m.TooManyRequests != nil {
+			s.pause(m.RetryAfter)
+		}
+	})
+
+	return s
+}
+
+func (s *DirectMessageSender) pause(d time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+	if until := time.Now().Add(d); until.After(s.pausedUntil) {
+		s.pausedUntil = until
+	}
+}
+
+// wait blocks until a message may be sent to the channel.
+func (s *DirectMessageSender) wait(ctx context.Context, channelID string) error {
+	s.Lock()
+	pause := time.Until(s.pausedUntil)
+	s.Unlock()
+
+	if pause > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pause):
+		}
+	}
+
+	limiter, _ := s.channels.LoadOrStore(channelID, rate.NewLimiter(rate.Every(time.Second), DirectMessageChannelBurst))
+	if err := limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return s.global.Wait(ctx)
+}
+
+// Send sends the messages to the channel, in order, splitting any that are too long.
+func (s *DirectMessageSender) Send(ctx context.Context, channelID string, messages ...string) error {
+	for _, m := range messages {
+		for _, chunk := range SplitMessage(m, DirectMessageChunkSize) {
+			if strings.TrimSpace(chunk) == "" {
+				continue
+			}
+			if err := s.wait(ctx, channelID); err != nil {
+				return err
+			}
+			if _, err := s.dg.ChannelMessageSend(channelID, chunk); err != nil {
+				return fmt.Errorf("failed to send message: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// SendUser sends the messages to the user's DM channel.
+func (s *DirectMessageSender) SendUser(ctx context.Context, discordID string, messages ...string) error {
+	channel, err := s.dg.UserChannelCreate(discordID)
+	if err != nil {
+		return fmt.Errorf("failed to create DM channel: %w", err)
+	}
+	return s.Send(ctx, channel.ID, messages...)
+}
+
+// SplitMessage splits the content into chunks of at most size bytes, breaking after a newline where possible.
+func SplitMessage(content string, size int) []string {
+	chunks := make([]string, 0, len(content)/size+1)
+	for len(content) > size {
+		i := strings.LastIndex(content[:size], "\n") + 1
+		if i == 0 {
+			// No newline; break at the last full character.
+			i = size
+			for i > 0 && !utf8.RuneStart(content[i]) {
+				i--
+			}
+			if i == 0 {
+				i = size
+			}
+		}
+		chunks = append(chunks, content[:i])
+		content = content[i:]
+	}
+	if content != "" {
+		chunks = append(chunks, content)
+	}
+	return chunks
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSplitMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		size    int
+		want    []string
+	}{
+		{"empty", "", 10, []string{}},
+		{"fits", "hello", 10, []string{"hello"}},
+		{"breaks after newline", "abc\ndefgh\nij", 8, []string{"abc\n", "defgh\nij"}},
+		{"no newline", "abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"keeps runes whole", "aé" + "bc", 2, []string{"a", "é", "bc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitMessage(tt.content, tt.size)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SplitMessage() = %q, want %q", got, tt.want)
+			}
+			if strings.Join(got, "") != tt.content {
+				t.Errorf("SplitMessage() lost content: %q", got)
+			}
+		})
+	}
+}
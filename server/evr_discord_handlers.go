@@ -3,15 +3,18 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/gofrs/uuid/v5"
 	"github.com/heroiclabs/nakama-common/runtime"
 	"github.com/heroiclabs/nakama/v3/server/evr"
-	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -30,7 +33,7 @@ func (d *DiscordAppBot) handleInteractionApplicationCommand(logger runtime.Logge
 	groupID := d.cache.GuildIDToGroupID(i.GuildID)
 
 	switch commandName {
-	case "link-headset":
+	case "link-headset", "link-status", "login-link":
 
 	case "unlink-headset":
 
@@ -83,13 +86,37 @@ func (d *DiscordAppBot) handleInteractionApplicationCommand(logger runtime.Logge
 			return simpleInteractionResponse(s, i, "This guild does not allow public allocation.")
 		}
 
-	case "allocate":
+	case "lock-match", "unlock-match":
+
+		if group.AuditChannelID != "" {
+			if err := d.LogInteractionToChannel(i, group.AuditChannelID); err != nil {
+				logger.Warn("Failed to log interaction to channel")
+			}
+		}
+
+		if !perms.IsModerator && !perms.IsAllocator {
+			return simpleInteractionResponse(s, i, "You must be a guild moderator or allocator to use this command.")
+		}
+
+	case "spectate":
+
+		if perms.IsSuspended {
+			return simpleInteractionResponse(s, i, "You are suspended from this guild.")
+		}
+
+	case "set-default", "arena-teamsize", "combat-teamsize":
+
+		if !perms.IsModerator {
+			return simpleInteractionResponse(s, i, "You must be a guild moderator to use this command.")
+		}
+
+	case "allocate", "server-allocate-bulk", "server-reserve", "server-start":
 
 		if !perms.IsAllocator {
 			return simpleInteractionResponse(s, i, "You must be a guild allocator to use this command.")
 		}
 
-	case "trigger-cv", "kick-player", "join-player":
+	case "trigger-cv", "kick", "kick-player", "join-player", "set-display-name", "purge-display-name-history", "match-kick-all":
 
 		if group.AuditChannelID != "" {
 			if err := d.LogInteractionToChannel(i, group.AuditChannelID); err != nil {
@@ -119,8 +146,6 @@ func (d *DiscordAppBot) handleInteractionMessageComponent(logger runtime.Logger,
 	userID := d.cache.DiscordIDToUserID(user.ID)
 	groupID := d.cache.GuildIDToGroupID(i.GuildID)
 
-	_ = groupID
-
 	switch commandName {
 	case "approve_ip":
 		ip := net.ParseIP(value)
@@ -181,6 +206,265 @@ func (d *DiscordAppBot) handleInteractionMessageComponent(logger runtime.Logger,
 			return fmt.Errorf("failed to respond to interaction: %w", err)
 		}
 		return err
+	case "account_merge":
+		primaryUserID, secondaryUserID, _ := strings.Cut(value, ":")
+		if primaryUserID == "" || secondaryUserID == "" {
+			return simpleInteractionResponse(s, i, "Invalid account merge request.")
+		}
+
+		// Limit access to global developers
+		if ok, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalDevelopers); err != nil {
+			return fmt.Errorf("failed to check group membership: %w", err)
+		} else if !ok {
+			return simpleInteractionResponse(s, i, "You do not have permission to merge accounts.")
+		}
+
+		relinked, err := MergeUserAccounts(ctx, nk, d.db, primaryUserID, secondaryUserID)
+		if err != nil {
+			return fmt.Errorf("failed to merge accounts: %w", err)
+		}
+
+		d.cache.QueueSyncMember(i.GuildID, d.cache.UserIDToDiscordID(primaryUserID))
+		d.cache.QueueSyncMember(i.GuildID, d.cache.UserIDToDiscordID(secondaryUserID))
+
+		logger.WithFields(map[string]any{
+			"primary_user_id":   primaryUserID,
+			"secondary_user_id": secondaryUserID,
+			"devices":           relinked,
+		}).Info("Merged accounts")
+
+		_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("<@%s> merged account <@%s> into <@%s> (relinked %d devices).", user.ID, secondaryUserID, primaryUserID, len(relinked)), true)
+
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    d.cache.ReplaceMentions(fmt.Sprintf("Merged <@%s> into <@%s>. Relinked devices: `%s`", secondaryUserID, primaryUserID, strings.Join(relinked, "`, `"))),
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+	case "join_match":
+		matchID, err := MatchIDFromString(value)
+		if err != nil {
+			return simpleInteractionResponse(s, i, "Invalid match ID.")
+		}
+
+		guildGroups, err := UserGuildGroupsList(ctx, nk, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get guild groups: %w", err)
+		}
+
+		group, ok := guildGroups[groupID]
+		if !ok {
+			return simpleInteractionResponse(s, i, "You must be a member of this guild to join the match.")
+		}
+		if group.PermissionsUser(userID).IsSuspended {
+			return simpleInteractionResponse(s, i, "You are suspended from this guild.")
+		}
+
+		label, err := MatchLabelByID(ctx, nk, matchID)
+		if err != nil || label == nil {
+			return simpleInteractionResponse(s, i, "The match is no longer available.")
+		}
+		if label.GetGroupID().String() != groupID {
+			return simpleInteractionResponse(s, i, "The match does not belong to this guild.")
+		}
+
+		if err := SetNextMatchID(ctx, nk, userID, label.ID, AnyTeam, ""); errors.Is(err, ErrNextMatchFull) {
+			return simpleInteractionResponse(s, i, "The match is full.")
+		} else if errors.Is(err, ErrNextMatchUnavailable) {
+			return simpleInteractionResponse(s, i, "The match is no longer available.")
+		} else if err != nil {
+			return fmt.Errorf("failed to set next match ID: %w", err)
+		}
+
+		return simpleInteractionResponse(s, i, "Click play or start matchmaking to join the match.")
+	case "search_page":
+		page, allGuilds, partial, err := parseSearchPageValue(value)
+		if err != nil {
+			return simpleInteractionResponse(s, i, "Invalid search page.")
+		}
+
+		if allGuilds {
+			if isGlobalModerator, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalModerators); err != nil {
+				return fmt.Errorf("failed to check global moderator status: %w", err)
+			} else if !isGlobalModerator {
+				return simpleInteractionResponse(s, i, "You must be a global moderator to search all guilds.")
+			}
+		}
+
+		results, err := d.searchDisplayNames(ctx, partial, groupID, allGuilds)
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			return simpleInteractionResponse(s, i, "No results found")
+		}
+
+		embed, components := searchResultsPage(partial, results, page, allGuilds)
+
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{embed},
+				Components: components,
+			},
+		})
+	case "combat_loadout":
+		data := i.Interaction.MessageComponentData()
+		if len(data.Values) == 0 {
+			return simpleInteractionResponse(s, i, "Invalid selection.")
+		}
+
+		profile, err := d.setCombatLoadout(ctx, userID, value, data.Values[0])
+		if err != nil {
+			return err
+		}
+
+		content, components := combatLoadoutMessage(profile.GetClient())
+
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    content,
+				Components: components,
+			},
+		})
+	case "kick_player":
+		data := i.Interaction.MessageComponentData()
+		if len(data.Values) == 0 {
+			return simpleInteractionResponse(s, i, "Invalid selection.")
+		}
+		targetUserID := data.Values[0]
+		reason := value
+
+		// Permissions may have changed since the menu was shown.
+		guildGroups, err := UserGuildGroupsList(ctx, nk, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get guild groups: %w", err)
+		}
+		if g, ok := guildGroups[groupID]; !ok || !g.PermissionsUser(userID).IsModerator {
+			return simpleInteractionResponse(s, i, "You must be a guild moderator to kick a player.")
+		}
+
+		presences, err := nk.StreamUserList(StreamModeService, targetUserID, "", StreamLabelMatchService, false, true)
+		if err != nil {
+			return fmt.Errorf("failed to get user list: %w", err)
+		}
+
+		var label *MatchLabel
+		for _, p := range presences {
+			if label, _ = MatchLabelByID(ctx, nk, MatchIDFromStringOrNil(p.GetStatus())); label != nil {
+				break
+			}
+		}
+
+		if label == nil {
+			return simpleInteractionResponse(s, i, "The player is no longer in a match.")
+		}
+
+		if label.GetGroupID().String() != groupID {
+			return simpleInteractionResponse(s, i, "The player's match is not from this guild.")
+		}
+
+		if err := KickPlayerFromMatch(ctx, nk, label.ID, targetUserID); err != nil {
+			return fmt.Errorf("failed to kick player: %w", err)
+		}
+
+		content := fmt.Sprintf("<@%s> kicked player <@%s> from [%s](%s) match.", user.ID, d.cache.UserIDToDiscordID(targetUserID), label.Mode.String(), d.sparkLink(label.ID))
+		if reason != "" {
+			content += fmt.Sprintf(" Reason: %s", reason)
+		}
+		_, _ = d.LogAuditMessage(ctx, groupID, content, false)
+
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    fmt.Sprintf("Kicked <@%s> from the match.", d.cache.UserIDToDiscordID(targetUserID)),
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+	case "purge_display_names":
+		targetUserID, keepStr, _ := strings.Cut(value, ":")
+		keep, err := strconv.Atoi(keepStr)
+		if targetUserID == "" || err != nil || keep < 0 {
+			return simpleInteractionResponse(s, i, "Invalid purge request.")
+		}
+
+		guildGroups, err := UserGuildGroupsList(ctx, nk, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get guild groups: %w", err)
+		}
+		if g, ok := guildGroups[groupID]; !ok || !g.PermissionsUser(userID).IsModerator {
+			return simpleInteractionResponse(s, i, "You must be a guild moderator to purge display name history.")
+		}
+
+		history, err := DisplayNameHistoryLoad(ctx, nk, targetUserID)
+		if err != nil {
+			return err
+		}
+
+		removed := history.Trim(groupID, keep)
+		if removed > 0 {
+			if err := DisplayNameHistoryStore(ctx, nk, targetUserID, history); err != nil {
+				return err
+			}
+		}
+
+		_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("<@%s> purged %d display names from the history of <@%s>.", user.ID, removed, d.cache.UserIDToDiscordID(targetUserID)), false)
+
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    fmt.Sprintf("Removed %d display names from the history.", removed),
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+	case "match_kick_all":
+		matchID := MatchIDFromStringOrNil(value)
+		if matchID.IsNil() {
+			return simpleInteractionResponse(s, i, "Invalid match ID.")
+		}
+
+		guildGroups, err := UserGuildGroupsList(ctx, nk, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get guild groups: %w", err)
+		}
+		if g, ok := guildGroups[groupID]; !ok || !g.PermissionsUser(userID).IsModerator {
+			return simpleInteractionResponse(s, i, "You must be a guild moderator to clear a match.")
+		}
+
+		label, err := MatchLabelByID(ctx, nk, matchID)
+		if err != nil || label == nil {
+			return simpleInteractionResponse(s, i, "The match no longer exists.")
+		}
+
+		if label.GetGroupID().String() != groupID {
+			return simpleInteractionResponse(s, i, "The match is not from this guild.")
+		}
+
+		kicked := 0
+		for _, p := range label.Players {
+			if err := KickPlayerFromMatch(ctx, nk, label.ID, p.UserID); err != nil {
+				logger.Warn("Failed to kick player %s: %v", p.UserID, err)
+				continue
+			}
+			kicked++
+			_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("<@%s> kicked <@%s> (%s) from [%s](%s) match.", user.ID, p.DiscordID, EscapeDiscordMarkdown(p.DisplayName), label.Mode.String(), d.sparkLink(label.ID)), false)
+		}
+
+		if _, err := SignalMatch(ctx, nk, label.ID, SignalShutdown, SignalShutdownPayload{GraceSeconds: MatchKickAllGraceSeconds, DisconnectUsers: true}); err != nil {
+			logger.Warn("Failed to shut down match %s: %v", label.ID.String(), err)
+		}
+
+		_, _ = d.LogAuditMessage(ctx, groupID, fmt.Sprintf("<@%s> cleared [%s](%s) match.", user.ID, label.Mode.String(), d.sparkLink(label.ID)), false)
+
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    fmt.Sprintf("Kicked %d of %d players; the match will shut down in %d seconds.", kicked, len(label.Players), MatchKickAllGraceSeconds),
+				Components: []discordgo.MessageComponent{},
+			},
+		})
 	case "unlink-headset":
 		data := i.Interaction.MessageComponentData()
 		if len(data.Values) == 0 {
@@ -209,7 +493,20 @@ func (d *DiscordAppBot) handleInteractionMessageComponent(logger runtime.Logger,
 	return nil
 }
 
-func (d *DiscordAppBot) handleAllocateMatch(ctx context.Context, logger runtime.Logger, userID, guildID string, region, mode, level evr.Symbol, startTime time.Time) (l *MatchLabel, rtt float64, err error) {
+// handleAllocateMatch allocates a match for the user, subject to their prepare match rate limit.
+func (d *DiscordAppBot) handleAllocateMatch(ctx context.Context, logger runtime.Logger, userID, guildID string, region, mode, level evr.Symbol, startTime time.Time) (*MatchLabel, float64, error) {
+	limiter := d.loadPrepareMatchRateLimiter(userID, d.cache.GuildIDToGroupID(guildID))
+	if !limiter.Allow() {
+		return nil, 0, status.Error(codes.ResourceExhausted, fmt.Sprintf("rate limit exceeded (%0.0f requests per minute)", limiter.Limit()*60))
+	}
+	return d.allocateMatch(ctx, logger, userID, guildID, region, mode, level, startTime)
+}
+
+// allocateMatch prepares an unassigned match on a game server the user may allocate. The caller is responsible for rate limiting.
+func (d *DiscordAppBot) allocateMatch(ctx context.Context, logger runtime.Logger, userID, guildID string, region, mode, level evr.Symbol, startTime time.Time) (l *MatchLabel, rtt float64, err error) {
+	correlationID := uuid.Must(uuid.NewV4()).String()
+	ctx = WithSignalCorrelationID(ctx, correlationID)
+	logger = logger.WithField("correlation_id", correlationID)
 
 	// Find a parking match to prepare
 
@@ -235,16 +532,11 @@ func (d *DiscordAppBot) handleAllocateMatch(ctx context.Context, logger runtime.
 		return nil, 0, status.Error(codes.PermissionDenied, "user does not have the allocator role in this guild.")
 	}
 
-	limiter := d.loadPrepareMatchRateLimiter(userID, groupID)
-	if !limiter.Allow() {
-		return nil, 0, status.Error(codes.ResourceExhausted, fmt.Sprintf("rate limit exceeded (%0.0f requests per minute)", limiter.Limit()*60))
-	}
-
 	query := fmt.Sprintf("+label.lobby_type:unassigned +label.broadcaster.group_ids:/(%s)/ +label.broadcaster.regions:/(%s)/", Query.Join(allocatorGroupIDs, "|"), region.String())
 
 	minSize := 1
 	maxSize := 1
-	matches, err := d.nk.MatchList(ctx, 100, true, "", &minSize, &maxSize, query)
+	matches, err := d.listMatches(ctx, 100, &minSize, &maxSize, query)
 	if err != nil {
 		return nil, 0, status.Errorf(codes.Internal, "failed to list matches `%s`: %v", query, err)
 	}
@@ -294,9 +586,6 @@ func (d *DiscordAppBot) handleAllocateMatch(ctx context.Context, logger runtime.
 		lobbyCreateSortOptions(labels, labelLatencies, &params)
 	}
 
-	// Pick a random result
-	match := matches[0]
-	matchID := MatchIDFromStringOrNil(match.GetMatchId())
 	gid := uuid.FromStringOrNil(groupID)
 	// Prepare the session for the match.
 
@@ -314,16 +603,165 @@ func (d *DiscordAppBot) handleAllocateMatch(ctx context.Context, logger runtime.
 		SpawnedBy: userID,
 	}
 
-	label, err := LobbyPrepareSession(ctx, d.nk, matchID, &settings)
+	// Try the next-best servers if the first does not respond
+	label, err := PrepareFirstAvailable(logger, labels, AllocateMaxCandidates, func(matchID MatchID) (*MatchLabel, error) {
+		return LobbyPrepareSession(ctx, d.nk, matchID, &settings)
+	})
 	if err != nil {
-		logger.Warn("Failed to prepare session", zap.Error(err), zap.String("mid", matchID.UUID.String()))
 		return nil, -1, fmt.Errorf("failed to prepare session: %w", err)
 	}
 
 	return label, rtt, nil
 }
 
-func (d *DiscordAppBot) handleCreateMatch(ctx context.Context, logger runtime.Logger, userID, guildID string, region, mode, level evr.Symbol, startTime time.Time) (l *MatchLabel, latencyMillis int, err error) {
+// handleFindMatch finds the open public lobby with the lowest latency in the user's active group. Returns nil if none are available.
+func (d *DiscordAppBot) handleFindMatch(ctx context.Context, logger runtime.Logger, userID string, mode evr.Symbol) (l *MatchLabel, latencyMillis int, err error) {
+
+	md, err := GetAccountMetadata(ctx, d.nk, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get account metadata: %w", err)
+	}
+
+	groupID := md.GetActiveGroupID()
+	if groupID.IsNil() {
+		return nil, 0, status.Error(codes.FailedPrecondition, "no active guild set; use `/set-lobby` first")
+	}
+
+	guildGroups, err := UserGuildGroupsList(ctx, d.nk, userID)
+	if err != nil {
+		return nil, 0, status.Errorf(codes.Internal, "failed to get guild groups: %v", err)
+	}
+
+	group, ok := guildGroups[groupID.String()]
+	if !ok {
+		return nil, 0, status.Error(codes.PermissionDenied, "user is not a member of the guild")
+	}
+
+	if group.PermissionsUser(userID).IsSuspended {
+		return nil, 0, status.Error(codes.PermissionDenied, "user is suspended from the guild")
+	}
+
+	query := fmt.Sprintf("+label.open:T +label.lobby_type:public +label.mode:%s +label.group_id:/%s/", mode.String(), Query.Escape(groupID.String()))
+	matches, err := ListMatchStates(ctx, d.nk, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list matches: %w", err)
+	}
+
+	labels := make([]*MatchLabel, 0, len(matches))
+	for _, m := range matches {
+		if m.State.OpenPlayerSlots() > 0 {
+			labels = append(labels, m.State)
+		}
+	}
+
+	if len(labels) == 0 {
+		return nil, 0, nil
+	}
+
+	zapLogger := logger.(*RuntimeGoLogger).logger
+	latencyHistory, err := LoadLatencyHistory(ctx, zapLogger, d.db, uuid.FromStringOrNil(userID))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load latency history: %w", err)
+	}
+
+	best := latencyHistory.LabelsByAverageRTT(labels)[0]
+
+	return best.Label, best.RTT, nil
+}
+
+// handleQueueMatchmaking queues the player to matchmake for the mode from their active game session. The next social lobby
+// request from the game client is replaced with a matchmaking request, so a player in a social lobby is sent back to the lobby to start it.
+func (d *DiscordAppBot) handleQueueMatchmaking(ctx context.Context, logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, userID string, mode evr.Symbol) error {
+	presences, err := d.nk.StreamUserList(StreamModeService, userID, "", StreamLabelMatchService, false, true)
+	if err != nil {
+		return fmt.Errorf("failed to get user presences: %w", err)
+	}
+	if len(presences) == 0 {
+		return errors.New("you must be in-game to start matchmaking")
+	}
+
+	settings, err := LoadMatchmakingSettings(ctx, d.nk, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load matchmaking settings: %w", err)
+	}
+	settings.NextMatchMode = mode
+	if _, err := SaveToStorage(ctx, d.nk, userID, settings); err != nil {
+		return fmt.Errorf("failed to save matchmaking settings: %w", err)
+	}
+
+	label, _ := MatchLabelByID(ctx, d.nk, MatchIDFromStringOrNil(presences[0].GetStatus()))
+	if label == nil || !label.IsSocial() {
+		return simpleInteractionResponse(s, i, fmt.Sprintf("You will start matchmaking for `%s` when you return to the lobby.", mode.String()))
+	}
+
+	if err := KickPlayerFromMatch(ctx, d.nk, label.ID, userID); err != nil {
+		logger.Warn("Failed to remove player from social lobby: %v", err)
+		return simpleInteractionResponse(s, i, fmt.Sprintf("You will start matchmaking for `%s` the next time you load into a lobby.", mode.String()))
+	}
+
+	content := fmt.Sprintf("Starting matchmaking for `%s`...", mode.String())
+	if settings.LobbyGroupName != "" {
+		content += fmt.Sprintf(" Your party group is `%s`.", settings.LobbyGroupName)
+	}
+	return simpleInteractionResponse(s, i, content)
+}
+
+// partyUserIDs returns the user IDs of the user's party, or just the user if they are not in one.
+func (d *DiscordAppBot) partyUserIDs(ctx context.Context, userID string) ([]string, error) {
+	mmSettings, err := LoadMatchmakingSettings(ctx, d.nk, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load matchmaking settings: %w", err)
+	}
+
+	userIDs := []string{userID}
+	if mmSettings.LobbyGroupName != "" {
+		if partyUserIDs, err := GetPartyGroupUserIDs(ctx, d.nk, mmSettings.LobbyGroupName); err == nil {
+			for _, id := range partyUserIDs {
+				if !slices.Contains(userIDs, id) {
+					userIDs = append(userIDs, id)
+				}
+			}
+		}
+	}
+	return userIDs, nil
+}
+
+// partyReservations creates slot reservations for the lobby sessions of the user and their party.
+func (d *DiscordAppBot) partyReservations(ctx context.Context, logger runtime.Logger, userID, groupID string) ([]*EvrMatchPresence, error) {
+	userIDs, err := d.partyUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations := make([]*EvrMatchPresence, 0, len(userIDs))
+	for _, id := range userIDs {
+		presences, err := d.nk.StreamUserList(StreamModeService, id, "", StreamLabelMatchService, false, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user list: %w", err)
+		}
+
+		for _, p := range presences {
+			session := d.pipeline.sessionRegistry.Get(uuid.FromStringOrNil(p.GetSessionId()))
+			if session == nil {
+				continue
+			}
+
+			presence, err := EntrantPresenceFromSession(session, groupID)
+			if err != nil {
+				logger.Warn("Failed to create reservation for %s: %v", id, err)
+				continue
+			}
+			reservations = append(reservations, presence)
+		}
+	}
+
+	return reservations, nil
+}
+
+func (d *DiscordAppBot) handleCreateMatch(ctx context.Context, logger runtime.Logger, userID, guildID string, region, mode, level evr.Symbol, startTime time.Time, maxRounds int, holdDuration time.Duration) (l *MatchLabel, latencyMillis int, err error) {
+	correlationID := uuid.Must(uuid.NewV4()).String()
+	ctx = WithSignalCorrelationID(ctx, correlationID)
+	logger = logger.WithField("correlation_id", correlationID)
 
 	// Find a parking match to prepare
 
@@ -348,6 +786,16 @@ func (d *DiscordAppBot) handleCreateMatch(ctx context.Context, logger runtime.Lo
 		return nil, 0, status.Error(codes.PermissionDenied, "guild does not allow public match creation")
 	}
 
+	if minSize := group.MinPartySize(mode); minSize > 1 {
+		userIDs, err := d.partyUserIDs(ctx, userID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(userIDs) < minSize {
+			return nil, 0, status.Error(codes.FailedPrecondition, fmt.Sprintf("guild requires a party of at least %d players for `%s`", minSize, mode.String()))
+		}
+	}
+
 	limiter := d.loadPrepareMatchRateLimiter(userID, groupID)
 	if !limiter.Allow() {
 		return nil, 0, status.Error(codes.ResourceExhausted, fmt.Sprintf("rate limit exceeded (%0.0f requests per minute)", limiter.Limit()*60))
@@ -367,6 +815,15 @@ func (d *DiscordAppBot) handleCreateMatch(ctx context.Context, logger runtime.Lo
 		GroupID:   uuid.FromStringOrNil(groupID),
 		StartTime: startTime.UTC().Add(1 * time.Minute),
 		SpawnedBy: userID,
+		MaxRounds: maxRounds,
+	}
+
+	// Hold slots for the creator's party while they join.
+	if holdDuration > 0 {
+		if settings.Reservations, err = d.partyReservations(ctx, logger, userID, groupID); err != nil {
+			return nil, 0, err
+		}
+		settings.ReservationLifetime = holdDuration
 	}
 
 	label, err := AllocateGameServer(ctx, logger, d.nk, groupID, extIPs, settings, []string{region.String()}, true, false)
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// InactivePartyGroupDefaultDays is how long a party group's members must be inactive before it is reported by default.
+const InactivePartyGroupDefaultDays = 30
+
+type partyGroupMemberActivity struct {
+	GroupName  string
+	UserID     string
+	LastActive time.Time
+}
+
+type stalePartyGroup struct {
+	Name       string
+	UserIDs    []string
+	LastActive time.Time // The most recent activity of any member
+}
+
+// listPartyGroupMemberActivity returns each user with a stored party group, and when they last logged in.
+func listPartyGroupMemberActivity(ctx context.Context, db *sql.DB) ([]partyGroupMemberActivity, error) {
+	query := `
+	SELECT m.value->>'group_id', m.user_id, COALESCE(h.update_time, m.update_time)
+	FROM storage m
+	LEFT JOIN storage h ON h.user_id = m.user_id AND h.collection = $3 AND h.key = $4
+	WHERE m.collection = $1 AND m.key = $2 AND COALESCE(m.value->>'group_id', '') <> ''
+	`
+	rows, err := db.QueryContext(ctx, query, MatchmakerStorageCollection, MatchmakingConfigStorageKey, LoginStorageCollection, LoginHistoryStorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list party group members: %w", err)
+	}
+	defer rows.Close()
+
+	members := make([]partyGroupMemberActivity, 0)
+	for rows.Next() {
+		var m partyGroupMemberActivity
+		if err := rows.Scan(&m.GroupName, &m.UserID, &m.LastActive); err != nil {
+			return nil, fmt.Errorf("failed to scan party group member: %w", err)
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// stalePartyGroups returns the party groups that have had no active members since the cutoff, least recently active first.
+func stalePartyGroups(members []partyGroupMemberActivity, cutoff time.Time) []*stalePartyGroup {
+	groups := make(map[string]*stalePartyGroup)
+	for _, m := range members {
+		g, ok := groups[m.GroupName]
+		if !ok {
+			g = &stalePartyGroup{Name: m.GroupName}
+			groups[m.GroupName] = g
+		}
+		g.UserIDs = append(g.UserIDs, m.UserID)
+		if m.LastActive.After(g.LastActive) {
+			g.LastActive = m.LastActive
+		}
+	}
+
+	stale := make([]*stalePartyGroup, 0, len(groups))
+	for _, g := range groups {
+		if g.LastActive.Before(cutoff) {
+			stale = append(stale, g)
+		}
+	}
+	slices.SortFunc(stale, func(a, b *stalePartyGroup) int {
+		return a.LastActive.Compare(b.LastActive)
+	})
+	return stale
+}
+
+// clearPartyGroups clears the stored party group of the groups' members, unless they have since changed it.
+func (d *DiscordAppBot) clearPartyGroups(ctx context.Context, groups []*stalePartyGroup) (cleared int, err error) {
+	for _, g := range groups {
+		for _, userID := range g.UserIDs {
+			settings, err := LoadMatchmakingSettings(ctx, d.nk, userID)
+			if err != nil {
+				return cleared, fmt.Errorf("failed to load matchmaking settings: %w", err)
+			}
+			if settings.LobbyGroupName != g.Name {
+				continue
+			}
+			settings.LobbyGroupName = ""
+			if _, err := SaveToStorage(ctx, d.nk, userID, settings); err != nil {
+				return cleared, fmt.Errorf("failed to save matchmaking settings: %w", err)
+			}
+			cleared++
+		}
+	}
+	return cleared, nil
+}
+
+// stalePartyGroupsMessage describes the stale party groups.
+func stalePartyGroupsMessage(groups []*stalePartyGroup, days int) string {
+	if len(groups) == 0 {
+		return fmt.Sprintf("No party groups have been inactive for %d days.", days)
+	}
+	content := fmt.Sprintf("%d party groups have been inactive for %d days:\n", len(groups), days)
+	for _, g := range groups {
+		content += fmt.Sprintf("`%s`: %d members, last active <t:%d:R>\n", g.Name, len(g.UserIDs), g.LastActive.Unix())
+	}
+	return content
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStalePartyGroups(t *testing.T) {
+	now := time.Now()
+	cutoff := now.AddDate(0, 0, -30)
+
+	members := []partyGroupMemberActivity{
+		{GroupName: "active", UserID: "a1", LastActive: now.AddDate(0, 0, -60)},
+		{GroupName: "active", UserID: "a2", LastActive: now.AddDate(0, 0, -1)},
+		{GroupName: "stale", UserID: "s1", LastActive: now.AddDate(0, 0, -45)},
+		{GroupName: "stale", UserID: "s2", LastActive: now.AddDate(0, 0, -90)},
+		{GroupName: "staler", UserID: "t1", LastActive: now.AddDate(0, 0, -120)},
+	}
+
+	got := stalePartyGroups(members, cutoff)
+	if len(got) != 2 {
+		t.Fatalf("len(stalePartyGroups()) = %d, want 2", len(got))
+	}
+	if got[0].Name != "staler" || got[1].Name != "stale" {
+		t.Errorf("stalePartyGroups() = [%s, %s], want [staler, stale]", got[0].Name, got[1].Name)
+	}
+	if len(got[1].UserIDs) != 2 || !got[1].LastActive.Equal(members[2].LastActive) {
+		t.Errorf("stale group = %+v, want both members and the most recent activity", got[1])
+	}
+}
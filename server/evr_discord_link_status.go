@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// linkStatusEmbed builds an embed describing the user's linked devices, pending link tickets, and authorized IPs.
+func (d *DiscordAppBot) linkStatusEmbed(ctx context.Context, userID string) (*discordgo.MessageEmbed, error) {
+	embed := &discordgo.MessageEmbed{
+		Title: "Headset Link Status",
+		Color: 0xCCCCCC,
+	}
+
+	if userID == "" {
+		embed.Description = "No account found for this Discord user."
+		embed.Fields = []*discordgo.MessageEmbedField{
+			{
+				Name:  "Next Steps",
+				Value: "Start EchoVR to receive a four letter link code, then use `/link-headset` with that code.",
+			},
+		}
+		return embed, nil
+	}
+
+	account, err := d.nk.AccountGetId(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	loginHistory, err := LoginHistoryLoad(ctx, d.nk, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load login history: %w", err)
+	}
+
+	linkTickets, err := LoadLinkTickets(ctx, d.nk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load link tickets: %w", err)
+	}
+
+	devices := make([]string, 0, len(account.GetDevices()))
+	for _, device := range account.GetDevices() {
+		devices = append(devices, fmt.Sprintf("`%s`", device.GetId()))
+	}
+
+	// A pending ticket belongs to this user if it was created from one of their devices. IP addresses are not
+	// used, since players behind the same NAT share them; nor is a device that is linked to another account.
+	pending := make([]string, 0)
+	for code, ticket := range linkTickets {
+		if _, ok := loginHistory.XPIs[ticket.XPID.String()]; !ok {
+			continue
+		}
+		if ownerID, err := GetUserIDByDeviceID(ctx, RuntimeLoggerToZapLogger(d.logger), d.db, ticket.XPID.Token()); err == nil && ownerID != userID {
+			continue
+		}
+		pending = append(pending, fmt.Sprintf("`%s` (`%s`)", code, ticket.XPID.Token()))
+	}
+
+	tips := make([]string, 0, 3)
+	switch {
+	case len(devices) == 0 && len(pending) == 0:
+		tips = append(tips, "- No headsets are linked. Start EchoVR to receive a link code, then use `/link-headset`.")
+	case len(pending) > 0:
+		tips = append(tips, "- Use `/link-headset` with the pending link code shown above.")
+	}
+	if len(loginHistory.AuthorizedIPs) == 0 && len(devices) > 0 {
+		tips = append(tips, "- No IP addresses are authorized. Check your Discord DMs for a verification request after logging in.")
+	}
+	if account.GetDisableTime() != nil {
+		tips = append(tips, "- This account has been disabled.")
+	}
+	if len(tips) == 0 {
+		tips = append(tips, "- Everything looks good. If you are still having trouble, restart EchoVR.")
+	}
+
+	valueOrNone := func(values []string) string {
+		if len(values) == 0 {
+			return "None"
+		}
+		return strings.Join(values, "\n")
+	}
+
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{Name: "Linked Devices", Value: valueOrNone(devices), Inline: false},
+		{Name: "Pending Link Codes", Value: valueOrNone(pending), Inline: false},
+		{Name: "Authorized IPs", Value: fmt.Sprintf("%d", len(loginHistory.AuthorizedIPs)), Inline: true},
+		{Name: "Tips", Value: strings.Join(tips, "\n"), Inline: false},
+	}
+
+	return embed, nil
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/heroiclabs/nakama/v3/server/evr"
+)
+
+type queueStatusEntry struct {
+	GroupID  string
+	Mode     evr.Symbol
+	Sessions int
+	Party    int
+	Solo     int
+	Oldest   time.Time
+}
+
+// queueStatus summarizes the matchmaking stream presences by group and mode.
+func (d *DiscordAppBot) queueStatus() []*queueStatusEntry {
+	mode := uint8(StreamModeMatchmaking)
+	streams := d.pipeline.tracker.CountByStreamModeFilter(map[uint8]*uint8{StreamModeMatchmaking: &mode})
+
+	entries := make(map[string]*queueStatusEntry)
+	for stream := range streams {
+		for _, presence := range d.pipeline.tracker.ListByStream(*stream, true, true) {
+			status := struct {
+				Mode                 evr.Symbol `json:"mode"`
+				PartyID              uuid.UUID  `json:"party_id"`
+				MatchmakingTimestamp time.Time  `json:"matchmaking_timestamp"`
+			}{}
+			if err := json.Unmarshal([]byte(presence.Meta.Status), &status); err != nil {
+				continue
+			}
+
+			key := stream.Subject.String() + ":" + status.Mode.String()
+			e, ok := entries[key]
+			if !ok {
+				e = &queueStatusEntry{
+					GroupID: stream.Subject.String(),
+					Mode:    status.Mode,
+				}
+				entries[key] = e
+			}
+
+			e.Sessions++
+			if status.PartyID.IsNil() {
+				e.Solo++
+			} else {
+				e.Party++
+			}
+			if e.Oldest.IsZero() || status.MatchmakingTimestamp.Before(e.Oldest) {
+				e.Oldest = status.MatchmakingTimestamp
+			}
+		}
+	}
+
+	sorted := make([]*queueStatusEntry, 0, len(entries))
+	for _, e := range entries {
+		sorted = append(sorted, e)
+	}
+	slices.SortFunc(sorted, func(a, b *queueStatusEntry) int {
+		if c := strings.Compare(a.GroupID, b.GroupID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Mode.String(), b.Mode.String())
+	})
+	return sorted
+}
+
+// queueStatusMessages renders the queue status as messages that fit within Discord's message limit.
+func (d *DiscordAppBot) queueStatusMessages(entries []*queueStatusEntry) []string {
+	if len(entries) == 0 {
+		return []string{"No sessions are matchmaking."}
+	}
+
+	messages := make([]string, 0)
+	var b strings.Builder
+	for _, e := range entries {
+		guildName := e.GroupID
+		if guildID := d.cache.GroupIDToGuildID(e.GroupID); guildID != "" {
+			if guild, err := d.dg.State.Guild(guildID); err == nil {
+				guildName = guild.Name
+			}
+		}
+
+		line := fmt.Sprintf("`%s` `%s`: %d sessions (%d party, %d solo), oldest %s\n", guildName, e.Mode.String(), e.Sessions, e.Party, e.Solo, time.Since(e.Oldest).Round(time.Second))
+		if b.Len()+len(line) > 1900 {
+			messages = append(messages, b.String())
+			b.Reset()
+		}
+		b.WriteString(line)
+	}
+	messages = append(messages, b.String())
+	return messages
+}
@@ -0,0 +1,296 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+const (
+	RegionStatusBoardInterval = 30 * time.Second
+
+	RegionStatusPinStorageCollection = "RegionStatusPins"
+	RegionStatusPinStorageKey        = "pins"
+)
+
+// RegionStatusPin is a region status message posted by the bot.
+type RegionStatusPin struct {
+	GuildID   string    `json:"guild_id"`
+	ChannelID string    `json:"channel_id"`
+	MessageID string    `json:"message_id"`
+	Region    string    `json:"region"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RegionStatusPins are the region status messages the bot has posted, so they can be found and removed later.
+type RegionStatusPins struct {
+	Pins []RegionStatusPin `json:"pins"`
+}
+
+// Add tracks the pin, replacing any existing pin for the same message.
+func (p *RegionStatusPins) Add(pin RegionStatusPin) {
+	p.Remove(pin.ChannelID, pin.MessageID)
+	p.Pins = append(p.Pins, pin)
+}
+
+// Remove stops tracking the message.
+func (p *RegionStatusPins) Remove(channelID, messageID string) {
+	p.Pins = slices.DeleteFunc(p.Pins, func(pin RegionStatusPin) bool {
+		return pin.ChannelID == channelID && pin.MessageID == messageID
+	})
+}
+
+// ByGuild returns the pins posted in the guild, or every pin if the guild ID is empty.
+func (p *RegionStatusPins) ByGuild(guildID string) []RegionStatusPin {
+	if guildID == "" {
+		return slices.Clone(p.Pins)
+	}
+	pins := make([]RegionStatusPin, 0)
+	for _, pin := range p.Pins {
+		if pin.GuildID == guildID {
+			pins = append(pins, pin)
+		}
+	}
+	return pins
+}
+
+func LoadRegionStatusPins(ctx context.Context, nk runtime.NakamaModule) (*RegionStatusPins, error) {
+	objs, err := nk.StorageRead(ctx, []*runtime.StorageRead{
+		{
+			Collection: RegionStatusPinStorageCollection,
+			Key:        RegionStatusPinStorageKey,
+			UserID:     SystemUserID,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read region status pins: %w", err)
+	}
+
+	pins := &RegionStatusPins{
+		Pins: make([]RegionStatusPin, 0),
+	}
+	if len(objs) > 0 {
+		if err := json.Unmarshal([]byte(objs[0].Value), pins); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal region status pins: %w", err)
+		}
+	}
+	return pins, nil
+}
+
+func StoreRegionStatusPins(ctx context.Context, nk runtime.NakamaModule, pins *RegionStatusPins) error {
+	data, err := json.Marshal(pins)
+	if err != nil {
+		return fmt.Errorf("failed to marshal region status pins: %w", err)
+	}
+
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{
+		{
+			Collection:      RegionStatusPinStorageCollection,
+			Key:             RegionStatusPinStorageKey,
+			UserID:          SystemUserID,
+			PermissionRead:  0,
+			PermissionWrite: 0,
+			Value:           string(data),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to write region status pins: %w", err)
+	}
+	return nil
+}
+
+// updateRegionStatusPins loads, modifies, and stores the region status pins.
+func (d *DiscordAppBot) updateRegionStatusPins(ctx context.Context, fn func(pins *RegionStatusPins)) error {
+	d.regionStatusPinsMu.Lock()
+	defer d.regionStatusPinsMu.Unlock()
+
+	pins, err := LoadRegionStatusPins(ctx, d.nk)
+	if err != nil {
+		return err
+	}
+	fn(pins)
+	return StoreRegionStatusPins(ctx, d.nk, pins)
+}
+
+// trackRegionStatusPin records a region status message posted in the channel.
+func (d *DiscordAppBot) trackRegionStatusPin(ctx context.Context, logger runtime.Logger, channelID, messageID, region string) {
+	guildID := ""
+	if d.dg.State != nil {
+		if channel, err := d.dg.State.Channel(channelID); err == nil {
+			guildID = channel.GuildID
+		}
+	}
+
+	if err := d.updateRegionStatusPins(ctx, func(pins *RegionStatusPins) {
+		pins.Add(RegionStatusPin{
+			GuildID:   guildID,
+			ChannelID: channelID,
+			MessageID: messageID,
+			Region:    region,
+			CreatedAt: time.Now().UTC(),
+		})
+	}); err != nil {
+		logger.Warn("Failed to track region status message: %v", err)
+	}
+}
+
+// untrackRegionStatusPin forgets a region status message that has been deleted.
+func (d *DiscordAppBot) untrackRegionStatusPin(ctx context.Context, logger runtime.Logger, channelID, messageID string) {
+	if err := d.updateRegionStatusPins(ctx, func(pins *RegionStatusPins) {
+		pins.Remove(channelID, messageID)
+	}); err != nil {
+		logger.Warn("Failed to untrack region status message: %v", err)
+	}
+}
+
+// cleanupRegionStatusPins deletes the tracked region status messages in the guild (or every guild, if the guild ID is empty).
+// It returns the number of messages deleted.
+func (d *DiscordAppBot) cleanupRegionStatusPins(ctx context.Context, logger runtime.Logger, guildID string) (int, error) {
+	deleted := 0
+	err := d.updateRegionStatusPins(ctx, func(pins *RegionStatusPins) {
+		for _, pin := range pins.ByGuild(guildID) {
+			if err := d.dg.ChannelMessageDelete(pin.ChannelID, pin.MessageID); err != nil {
+				// The message may already be gone; forget it either way.
+				logger.Warn("Failed to delete region status message %s in %s: %v", pin.MessageID, pin.ChannelID, err)
+			} else {
+				deleted++
+			}
+			pins.Remove(pin.ChannelID, pin.MessageID)
+		}
+	})
+	return deleted, err
+}
+
+type regionStatusBoardKey struct {
+	GuildID   string
+	Region    string
+	ChannelID string
+}
+
+// runRegionStatusBoards posts and updates the region status boards configured in each guild's metadata.
+func (d *DiscordAppBot) runRegionStatusBoards(ctx context.Context, logger runtime.Logger, interval time.Duration) {
+	// The message ID of each board
+	boards := make(map[regionStatusBoardKey]string)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.updateRegionStatusBoards(ctx, logger, boards); err != nil {
+				logger.Warn("Failed to update region status boards: %v", err)
+			}
+		}
+	}
+}
+
+func (d *DiscordAppBot) updateRegionStatusBoards(ctx context.Context, logger runtime.Logger, boards map[regionStatusBoardKey]string) error {
+	if d.dg == nil || d.dg.State == nil {
+		return nil
+	}
+
+	configured := make(map[regionStatusBoardKey]struct{})
+	for _, guild := range d.dg.State.Guilds {
+		groupID := d.cache.GuildIDToGroupID(guild.ID)
+		if groupID == "" {
+			continue
+		}
+
+		md, err := GetGuildGroupMetadata(ctx, d.db, groupID)
+		if err != nil {
+			logger.Warn("Failed to get guild group metadata for %s: %v", guild.ID, err)
+			continue
+		}
+
+		for region, channelID := range md.RegionStatusChannelIDs {
+			configured[regionStatusBoardKey{GuildID: guild.ID, Region: region, ChannelID: channelID}] = struct{}{}
+		}
+	}
+
+	// Remove boards that are no longer configured
+	for key, messageID := range boards {
+		if _, ok := configured[key]; !ok {
+			if err := d.dg.ChannelMessageDelete(key.ChannelID, messageID); err != nil {
+				logger.Warn("Failed to delete region status board: %v", err)
+			}
+			d.untrackRegionStatusPin(ctx, logger, key.ChannelID, messageID)
+			delete(boards, key)
+		}
+	}
+
+	if len(configured) == 0 {
+		return nil
+	}
+
+	matches, err := d.listMatches(ctx, 100, nil, nil, "")
+	if err != nil {
+		return fmt.Errorf("failed to list matches: %w", err)
+	}
+
+	for key := range configured {
+		embed, err := d.regionStatusEmbed(ctx, logger, key.Region, matches)
+		if err != nil {
+			logger.Warn("Failed to build region status embed for %s: %v", key.Region, err)
+			continue
+		}
+		if len(embed.Fields) == 0 {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:  "No servers",
+				Value: "There are no game servers online in this region.",
+			})
+		}
+
+		// Reuse the board from a previous run, if one exists.
+		if _, ok := boards[key]; !ok {
+			if messageID := d.findRegionStatusBoard(key.ChannelID, embed.Title); messageID != "" {
+				boards[key] = messageID
+			}
+		}
+
+		if messageID, ok := boards[key]; ok {
+			if _, err := d.dg.ChannelMessageEditEmbed(key.ChannelID, messageID, embed); err == nil {
+				continue
+			}
+			// The message was likely deleted; post a new one.
+			d.untrackRegionStatusPin(ctx, logger, key.ChannelID, messageID)
+			delete(boards, key)
+		}
+
+		msg, err := d.dg.ChannelMessageSendEmbed(key.ChannelID, embed)
+		if err != nil {
+			logger.Warn("Failed to post region status board in %s: %v", key.ChannelID, err)
+			continue
+		}
+		boards[key] = msg.ID
+		d.trackRegionStatusPin(ctx, logger, key.ChannelID, msg.ID, key.Region)
+	}
+
+	return nil
+}
+
+// findRegionStatusBoard returns the ID of the bot's most recent region status board in the channel.
+func (d *DiscordAppBot) findRegionStatusBoard(channelID string, title string) string {
+	messages, err := d.dg.ChannelMessages(channelID, 20, "", "", "")
+	if err != nil {
+		return ""
+	}
+
+	for _, m := range messages {
+		if m.Author == nil || m.Author.ID != d.dg.State.User.ID {
+			continue
+		}
+		for _, e := range m.Embeds {
+			if e.Title == title {
+				return m.ID
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"testing"
+)
+
+func TestRegionStatusPins(t *testing.T) {
+	pins := &RegionStatusPins{}
+	pins.Add(RegionStatusPin{GuildID: "g1", ChannelID: "c1", MessageID: "m1", Region: "uswest"})
+	pins.Add(RegionStatusPin{GuildID: "g1", ChannelID: "c1", MessageID: "m1", Region: "useast"})
+	pins.Add(RegionStatusPin{GuildID: "g2", ChannelID: "c2", MessageID: "m2", Region: "euw"})
+
+	if len(pins.Pins) != 2 {
+		t.Fatalf("expected the duplicate message to be replaced, got %d pins", len(pins.Pins))
+	}
+
+	if got := pins.ByGuild("g1"); len(got) != 1 || got[0].Region != "useast" {
+		t.Errorf("expected one pin for g1 with the latest region, got %v", got)
+	}
+
+	if got := pins.ByGuild(""); len(got) != 2 {
+		t.Errorf("expected every pin for an empty guild ID, got %d", len(got))
+	}
+
+	pins.Remove("c2", "m2")
+	if got := pins.ByGuild("g2"); len(got) != 0 {
+		t.Errorf("expected the pin to be removed, got %v", got)
+	}
+}
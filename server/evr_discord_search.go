@@ -0,0 +1,187 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	SearchResultsLimit          = 100 // Maximum number of display name histories to search
+	SearchResultsPerPage        = 5   // Players shown per page; each uses two embed fields
+	SearchDisplayNamesPerPlayer = 10  // Most recent display names shown per player
+)
+
+type searchResult struct {
+	UserID    string
+	DiscordID string
+	Entries   []DisplayNameHistoryEntry
+}
+
+// searchDisplayNames finds the players that have used a display name containing the partial, sorted by their most recent use.
+func (d *DiscordAppBot) searchDisplayNames(ctx context.Context, partial string, groupID string, allGuilds bool) ([]*searchResult, error) {
+	pattern := fmt.Sprintf(".*%s.*", partial)
+
+	if len(partial) <= 3 {
+		// exact match only
+		pattern = partial
+	}
+
+	histories, err := DisplayNameCacheRegexSearch(ctx, d.nk, pattern, SearchResultsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search display name history: %w", err)
+	}
+
+	results := make([]*searchResult, 0, len(histories))
+	for userID, journal := range histories {
+
+		entries := make([]DisplayNameHistoryEntry, 0)
+		for gid, history := range journal.Histories {
+			if !allGuilds && gid != groupID {
+				continue
+			}
+			for _, e := range history {
+				if strings.Contains(strings.ToLower(e.DisplayName), partial) {
+					entries = append(entries, e)
+				}
+			}
+		}
+
+		if len(entries) == 0 {
+			continue
+		}
+
+		discordID := d.cache.UserIDToDiscordID(userID)
+		if discordID == "" {
+			continue
+		}
+
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].UpdateTime.After(entries[j].UpdateTime)
+		})
+
+		// Remove older duplicates
+		seen := make(map[string]struct{})
+		unique := make([]DisplayNameHistoryEntry, 0, len(entries))
+		for _, e := range entries {
+			if _, ok := seen[e.DisplayName]; ok {
+				continue
+			}
+			seen[e.DisplayName] = struct{}{}
+			unique = append(unique, e)
+		}
+
+		if len(unique) > SearchDisplayNamesPerPlayer {
+			unique = unique[:SearchDisplayNamesPerPlayer]
+		}
+
+		results = append(results, &searchResult{
+			UserID:    userID,
+			DiscordID: discordID,
+			Entries:   unique,
+		})
+	}
+
+	slices.SortStableFunc(results, func(a, b *searchResult) int {
+		if c := b.Entries[0].UpdateTime.Compare(a.Entries[0].UpdateTime); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	return results, nil
+}
+
+// searchResultsPage renders one page of search results, with buttons to move between pages.
+func searchResultsPage(partial string, results []*searchResult, page int, allGuilds bool) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+	pageCount := (len(results) + SearchResultsPerPage - 1) / SearchResultsPerPage
+	page = max(0, min(page, pageCount-1))
+
+	title := "Search Results for `" + partial + "`"
+	if allGuilds {
+		title += " (all guilds)"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:  title,
+		Color:  0x9656ce,
+		Fields: make([]*discordgo.MessageEmbedField, 0, SearchResultsPerPage*2),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Page %d of %d (%d players)", page+1, pageCount, len(results)),
+		},
+	}
+
+	start := page * SearchResultsPerPage
+	end := min(start+SearchResultsPerPage, len(results))
+	for _, r := range results[start:end] {
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Player",
+			Value:  fmt.Sprintf("<@%s>", r.DiscordID),
+			Inline: true,
+		})
+
+		displayNames := make([]string, 0, len(r.Entries))
+		for _, e := range r.Entries {
+			displayNames = append(displayNames, fmt.Sprintf("%s <t:%d:R>", EscapeDiscordMarkdown(e.DisplayName), e.UpdateTime.UTC().Unix()))
+		}
+
+		value := strings.Join(displayNames, "\n")
+		if len(value) > 1024 {
+			value = value[:1021] + "..."
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "In-Game Names",
+			Value:  value,
+			Inline: false,
+		})
+	}
+
+	if pageCount <= 1 {
+		return embed, []discordgo.MessageComponent{}
+	}
+
+	scope := "0"
+	if allGuilds {
+		scope = "1"
+	}
+
+	return embed, []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Previous",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("search_page:%d:%s:%s", page-1, scope, partial),
+					Disabled: page == 0,
+				},
+				discordgo.Button{
+					Label:    "Next",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("search_page:%d:%s:%s", page+1, scope, partial),
+					Disabled: page >= pageCount-1,
+				},
+			},
+		},
+	}
+}
+
+// parseSearchPageValue parses the page, scope, and partial from a search page button.
+func parseSearchPageValue(value string) (page int, allGuilds bool, partial string, err error) {
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) != 3 {
+		return 0, false, "", fmt.Errorf("invalid search page value: %s", value)
+	}
+
+	if page, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, false, "", fmt.Errorf("invalid search page: %w", err)
+	}
+
+	return page, parts[1] == "1", parts[2], nil
+}
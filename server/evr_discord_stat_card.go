@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/gofrs/uuid/v5"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama/v3/server/evr"
+)
+
+type statCardField struct {
+	Label string
+	Key   string
+}
+
+var (
+	// The statistics shown on the stat card, by stat group.
+	statCardFields = map[MatchStatGroup][]statCardField{
+		StatGroupArena: {
+			{"Points", "Points"},
+			{"Goals", "Goals"},
+			{"Assists", "Assists"},
+			{"Saves", "Saves"},
+			{"Stuns", "Stuns"},
+			{"MVPs", "ArenaMVPs"},
+		},
+		StatGroupCombat: {
+			{"Eliminations", "CombatEliminations"},
+			{"Assists", "CombatAssists"},
+			{"Damage", "CombatDamage"},
+			{"Healing", "CombatHealing"},
+			{"Objective Time", "CombatObjectiveTime"},
+			{"Best Streak", "CombatBestEliminationStreak"},
+		},
+	}
+
+	// The win and loss statistics, by stat group.
+	statCardWinLossKeys = map[MatchStatGroup][2]string{
+		StatGroupArena:  {"ArenaWins", "ArenaLosses"},
+		StatGroupCombat: {"CombatWins", "CombatLosses"},
+	}
+)
+
+// statValue returns the numeric value of a profile statistic, or zero if it is not set.
+func statValue(stats map[string]evr.MatchStatistic, key string) float64 {
+	s, ok := stats[key]
+	if !ok {
+		return 0
+	}
+	switch v := s.Value.(type) {
+	case float64:
+		return v
+	case int64:
+		return float64(v)
+	case int:
+		return float64(v)
+	}
+	return 0
+}
+
+// statCardEmbed renders the player's statistics for the stat group.
+func (d *DiscordAppBot) statCardEmbed(ctx context.Context, userID, discordID string, group MatchStatGroup) (*discordgo.MessageEmbed, error) {
+	profile, err := d.profileRegistry.Load(ctx, uuid.FromStringOrNil(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load profile: %w", err)
+	}
+
+	stats := profile.GetServer().Statistics[string(group)]
+
+	keys := statCardWinLossKeys[group]
+	wins, losses := statValue(stats, keys[0]), statValue(stats, keys[1])
+	winRate := "n/a"
+	if wins+losses > 0 {
+		winRate = fmt.Sprintf("%.1f%%", 100*wins/(wins+losses))
+	}
+
+	title := "Arena Stats"
+	if group == StatGroupCombat {
+		title = "Combat Stats"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: fmt.Sprintf("<@%s>", discordID),
+		Color:       0x9656ce,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Wins", Value: fmt.Sprintf("%.0f", wins), Inline: true},
+			{Name: "Losses", Value: fmt.Sprintf("%.0f", losses), Inline: true},
+			{Name: "Win Rate", Value: winRate, Inline: true},
+		},
+	}
+
+	for _, f := range statCardFields[group] {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   f.Label,
+			Value:  fmt.Sprintf("%.0f", statValue(stats, f.Key)),
+			Inline: true,
+		})
+	}
+
+	return embed, nil
+}
+
+// handleStatCard shows the caller's stat card for the stat group. Moderators may view other players.
+func (d *DiscordAppBot) handleStatCard(group MatchStatGroup) DiscordCommandHandlerFn {
+	return func(logger runtime.Logger, s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member, userID string, groupID string) error {
+		if user == nil {
+			return nil
+		}
+		ctx := d.ctx
+
+		target := user
+		for _, o := range i.ApplicationCommandData().Options {
+			if o.Name == "user" {
+				target = o.UserValue(s)
+			}
+		}
+
+		targetUserID := userID
+		if target.ID != user.ID {
+			isModerator := false
+			if guildGroups, err := UserGuildGroupsList(ctx, d.nk, userID); err != nil {
+				return fmt.Errorf("failed to get guild groups: %w", err)
+			} else if g, ok := guildGroups[groupID]; ok {
+				isModerator = g.PermissionsUser(userID).IsModerator
+			}
+
+			if !isModerator {
+				if isGlobalModerator, err := CheckSystemGroupMembership(ctx, d.db, userID, GroupGlobalModerators); err != nil {
+					return fmt.Errorf("failed to check global moderator status: %w", err)
+				} else if !isGlobalModerator {
+					return simpleInteractionResponse(s, i, "You must be a moderator to view another player's stats.")
+				}
+			}
+
+			if targetUserID = d.cache.DiscordIDToUserID(target.ID); targetUserID == "" {
+				return simpleInteractionResponse(s, i, "That player does not have a linked account.")
+			}
+		}
+
+		embed, err := d.statCardEmbed(ctx, targetUserID, target.ID, group)
+		if err != nil {
+			return err
+		}
+
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags:  discordgo.MessageFlagsEphemeral,
+				Embeds: []*discordgo.MessageEmbed{embed},
+			},
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+const (
+	SuspensionReconcileInterval = 5 * time.Minute
+)
+
+// runSuspensionReconciler periodically lifts suspensions whose expiry has passed.
+func (d *DiscordAppBot) runSuspensionReconciler(ctx context.Context, logger runtime.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.reconcileSuspensions(ctx, logger); err != nil {
+				logger.Warn("Failed to reconcile suspensions: %v", err)
+			}
+		}
+	}
+}
+
+// reconcileSuspensions removes the suspension role and the stored status for every expired suspension.
+func (d *DiscordAppBot) reconcileSuspensions(ctx context.Context, logger runtime.Logger) error {
+	if !d.discordEnabled() {
+		return ErrDiscordDisabled
+	}
+
+	expired := make([]*runtime.StorageDelete, 0)
+	statuses := make([]*SuspensionStatus, 0)
+
+	cursor := ""
+	for {
+		objs, c, err := d.nk.StorageList(ctx, SystemUserID, "", SuspensionStatusCollection, 100, cursor)
+		if err != nil {
+			return fmt.Errorf("failed to list suspensions: %w", err)
+		}
+
+		for _, obj := range objs {
+			status := &SuspensionStatus{}
+			if err := json.Unmarshal([]byte(obj.GetValue()), status); err != nil {
+				logger.Warn("Failed to unmarshal suspension status %s/%s: %v", obj.GetUserId(), obj.GetKey(), err)
+				continue
+			}
+
+			if status.Expiry.IsZero() || status.Expiry.After(time.Now()) {
+				continue
+			}
+
+			expired = append(expired, &runtime.StorageDelete{
+				Collection: SuspensionStatusCollection,
+				Key:        obj.GetKey(),
+				UserID:     obj.GetUserId(),
+			})
+			statuses = append(statuses, status)
+		}
+
+		if c == "" {
+			break
+		}
+		cursor = c
+	}
+
+	for i, status := range statuses {
+		if status.GuildId != "" && status.UserDiscordId != "" && status.RoleId != "" {
+			if err := d.dg.GuildMemberRoleRemove(status.GuildId, status.UserDiscordId, status.RoleId); err != nil {
+				// The member may have left the guild, in which case there is no role to remove.
+				var restError *discordgo.RESTError
+				if !errors.As(err, &restError) || restError.Message == nil || restError.Message.Code != discordgo.ErrCodeUnknownMember {
+					logger.Warn("Failed to remove suspension role %s from %s: %v", status.RoleId, status.UserDiscordId, err)
+					continue
+				}
+			}
+		}
+
+		if err := d.nk.StorageDelete(ctx, []*runtime.StorageDelete{expired[i]}); err != nil {
+			logger.Warn("Failed to delete suspension status for %s: %v", status.UserId, err)
+			continue
+		}
+
+		if groupID := d.cache.GuildIDToGroupID(status.GuildId); groupID != "" {
+			if _, err := d.LogAuditMessage(ctx, groupID, fmt.Sprintf("Suspension of <@%s> (`%s`) expired <t:%d:R>; removed role <@&%s>.", status.UserDiscordId, status.Reason, status.Expiry.Unix(), status.RoleId), false); err != nil {
+				logger.Warn("Failed to log audit message: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// activeSuspensions returns the user's unexpired suspensions in the guild.
+func (d *DiscordAppBot) activeSuspensions(ctx context.Context, userID, guildID string) ([]*SuspensionStatus, error) {
+	all, err := GetAllSuspensions(ctx, d.nk, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	suspensions := make([]*SuspensionStatus, 0, len(all))
+	for _, status := range all {
+		if status.GuildId == guildID {
+			suspensions = append(suspensions, status)
+		}
+	}
+	return suspensions, nil
+}
+
+// GetAllSuspensions returns the user's unexpired suspensions across all guilds.
+func GetAllSuspensions(ctx context.Context, nk runtime.NakamaModule, userID string) ([]*SuspensionStatus, error) {
+	suspensions := make([]*SuspensionStatus, 0)
+
+	cursor := ""
+	for {
+		objs, c, err := nk.StorageList(ctx, SystemUserID, userID, SuspensionStatusCollection, 100, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list suspensions: %w", err)
+		}
+
+		for _, obj := range objs {
+			status := &SuspensionStatus{}
+			if err := json.Unmarshal([]byte(obj.GetValue()), status); err != nil {
+				continue
+			}
+			if status.Expiry.Before(time.Now()) {
+				continue
+			}
+			suspensions = append(suspensions, status)
+		}
+
+		if c == "" {
+			break
+		}
+		cursor = c
+	}
+
+	return suspensions, nil
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+type traceSession struct {
+	SessionID string          `json:"session_id"`
+	Stream    string          `json:"stream"`
+	Status    json.RawMessage `json:"status,omitempty"`
+}
+
+// userTrace is a snapshot of a user's sessions, matchmaking, party, and match for support debugging.
+type userTrace struct {
+	UserID              string               `json:"user_id"`
+	DiscordID           string               `json:"discord_id"`
+	ActiveGroupID       string               `json:"active_group_id"`
+	LoginSessions       []*traceSession      `json:"login_sessions"`
+	MatchSessions       []*traceSession      `json:"match_sessions"`
+	Matchmaking         []*traceSession      `json:"matchmaking"`
+	MatchmakingSettings *MatchmakingSettings `json:"matchmaking_settings,omitempty"`
+	PartyUserIDs        []string             `json:"party_user_ids,omitempty"`
+	Match               *MatchLabel          `json:"match,omitempty"`
+	Errors              []string             `json:"errors,omitempty"`
+}
+
+// traceUser gathers the user's session and matchmaking context. Failures are recorded in the trace rather than returned.
+func (d *DiscordAppBot) traceUser(ctx context.Context, userID string) *userTrace {
+	trace := &userTrace{
+		UserID:        userID,
+		DiscordID:     d.cache.UserIDToDiscordID(userID),
+		LoginSessions: make([]*traceSession, 0),
+		MatchSessions: make([]*traceSession, 0),
+		Matchmaking:   make([]*traceSession, 0),
+		Errors:        make([]string, 0),
+	}
+
+	toTraceSessions := func(stream string, presences []runtime.Presence) []*traceSession {
+		sessions := make([]*traceSession, 0, len(presences))
+		for _, p := range presences {
+			s := &traceSession{
+				SessionID: p.GetSessionId(),
+				Stream:    stream,
+			}
+			if json.Valid([]byte(p.GetStatus())) {
+				s.Status = json.RawMessage(p.GetStatus())
+			} else if p.GetStatus() != "" {
+				s.Status, _ = json.Marshal(p.GetStatus())
+			}
+			sessions = append(sessions, s)
+		}
+		return sessions
+	}
+
+	if md, err := GetAccountMetadata(ctx, d.nk, userID); err != nil {
+		trace.Errors = append(trace.Errors, fmt.Sprintf("account metadata: %v", err))
+	} else {
+		trace.ActiveGroupID = md.GetActiveGroupID().String()
+	}
+
+	if presences, err := d.nk.StreamUserList(StreamModeService, userID, StreamContextLogin.String(), "", true, true); err != nil {
+		trace.Errors = append(trace.Errors, fmt.Sprintf("login sessions: %v", err))
+	} else {
+		trace.LoginSessions = toTraceSessions("login", presences)
+	}
+
+	if presences, err := d.nk.StreamUserList(StreamModeService, userID, "", StreamLabelMatchService, true, true); err != nil {
+		trace.Errors = append(trace.Errors, fmt.Sprintf("match sessions: %v", err))
+	} else {
+		trace.MatchSessions = toTraceSessions("match", presences)
+
+		for _, p := range presences {
+			if label, err := MatchLabelByID(ctx, d.nk, MatchIDFromStringOrNil(p.GetStatus())); err == nil && label != nil {
+				trace.Match = label
+				break
+			}
+		}
+	}
+
+	// The matchmaking streams are per group, so check each one for the user.
+	mode := uint8(StreamModeMatchmaking)
+	for stream := range d.pipeline.tracker.CountByStreamModeFilter(map[uint8]*uint8{StreamModeMatchmaking: &mode}) {
+		for _, p := range d.pipeline.tracker.ListByStream(*stream, true, true) {
+			if p.UserID.String() != userID {
+				continue
+			}
+			s := &traceSession{
+				SessionID: p.ID.SessionID.String(),
+				Stream:    stream.Subject.String(),
+			}
+			if json.Valid([]byte(p.Meta.Status)) {
+				s.Status = json.RawMessage(p.Meta.Status)
+			}
+			trace.Matchmaking = append(trace.Matchmaking, s)
+		}
+	}
+
+	if settings, err := LoadMatchmakingSettings(ctx, d.nk, userID); err != nil {
+		trace.Errors = append(trace.Errors, fmt.Sprintf("matchmaking settings: %v", err))
+	} else {
+		trace.MatchmakingSettings = &settings
+		if settings.LobbyGroupName != "" {
+			if userIDs, err := GetPartyGroupUserIDs(ctx, d.nk, settings.LobbyGroupName); err != nil {
+				trace.Errors = append(trace.Errors, fmt.Sprintf("party: %v", err))
+			} else {
+				trace.PartyUserIDs = userIDs
+			}
+		}
+	}
+
+	return trace
+}
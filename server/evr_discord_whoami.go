@@ -30,7 +30,11 @@ type WhoAmI struct {
 	MatchLabels          []*MatchLabel        `json:"match_labels"`
 	DefaultLobbyGroup    string               `json:"active_lobby_group,omitempty"`
 	GhostedPlayers       []string             `json:"ghosted_discord_ids,omitempty"`
+	PartyGroupName       string               `json:"party_group_name,omitempty"`
+	PartyMemberIDs       []string             `json:"party_member_ids,omitempty"`
 	LastMatchmakingError error                `json:"last_matchmaking_error,omitempty"`
+	PossibleEvasions     map[string][]string  `json:"possible_evasions,omitempty"` // map[disabledUserID]matchedItems
+	Suspensions          []*SuspensionStatus  `json:"suspensions,omitempty"`
 }
 
 type EvrIdLogins struct {
@@ -39,7 +43,39 @@ type EvrIdLogins struct {
 	DisplayName   string `json:"display_name,omitempty"`
 }
 
-func (d *DiscordAppBot) handleProfileRequest(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, s *discordgo.Session, i *discordgo.InteractionCreate, targetID string, username string, includePriviledged bool, includePrivate bool) error {
+const (
+	DiscordEmbedMaxFields     = 25
+	DiscordEmbedFieldNameMax  = 256
+	DiscordEmbedFieldValueMax = 1024
+	DiscordEmbedTotalMax      = 6000
+)
+
+// embedFieldsExceedLimits returns true if the fields cannot be sent in a single embed.
+func embedFieldsExceedLimits(title string, fields []*discordgo.MessageEmbedField) bool {
+	if len(fields) > DiscordEmbedMaxFields {
+		return true
+	}
+	total := len(title)
+	for _, f := range fields {
+		if len(f.Name) > DiscordEmbedFieldNameMax || len(f.Value) > DiscordEmbedFieldValueMax {
+			return true
+		}
+		total += len(f.Name) + len(f.Value)
+	}
+	return total > DiscordEmbedTotalMax
+}
+
+// embedFieldsMarkdown renders the fields as a Markdown document, for when they are too large for an embed.
+func embedFieldsMarkdown(title string, fields []*discordgo.MessageEmbedField) string {
+	var b strings.Builder
+	b.WriteString("# " + title + "\n")
+	for _, f := range fields {
+		b.WriteString(fmt.Sprintf("\n## %s\n%s\n", f.Name, f.Value))
+	}
+	return b.String()
+}
+
+func (d *DiscordAppBot) handleProfileRequest(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, s *discordgo.Session, i *discordgo.InteractionCreate, targetID string, username string, includePriviledged bool, includePrivate bool, exportFile bool) error {
 	whoami := &WhoAmI{
 		DiscordID:          targetID,
 		RecentLogins:       make(map[string]time.Time),
@@ -144,6 +180,24 @@ func (d *DiscordAppBot) handleProfileRequest(ctx context.Context, logger runtime
 		}
 	}
 
+	if includePriviledged {
+		// Flag devices and IPs shared with disabled (banned) accounts
+		if whoami.PossibleEvasions, err = d.findBannedAlternates(ctx, nk, loginHistory); err != nil {
+			logger.Warn("Failed to search for banned alternates: %v", err)
+		}
+	}
+
+	suspensions, err := GetAllSuspensions(ctx, nk, userID.String())
+	if err != nil {
+		logger.Warn("Failed to get suspensions: %v", err)
+	}
+	for _, status := range suspensions {
+		// Only show the suspensions of other guilds in the private view.
+		if includePrivate || status.GuildId == i.GuildID {
+			whoami.Suspensions = append(whoami.Suspensions, status)
+		}
+	}
+
 	displayNameHistory, err := DisplayNameHistoryLoad(ctx, nk, userID.String())
 	if err != nil {
 		return fmt.Errorf("failed to load display name history: %w", err)
@@ -202,6 +256,18 @@ func (d *DiscordAppBot) handleProfileRequest(ctx context.Context, logger runtime
 			}
 		}
 
+		// Get the player's party group members
+		if settings, err := LoadMatchmakingSettings(ctx, nk, userID.String()); err != nil {
+			logger.Warn("failed to load matchmaking settings", "error", err)
+		} else if settings.LobbyGroupName != "" {
+			whoami.PartyGroupName = settings.LobbyGroupName
+			if userIDs, err := GetPartyGroupUserIDs(ctx, nk, settings.LobbyGroupName); err == nil {
+				whoami.PartyMemberIDs = slices.DeleteFunc(userIDs, func(id string) bool {
+					return id == userID.String()
+				})
+			}
+		}
+
 		// If the player is online, Get the most recent matchmaking error for the player.
 		if len(presences) > 0 {
 			// Get the most recent matchmaking error for the player
@@ -313,13 +379,30 @@ func (d *DiscordAppBot) handleProfileRequest(ctx context.Context, logger runtime
 			return output
 		}(), "\n"), Inline: false},
 		{Name: "Match List", Value: strings.Join(lo.Map(whoami.MatchLabels, func(l *MatchLabel, index int) string {
-			link := fmt.Sprintf("`%s`: https://echo.taxi/spark://c/%s", l.Mode.String(), strings.ToUpper(l.ID.UUID.String()))
+			link := fmt.Sprintf("`%s`: %s", l.Mode.String(), d.sparkLink(l.ID))
 			players := make([]string, 0, len(l.Players))
+			team := ""
 			for _, p := range l.Players {
 				players = append(players, fmt.Sprintf("<@%s>", p.DiscordID))
+				if p.UserID == userID.String() {
+					team = fmt.Sprintf(" (team: %s)", p.Team.String())
+				}
 			}
-			return fmt.Sprintf("%s - %s\n%s", l.Mode.String(), link, strings.Join(players, ", "))
+			return fmt.Sprintf("%s - %s%s\n%s", l.Mode.String(), link, team, strings.Join(players, ", "))
 		}), "\n"), Inline: false},
+		{Name: "Party", Value: func() string {
+			if whoami.PartyGroupName == "" {
+				return ""
+			}
+			members := make([]string, 0, len(whoami.PartyMemberIDs))
+			for _, id := range whoami.PartyMemberIDs {
+				members = append(members, fmt.Sprintf("<@%s>", d.cache.UserIDToDiscordID(id)))
+			}
+			if len(members) == 0 {
+				return fmt.Sprintf("`%s` (no other members)", whoami.PartyGroupName)
+			}
+			return fmt.Sprintf("`%s`: %s", whoami.PartyGroupName, strings.Join(members, ", "))
+		}(), Inline: false},
 	}
 
 	fields = append(fields, &discordgo.MessageEmbedField{
@@ -337,6 +420,55 @@ func (d *DiscordAppBot) handleProfileRequest(ctx context.Context, logger runtime
 		Inline: false,
 	})
 
+	if includePriviledged && len(loginHistory.LocationAnomalies) > 0 {
+		lines := make([]string, 0, len(loginHistory.LocationAnomalies))
+		for _, a := range loginHistory.LocationAnomalies {
+			line := fmt.Sprintf("<t:%d:R> - %s -> %s (%.0fkm at %.0fkm/h)", a.DetectedAt.Unix(), a.PreviousLocation, a.Location, a.DistanceKm, a.SpeedKmh)
+			if includePrivate {
+				line += fmt.Sprintf(" `%s` -> `%s`", a.PreviousClientIP, a.ClientIP)
+			}
+			lines = append(lines, line)
+		}
+		slices.Reverse(lines)
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "Location Anomalies",
+			Value:  strings.Join(lines, "\n"),
+			Inline: false,
+		})
+	}
+
+	if len(whoami.PossibleEvasions) > 0 {
+		lines := make([]string, 0, len(whoami.PossibleEvasions))
+		for otherUserID, items := range whoami.PossibleEvasions {
+			lines = append(lines, fmt.Sprintf("<@%s> (banned): %s", d.cache.UserIDToDiscordID(otherUserID), strings.Join(items, ", ")))
+		}
+		sort.Strings(lines)
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "Possible Ban Evasion",
+			Value:  strings.Join(lines, "\n"),
+			Inline: false,
+		})
+	}
+
+	if len(whoami.Suspensions) > 0 {
+		slices.SortFunc(whoami.Suspensions, func(a, b *SuspensionStatus) int {
+			return a.Expiry.Compare(b.Expiry)
+		})
+		lines := make([]string, 0, len(whoami.Suspensions))
+		for _, status := range whoami.Suspensions {
+			line := fmt.Sprintf("**%s** until <t:%d:f> (<t:%d:R>)", EscapeDiscordMarkdown(status.GuildName), status.Expiry.Unix(), status.Expiry.Unix())
+			if status.Reason != "" {
+				line += ": " + EscapeDiscordMarkdown(status.Reason)
+			}
+			lines = append(lines, line)
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "Suspensions",
+			Value:  strings.Join(lines, "\n"),
+			Inline: false,
+		})
+	}
+
 	if whoami.LastMatchmakingError != nil {
 		fields = append(fields, &discordgo.MessageEmbedField{
 			Name:   "Last Matchmaking Error",
@@ -349,6 +481,26 @@ func (d *DiscordAppBot) handleProfileRequest(ctx context.Context, logger runtime
 		return f.Value != ""
 	})
 
+	title := "EchoVRCE Account"
+
+	// Large accounts do not fit in an embed; send the details as a file instead.
+	if exportFile || embedFieldsExceedLimits(title, fields) {
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags:   discordgo.MessageFlagsEphemeral,
+				Content: fmt.Sprintf("Account details for <@%s> are attached.", targetID),
+				Files: []*discordgo.File{
+					{
+						Name:        fmt.Sprintf("whoami-%s.md", whoami.NakamaID.String()),
+						ContentType: "text/markdown",
+						Reader:      strings.NewReader(embedFieldsMarkdown(title, fields)),
+					},
+				},
+			},
+		})
+	}
+
 	// Send the response
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -356,7 +508,7 @@ func (d *DiscordAppBot) handleProfileRequest(ctx context.Context, logger runtime
 			Flags: discordgo.MessageFlagsEphemeral,
 			Embeds: []*discordgo.MessageEmbed{
 				{
-					Title:  "EchoVRCE Account",
+					Title:  title,
 					Color:  0xCCCCCC,
 					Fields: fields,
 				},
@@ -364,3 +516,35 @@ func (d *DiscordAppBot) handleProfileRequest(ctx context.Context, logger runtime
 		},
 	})
 }
+
+// findBannedAlternates returns the disabled accounts that share login items with the login history.
+func (d *DiscordAppBot) findBannedAlternates(ctx context.Context, nk runtime.NakamaModule, loginHistory *LoginHistory) (map[string][]string, error) {
+	matches, err := LoginAlternateSearch(ctx, nk, loginHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	itemsByUserID := make(map[string][]string)
+	for _, m := range matches {
+		itemsByUserID[m.OtherUserID] = append(itemsByUserID[m.OtherUserID], m.MatchedItems()...)
+	}
+	if len(itemsByUserID) == 0 {
+		return nil, nil
+	}
+
+	accounts, err := nk.AccountsGetId(ctx, lo.Keys(itemsByUserID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alternate accounts: %w", err)
+	}
+
+	evasions := make(map[string][]string)
+	for _, a := range accounts {
+		if a.GetDisableTime() == nil {
+			continue
+		}
+		items := itemsByUserID[a.User.Id]
+		slices.Sort(items)
+		evasions[a.User.Id] = slices.Compact(items)
+	}
+	return evasions, nil
+}
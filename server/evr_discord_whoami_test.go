@@ -0,0 +1,35 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestEmbedFieldsExceedLimits(t *testing.T) {
+	field := func(value string) *discordgo.MessageEmbedField {
+		return &discordgo.MessageEmbedField{Name: "Recent Logins", Value: value}
+	}
+
+	tests := []struct {
+		name   string
+		fields []*discordgo.MessageEmbedField
+		want   bool
+	}{
+		{"small", []*discordgo.MessageEmbedField{field("a"), field("b")}, false},
+		{"long field", []*discordgo.MessageEmbedField{field(strings.Repeat("a", DiscordEmbedFieldValueMax+1))}, true},
+		{"long total", []*discordgo.MessageEmbedField{
+			field(strings.Repeat("a", 1000)), field(strings.Repeat("a", 1000)), field(strings.Repeat("a", 1000)),
+			field(strings.Repeat("a", 1000)), field(strings.Repeat("a", 1000)), field(strings.Repeat("a", 1000)),
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := embedFieldsExceedLimits("EchoVRCE Account", tt.fields); got != tt.want {
+				t.Errorf("embedFieldsExceedLimits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"log"
+	"time"
 )
 
 type TeamMetadata struct {
@@ -14,6 +15,14 @@ type GameState struct {
 	RoundClock             *RoundClock                `json:"round_clock,omitempty"`             // The round clock
 	EquilibriumCoefficient float64                    `json:"equilibrium_coefficient,omitempty"` // The equilibrium coefficient for the game (how much the game is balanced)
 	Teams                  map[TeamIndex]TeamMetadata `json:"teams,omitempty"`                   // Metadata for each team
+	RoundsPlayed           int                        `json:"rounds_played,omitempty"`           // The number of rounds completed
+	RoundResults           []RoundResult              `json:"round_results,omitempty"`           // The scores at the end of each round
+}
+
+type RoundResult struct {
+	BlueScore   int       `json:"blue_score"`
+	OrangeScore int       `json:"orange_score"`
+	EndedAt     time.Time `json:"ended_at"`
 }
 
 func NewGameState() *GameState {
@@ -43,6 +52,20 @@ func (g *GameState) Update(goals []*MatchGoal) {
 
 }
 
+// RecordRoundEnd records the round results when the game clock resets for a new round. It reports whether a round ended.
+func (g *GameState) RecordRoundEnd(gameClock time.Duration, now time.Time) bool {
+	if g.RoundClock == nil || gameClock == 0 || gameClock >= g.RoundClock.Elapsed {
+		return false
+	}
+	g.RoundsPlayed++
+	g.RoundResults = append(g.RoundResults, RoundResult{
+		BlueScore:   g.BlueScore,
+		OrangeScore: g.OrangeScore,
+		EndedAt:     now,
+	})
+	return true
+}
+
 func GoalTypeToPoints(goalType string) int {
 	switch goalType {
 	case "SLAM DUNK":
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"slices"
+
+	"github.com/heroiclabs/nakama/v3/server/evr"
+)
+
+// PlayerLiveStats are a player's statistics for the match so far, derived from the goals scored.
+type PlayerLiveStats struct {
+	XPID        string `json:"xp_id"`
+	DisplayName string `json:"display_name"`
+	TeamID      int    `json:"team_id"`
+	Goals       int    `json:"goals"`
+	Points      int    `json:"points"`
+	Assists     int    `json:"assists"`
+	SelfGoals   int    `json:"self_goals"`
+	Present     bool   `json:"present"` // Whether the player is still in the match
+}
+
+// MatchLiveStats are the statistics of an in-progress match.
+type MatchLiveStats struct {
+	BlueScore       int                `json:"blue_score"`
+	OrangeScore     int                `json:"orange_score"`
+	RoundsPlayed    int                `json:"rounds_played"`
+	RoundClockSecs  float64            `json:"round_clock_secs"`
+	RoundRemainSecs float64            `json:"round_remaining_secs"`
+	Players         []*PlayerLiveStats `json:"players"`
+	Goals           []*MatchGoal       `json:"goals"`
+}
+
+// NewMatchLiveStats builds the match statistics from the game state, the goals scored, and the players in the match.
+func NewMatchLiveStats(gs *GameState, goals []*MatchGoal, presences []*EvrMatchPresence) *MatchLiveStats {
+	stats := &MatchLiveStats{
+		Players: make([]*PlayerLiveStats, 0, len(presences)),
+		Goals:   goals,
+	}
+	if gs != nil {
+		stats.BlueScore = gs.BlueScore
+		stats.OrangeScore = gs.OrangeScore
+		stats.RoundsPlayed = gs.RoundsPlayed
+		if gs.RoundClock != nil {
+			stats.RoundClockSecs = gs.RoundClock.Current().Seconds()
+			stats.RoundRemainSecs = gs.RoundClock.Remaining().Seconds()
+		}
+	}
+
+	byXPID := make(map[string]*PlayerLiveStats, len(presences))
+	player := func(xpid, displayName string, teamID int) *PlayerLiveStats {
+		p, ok := byXPID[xpid]
+		if !ok {
+			p = &PlayerLiveStats{
+				XPID:        xpid,
+				DisplayName: displayName,
+				TeamID:      teamID,
+			}
+			byXPID[xpid] = p
+			stats.Players = append(stats.Players, p)
+		}
+		return p
+	}
+
+	for _, p := range presences {
+		if p.RoleAlignment != evr.TeamBlue && p.RoleAlignment != evr.TeamOrange {
+			continue
+		}
+		player(p.XPID.String(), p.DisplayName, p.RoleAlignment).Present = true
+	}
+
+	for _, g := range goals {
+		if g.XPID == "" {
+			continue
+		}
+		scorer := player(g.XPID, g.Displayname, int(g.Teamid))
+		if g.GoalType == "SELF GOAL" {
+			scorer.SelfGoals++
+			continue
+		}
+		scorer.Goals++
+		scorer.Points += GoalTypeToPoints(g.GoalType)
+
+		// The previous player to touch the disc assists, if they are a teammate.
+		if g.PrevPlayerXPID != "" && g.PrevPlayerXPID != g.XPID && g.PrevPlayerTeamID == g.Teamid {
+			player(g.PrevPlayerXPID, g.PrevPlayerDisplayName, int(g.PrevPlayerTeamID)).Assists++
+		}
+	}
+
+	slices.SortStableFunc(stats.Players, func(a, b *PlayerLiveStats) int {
+		if a.TeamID != b.TeamID {
+			return a.TeamID - b.TeamID
+		}
+		return b.Points - a.Points
+	})
+
+	return stats
+}
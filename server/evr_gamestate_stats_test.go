@@ -0,0 +1,48 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/heroiclabs/nakama/v3/server/evr"
+)
+
+func TestNewMatchLiveStats(t *testing.T) {
+	blue := &EvrMatchPresence{XPID: evr.NewXPID(evr.OVR, 1), DisplayName: "Blue1", RoleAlignment: evr.TeamBlue}
+	orange := &EvrMatchPresence{XPID: evr.NewXPID(evr.OVR, 2), DisplayName: "Orange1", RoleAlignment: evr.TeamOrange}
+	spectator := &EvrMatchPresence{XPID: evr.NewXPID(evr.OVR, 3), DisplayName: "Spectator", RoleAlignment: evr.TeamSpectator}
+
+	goals := []*MatchGoal{
+		{GoalType: "LONG SHOT", XPID: blue.XPID.String(), Displayname: "Blue1", Teamid: 0, PrevPlayerXPID: "OVR-ORG-4", PrevPlayerDisplayName: "Blue2", PrevPlayerTeamID: 0},
+		{GoalType: "SLAM DUNK", XPID: orange.XPID.String(), Displayname: "Orange1", Teamid: 1, PrevPlayerXPID: blue.XPID.String(), PrevPlayerTeamID: 0},
+		{GoalType: "SELF GOAL", XPID: blue.XPID.String(), Displayname: "Blue1", Teamid: 0},
+	}
+	gs := &GameState{BlueScore: 5, OrangeScore: 2}
+
+	stats := NewMatchLiveStats(gs, goals, []*EvrMatchPresence{orange, spectator, blue})
+
+	if stats.BlueScore != 5 || stats.OrangeScore != 2 {
+		t.Errorf("scores = %d-%d, want 5-2", stats.BlueScore, stats.OrangeScore)
+	}
+
+	byXPID := make(map[string]*PlayerLiveStats)
+	for _, p := range stats.Players {
+		byXPID[p.XPID] = p
+	}
+	if len(byXPID) != 3 {
+		t.Fatalf("len(Players) = %d, want 3 (spectators excluded)", len(byXPID))
+	}
+
+	if p := byXPID[blue.XPID.String()]; p.Goals != 1 || p.Points != 3 || p.SelfGoals != 1 || p.Assists != 0 || !p.Present {
+		t.Errorf("blue stats = %+v", p)
+	}
+	if p := byXPID[orange.XPID.String()]; p.Goals != 1 || p.Points != 2 || p.Assists != 0 || !p.Present {
+		t.Errorf("orange stats = %+v", p)
+	}
+	if p := byXPID["OVR-ORG-4"]; p == nil || p.Assists != 1 || p.Present {
+		t.Errorf("departed assister stats = %+v", p)
+	}
+
+	if stats.Players[0].TeamID != evr.TeamBlue || stats.Players[len(stats.Players)-1].TeamID != evr.TeamOrange {
+		t.Errorf("players are not sorted by team")
+	}
+}
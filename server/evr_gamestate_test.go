@@ -71,3 +71,37 @@ func TestGameState_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestGameState_RoundLimit(t *testing.T) {
+	now := time.Now()
+	label := &MatchLabel{
+		MaxRounds: 2,
+		GameState: &GameState{RoundClock: NewRoundClock(5*time.Minute, now)},
+	}
+	gs := label.GameState
+
+	// The game clock advances within a round and resets when the next round starts.
+	clocks := []time.Duration{30 * time.Second, 4 * time.Minute, 10 * time.Second, 3 * time.Minute, 5 * time.Second}
+	wantRounds := []int{0, 0, 1, 1, 2}
+	for i, clock := range clocks {
+		gs.RecordRoundEnd(clock, now)
+		gs.RoundClock.Update(clock)
+		gs.BlueScore = i
+
+		if gs.RoundsPlayed != wantRounds[i] {
+			t.Fatalf("clock %v: RoundsPlayed = %d, want %d", clock, gs.RoundsPlayed, wantRounds[i])
+		}
+		if got, want := label.RoundLimitReached(), wantRounds[i] >= label.MaxRounds; got != want {
+			t.Errorf("clock %v: RoundLimitReached() = %v, want %v", clock, got, want)
+		}
+	}
+
+	if len(gs.RoundResults) != 2 || gs.RoundResults[0].BlueScore != 1 || gs.RoundResults[1].BlueScore != 3 {
+		t.Errorf("RoundResults = %+v, want the scores before each reset", gs.RoundResults)
+	}
+
+	label.MaxRounds = 0
+	if label.RoundLimitReached() {
+		t.Errorf("RoundLimitReached() = true without a round limit")
+	}
+}
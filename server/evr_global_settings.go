@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/heroiclabs/nakama-common/runtime"
 	"go.uber.org/atomic"
@@ -12,18 +13,34 @@ import (
 const (
 	GlobalSettingsStorageCollection = "Global"
 	GlobalSettingsKey               = "settings"
+
+	// DefaultNewLocationMessage is shown to a user logging in from a new location; {bot} is replaced with the bot's username.
+	DefaultNewLocationMessage = "New location detected.\nPlease check your Discord DMs to accept the \nverification request from @{bot}."
 )
 
 var globalSettings = atomic.NewPointer(&GlobalSettingsData{})
 
 type GlobalSettingsData struct {
-	ServiceGuildID string `json:"service_guild_id"` // Central/Support guild ID
+	ServiceGuildID      string            `json:"service_guild_id"`                // Central/Support guild ID
+	NewLocationMessages map[string]string `json:"new_location_messages,omitempty"` // map[langTag]message; overrides DefaultNewLocationMessage
 }
 
 func GlobalSettings() *GlobalSettingsData {
 	return globalSettings.Load()
 }
 
+// NewLocationMessage returns the new location message in the user's language (i.e. "pt-BR", then "pt"), falling back to English.
+func (g *GlobalSettingsData) NewLocationMessage(langTag, botUsername string) string {
+	message := DefaultNewLocationMessage
+	for _, tag := range []string{langTag, strings.SplitN(langTag, "-", 2)[0], "en"} {
+		if m, ok := g.NewLocationMessages[tag]; ok && m != "" {
+			message = m
+			break
+		}
+	}
+	return strings.ReplaceAll(message, "{bot}", botUsername)
+}
+
 func (g *GlobalSettingsData) String() string {
 	data, _ := json.Marshal(g)
 	return string(data)
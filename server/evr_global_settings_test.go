@@ -0,0 +1,32 @@
+package server
+
+import "testing"
+
+func TestGlobalSettingsData_NewLocationMessage(t *testing.T) {
+	settings := &GlobalSettingsData{
+		NewLocationMessages: map[string]string{
+			"pt":    "Nova localização detectada. Verifique suas DMs de @{bot}.",
+			"es-ES": "Nueva ubicación detectada. Revisa tus MD de @{bot}.",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		settings *GlobalSettingsData
+		langTag  string
+		want     string
+	}{
+		{"exact tag", settings, "es-ES", "Nueva ubicación detectada. Revisa tus MD de @EchoBot."},
+		{"base language", settings, "pt-BR", "Nova localização detectada. Verifique suas DMs de @EchoBot."},
+		{"unknown language", settings, "de", "New location detected.\nPlease check your Discord DMs to accept the \nverification request from @EchoBot."},
+		{"no lang tag", &GlobalSettingsData{}, "", "New location detected.\nPlease check your Discord DMs to accept the \nverification request from @EchoBot."},
+		{"english override", &GlobalSettingsData{NewLocationMessages: map[string]string{"en": "Check @{bot}!"}}, "en-US", "Check @EchoBot!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.NewLocationMessage(tt.langTag, "EchoBot"); got != tt.want {
+				t.Errorf("NewLocationMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
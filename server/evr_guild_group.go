@@ -10,6 +10,7 @@ import (
 	"github.com/gofrs/uuid/v5"
 	"github.com/heroiclabs/nakama-common/api"
 	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama/v3/server/evr"
 	"github.com/samber/lo"
 )
 
@@ -66,21 +67,31 @@ func (g GuildGroupMemberships) IsMember(groupID string) bool {
 }
 
 type GroupMetadata struct {
-	GuildID                string              `json:"guild_id"`                 // The guild ID
-	RulesText              string              `json:"rules_text"`               // The rules text displayed on the main menu
-	MinimumAccountAgeDays  int                 `json:"minimum_account_age_days"` // The minimum account age in days to be able to play echo on this guild's sessions
-	MembersOnlyMatchmaking bool                `json:"members_only_matchmaking"` // Restrict matchmaking to members only (when this group is the active one)
-	DisableCreateCommand   bool                `json:"disable_create_command"`   // Disable the public allocate command
-	Roles                  *GuildGroupRoles    `json:"roles"`                    // The roles text displayed on the main menu
-	RoleCache              map[string][]string `json:"role_cache"`               // The role cache
-	MatchmakingChannelIDs  map[string]string   `json:"matchmaking_channel_ids"`  // The matchmaking channel IDs
-	DebugChannelID         string              `json:"debug_channel_id"`         // The debug channel
-	AuditChannelID         string              `json:"audit_channel_id"`         // The audit channel
-	ErrorChannelID         string              `json:"error_channel_id"`         // The error channel
-	BlockVPNUsers          bool                `json:"block_vpn_users"`          // Block VPN users
-	FraudScoreThreshold    int                 `json:"fraud_score_threshold"`    // The fraud score threshold
-	AllowedFeatures        []string            `json:"allowed_features"`         // Allowed features
-	LogAlternateAccounts   bool                `json:"log_alternate_accounts"`   // Log alternate accounts
+	GuildID                string              `json:"guild_id"`                  // The guild ID
+	RulesText              string              `json:"rules_text"`                // The rules text displayed on the main menu
+	MinimumAccountAgeDays  int                 `json:"minimum_account_age_days"`  // The minimum account age in days to be able to play echo on this guild's sessions
+	MembersOnlyMatchmaking bool                `json:"members_only_matchmaking"`  // Restrict matchmaking to members only (when this group is the active one)
+	MembersOnlySocial      *bool               `json:"members_only_social"`       // Restrict social lobbies to members only (nil follows MembersOnlyMatchmaking)
+	DisableCreateCommand   bool                `json:"disable_create_command"`    // Disable the public allocate command
+	Roles                  *GuildGroupRoles    `json:"roles"`                     // The roles text displayed on the main menu
+	RoleCache              map[string][]string `json:"role_cache"`                // The role cache
+	MatchmakingChannelIDs  map[string]string   `json:"matchmaking_channel_ids"`   // The matchmaking channel IDs
+	DebugChannelID         string              `json:"debug_channel_id"`          // The debug channel
+	AuditChannelID         string              `json:"audit_channel_id"`          // The audit channel
+	ModerationChannelID    string              `json:"moderation_channel_id"`     // The channel that receives player reports (defaults to the audit channel)
+	ErrorChannelID         string              `json:"error_channel_id"`          // The error channel
+	BlockVPNUsers          bool                `json:"block_vpn_users"`           // Block VPN users
+	FraudScoreThreshold    int                 `json:"fraud_score_threshold"`     // The fraud score threshold
+	AllowedFeatures        []string            `json:"allowed_features"`          // Allowed features
+	LogAlternateAccounts   bool                `json:"log_alternate_accounts"`    // Log alternate accounts
+	RegionStatusChannelIDs map[string]string   `json:"region_status_channel_ids"` // The region status board channel IDs (region -> channel ID)
+	AllowLargePrivateTeams bool                `json:"allow_large_private_teams"` // Allow private matches with teams larger than the default maximum
+	MatchPacing            *MatchPacing        `json:"match_pacing,omitempty"`    // Overrides the round timing of public arena matches
+	MinPartySizeByMode     map[string]int      `json:"min_party_size_by_mode"`    // The minimum party size to matchmake or create a match, by mode
+	DefaultMode            string              `json:"default_mode"`              // The mode used by /create and /allocate when none is given
+	DefaultRegion          string              `json:"default_region"`            // The region used by /create and /allocate when none is given
+	PublicArenaTeamSize    int                 `json:"public_arena_team_size"`    // Overrides the team size of public arena matches (0 is the default)
+	PublicCombatTeamSize   int                 `json:"public_combat_team_size"`   // Overrides the team size of public combat matches (0 is the default)
 
 	// UserIDs that are required to go to community values when the first join the social lobby
 	CommunityValuesUserIDs []string `json:"community_values_user_ids"`
@@ -169,10 +180,55 @@ func (m *GroupMetadata) IsAccountLinked(userID string) bool {
 	return false
 }
 
+// MinPartySize returns the smallest party allowed to matchmake or create a match in the mode (0 is unrestricted).
+func (m *GroupMetadata) MinPartySize(mode evr.Symbol) int {
+	return m.MinPartySizeByMode[mode.String()]
+}
+
+// PublicTeamSize returns the team size of the guild's public matches in the mode, or the default if it is unset or out of range.
+func (m *GroupMetadata) PublicTeamSize(mode evr.Symbol) int {
+	size, defaultSize := 0, 0
+	switch mode {
+	case evr.ModeArenaPublic:
+		size, defaultSize = m.PublicArenaTeamSize, DefaultPublicArenaTeamSize
+	case evr.ModeCombatPublic:
+		size, defaultSize = m.PublicCombatTeamSize, DefaultPublicCombatTeamSize
+	default:
+		return 0
+	}
+	if size < MinPublicTeamSize || size > MaxPublicTeamSize {
+		return defaultSize
+	}
+	return size
+}
+
+// DefaultModeRegion returns the guild's default mode and region for new matches.
+func (m *GroupMetadata) DefaultModeRegion() (mode evr.Symbol, region evr.Symbol) {
+	mode, region = evr.ModeArenaPrivate, evr.DefaultRegion
+	if m.DefaultMode != "" {
+		mode = evr.ToSymbol(m.DefaultMode)
+	}
+	if m.DefaultRegion != "" {
+		region = evr.ToSymbol(m.DefaultRegion)
+	}
+	return mode, region
+}
+
 func (m *GroupMetadata) IsAllowedFeature(feature string) bool {
 	return slices.Contains(m.AllowedFeatures, feature)
 }
 
+// IsMembersOnly returns true if only members may join the guild's lobbies in the mode.
+func (m *GroupMetadata) IsMembersOnly(mode evr.Symbol) bool {
+	switch mode {
+	case evr.ModeSocialPublic, evr.ModeSocialPrivate:
+		if m.MembersOnlySocial != nil {
+			return *m.MembersOnlySocial
+		}
+	}
+	return m.MembersOnlyMatchmaking
+}
+
 func (m *GroupMetadata) IsAllowedMatchmaking(userID string) bool {
 	if !m.MembersOnlyMatchmaking {
 		return true
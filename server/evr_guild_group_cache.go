@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+const (
+	GuildGroupsCacheTTL = 30 * time.Second // How long a user's guild group memberships are cached
+)
+
+type guildGroupsCacheEntry struct {
+	groupIDs []string
+	expiry   time.Time
+}
+
+// guildGroupsCache holds the IDs of each user's guild groups, so that hot paths (moderation commands, login, and
+// matchmaking) do not list their memberships on every lookup. Only the memberships are cached; the group metadata
+// (roles, settings) is always read fresh, so that permission checks and metadata updates never act on stale data.
+var guildGroupsCache = &MapOf[string, *guildGroupsCacheEntry]{}
+
+// guildGroupsCacheLastSweep is the time (unix nanoseconds) expired entries were last evicted from the cache.
+var guildGroupsCacheLastSweep atomic.Int64
+
+// InvalidateUserGuildGroups removes the user's cached guild groups; it must be called when their memberships change.
+func InvalidateUserGuildGroups(userIDs ...string) {
+	for _, userID := range userIDs {
+		guildGroupsCache.Delete(userID)
+	}
+}
+
+// userGuildGroups returns the user's guild groups, listing their memberships if they are not cached (or have expired).
+func userGuildGroups(ctx context.Context, nk runtime.NakamaModule, userID string) ([]*api.Group, error) {
+	if e, ok := guildGroupsCache.Load(userID); ok && time.Now().Before(e.expiry) {
+		nk.MetricsCounterAdd("guild_groups_cache", map[string]string{"result": "hit"}, 1)
+		if len(e.groupIDs) == 0 {
+			return []*api.Group{}, nil
+		}
+		groups, err := nk.GroupsGetId(ctx, e.groupIDs)
+		if err != nil {
+			return nil, fmt.Errorf("error getting groups: %w", err)
+		}
+		return groups, nil
+	}
+	nk.MetricsCounterAdd("guild_groups_cache", map[string]string{"result": "miss"}, 1)
+
+	groups := make([]*api.Group, 0)
+	cursor := ""
+	for {
+		// Fetch the groups using the provided userId
+		userGroups, nextCursor, err := nk.UserGroupsList(ctx, userID, 100, nil, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("error getting user groups: %w", err)
+		}
+
+		for _, ug := range userGroups {
+			if g := ug.GetGroup(); g.GetLangTag() == GuildGroupLangTag {
+				groups = append(groups, g)
+			}
+		}
+		if cursor = nextCursor; cursor == "" {
+			break
+		}
+	}
+
+	groupIDs := make([]string, 0, len(groups))
+	for _, g := range groups {
+		groupIDs = append(groupIDs, g.GetId())
+	}
+
+	now := time.Now()
+	guildGroupsCache.Store(userID, &guildGroupsCacheEntry{
+		groupIDs: groupIDs,
+		expiry:   now.Add(GuildGroupsCacheTTL),
+	})
+	evictExpiredGuildGroups(now)
+	return groups, nil
+}
+
+// evictExpiredGuildGroups removes the expired entries from the cache, at most once per TTL.
+func evictExpiredGuildGroups(now time.Time) {
+	last := guildGroupsCacheLastSweep.Load()
+	if now.UnixNano()-last < int64(GuildGroupsCacheTTL) || !guildGroupsCacheLastSweep.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	guildGroupsCache.Range(func(userID string, e *guildGroupsCacheEntry) bool {
+		if now.After(e.expiry) {
+			guildGroupsCache.Delete(userID)
+		}
+		return true
+	})
+}
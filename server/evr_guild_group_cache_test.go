@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+type guildGroupsCacheTestModule struct {
+	runtime.NakamaModule
+	listCalls int
+	getCalls  int
+	metadata  string
+	metrics   map[string]int
+}
+
+func (m *guildGroupsCacheTestModule) UserGroupsList(ctx context.Context, userID string, limit int, state *int, cursor string) ([]*api.UserGroupList_UserGroup, string, error) {
+	m.listCalls++
+	return []*api.UserGroupList_UserGroup{
+		{Group: &api.Group{Id: "guild", LangTag: GuildGroupLangTag, Metadata: m.metadata}},
+		{Group: &api.Group{Id: "other", LangTag: "en"}},
+	}, "", nil
+}
+
+func (m *guildGroupsCacheTestModule) GroupsGetId(ctx context.Context, groupIDs []string) ([]*api.Group, error) {
+	m.getCalls++
+	groups := make([]*api.Group, 0, len(groupIDs))
+	for _, id := range groupIDs {
+		groups = append(groups, &api.Group{Id: id, LangTag: GuildGroupLangTag, Metadata: m.metadata})
+	}
+	return groups, nil
+}
+
+func (m *guildGroupsCacheTestModule) MetricsCounterAdd(name string, tags map[string]string, delta int64) {
+	m.metrics[tags["result"]] += int(delta)
+}
+
+func TestUserGuildGroups_Cache(t *testing.T) {
+	ctx := context.Background()
+	nk := &guildGroupsCacheTestModule{metrics: make(map[string]int)}
+	userID := uuid.Must(uuid.NewV4()).String()
+	defer InvalidateUserGuildGroups(userID)
+
+	for i, metadata := range []string{"before", "after"} {
+		// The metadata changes between lookups; a cached membership must still return the current metadata.
+		nk.metadata = metadata
+		groups, err := userGuildGroups(ctx, nk, userID)
+		if err != nil {
+			t.Fatalf("userGuildGroups() error = %v", err)
+		}
+		if len(groups) != 1 || groups[0].Id != "guild" {
+			t.Fatalf("userGuildGroups() = %v, want only the guild group", groups)
+		}
+		if groups[0].Metadata != metadata {
+			t.Errorf("lookup %d: metadata = %q, want %q", i, groups[0].Metadata, metadata)
+		}
+	}
+	if nk.listCalls != 1 || nk.getCalls != 1 || nk.metrics["miss"] != 1 || nk.metrics["hit"] != 1 {
+		t.Errorf("listCalls = %d, getCalls = %d, metrics = %v, want 1 list call, 1 get call, 1 miss and 1 hit", nk.listCalls, nk.getCalls, nk.metrics)
+	}
+
+	InvalidateUserGuildGroups(userID)
+	if _, err := userGuildGroups(ctx, nk, userID); err != nil {
+		t.Fatalf("userGuildGroups() error = %v", err)
+	}
+	if nk.listCalls != 2 {
+		t.Errorf("listCalls = %d after invalidation, want 2", nk.listCalls)
+	}
+}
+
+func TestEvictExpiredGuildGroups(t *testing.T) {
+	expired := uuid.Must(uuid.NewV4()).String()
+	current := uuid.Must(uuid.NewV4()).String()
+	defer InvalidateUserGuildGroups(expired, current)
+
+	now := time.Now()
+	guildGroupsCache.Store(expired, &guildGroupsCacheEntry{expiry: now.Add(-time.Second)})
+	guildGroupsCache.Store(current, &guildGroupsCacheEntry{expiry: now.Add(GuildGroupsCacheTTL)})
+	guildGroupsCacheLastSweep.Store(0)
+
+	evictExpiredGuildGroups(now)
+
+	if _, ok := guildGroupsCache.Load(expired); ok {
+		t.Errorf("expected the expired entry to be evicted")
+	}
+	if _, ok := guildGroupsCache.Load(current); !ok {
+		t.Errorf("expected the current entry to be kept")
+	}
+}
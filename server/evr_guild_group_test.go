@@ -3,6 +3,7 @@ package server
 import (
 	"testing"
 
+	"github.com/heroiclabs/nakama/v3/server/evr"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -66,3 +67,48 @@ func TestGuildGroupMembership_ToUint64(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupMetadata_PublicTeamSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata GroupMetadata
+		mode     evr.Symbol
+		expected int
+	}{
+		{"arena default", GroupMetadata{}, evr.ModeArenaPublic, DefaultPublicArenaTeamSize},
+		{"combat default", GroupMetadata{}, evr.ModeCombatPublic, DefaultPublicCombatTeamSize},
+		{"combat override", GroupMetadata{PublicCombatTeamSize: 3}, evr.ModeCombatPublic, 3},
+		{"arena override does not apply to combat", GroupMetadata{PublicArenaTeamSize: 3}, evr.ModeCombatPublic, DefaultPublicCombatTeamSize},
+		{"too small", GroupMetadata{PublicArenaTeamSize: 1}, evr.ModeArenaPublic, DefaultPublicArenaTeamSize},
+		{"too large", GroupMetadata{PublicArenaTeamSize: 6}, evr.ModeArenaPublic, DefaultPublicArenaTeamSize},
+		{"private mode", GroupMetadata{PublicArenaTeamSize: 3}, evr.ModeArenaPrivate, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.metadata.PublicTeamSize(tt.mode))
+		})
+	}
+}
+
+func TestGroupMetadata_IsMembersOnly(t *testing.T) {
+	open, closed := false, true
+	tests := []struct {
+		name     string
+		metadata GroupMetadata
+		mode     evr.Symbol
+		expected bool
+	}{
+		{"social follows matchmaking", GroupMetadata{MembersOnlyMatchmaking: true}, evr.ModeSocialPublic, true},
+		{"open social, members-only matchmaking", GroupMetadata{MembersOnlyMatchmaking: true, MembersOnlySocial: &open}, evr.ModeSocialPublic, false},
+		{"open social does not open matchmaking", GroupMetadata{MembersOnlyMatchmaking: true, MembersOnlySocial: &open}, evr.ModeArenaPublic, true},
+		{"members-only social, open matchmaking", GroupMetadata{MembersOnlySocial: &closed}, evr.ModeSocialPublic, true},
+		{"members-only social does not close matchmaking", GroupMetadata{MembersOnlySocial: &closed}, evr.ModeCombatPublic, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.metadata.IsMembersOnly(tt.mode))
+		})
+	}
+}
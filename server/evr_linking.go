@@ -2,9 +2,10 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
-	"math/rand"
+	"math/big"
 	"net/http"
 	"net/url"
 	"strings"
@@ -24,12 +25,19 @@ import (
 // It contains the link code, xplatform ID string, and HMD serial number.
 // TODO move this to evr-common
 type LinkTicket struct {
-	Code         string            `json:"link_code"`          // the code the user will exchange to link the account
-	XPID         evr.XPID          `json:"xp_id"`              // the xplatform ID used by EchoVR
-	ClientIP     string            `json:"client_ip"`          // the client IP address that generated this link ticket
-	LoginProfile *evr.LoginProfile `json:"game_login_request"` // the login request payload that generated this link ticket
+	Code         string            `json:"link_code"`            // the code the user will exchange to link the account
+	XPID         evr.XPID          `json:"xp_id"`                // the xplatform ID used by EchoVR
+	ClientIP     string            `json:"client_ip"`            // the client IP address that generated this link ticket
+	LoginProfile *evr.LoginProfile `json:"game_login_request"`   // the login request payload that generated this link ticket
+	UserID       string            `json:"user_id,omitempty"`    // the user that a login ticket (created from Discord) authenticates
+	ExpiresAt    time.Time         `json:"expires_at,omitempty"` // when the login ticket expires
 }
 
+const (
+	LoginTicketLifetime = 10 * time.Minute
+	LoginCodeLength     = 8
+)
+
 func LoadLinkTickets(ctx context.Context, nk runtime.NakamaModule) (map[string]*LinkTicket, error) {
 	linkTickets := make(map[string]*LinkTicket, 1)
 
@@ -130,24 +138,95 @@ func generateLinkTicket(linkTickets map[string]*LinkTicket, xpid evr.XPID, clien
 	return ticket
 }
 
+// CreateLoginTicket creates a one-time login code that links the headset that uses it to the user's account.
+func CreateLoginTicket(ctx context.Context, nk runtime.NakamaModule, userID string) (*LinkTicket, error) {
+	linkTickets, err := LoadLinkTickets(ctx, nk)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove expired login tickets, and any previous ticket for this user.
+	for code, ticket := range linkTickets {
+		if ticket.UserID != "" && (ticket.UserID == userID || time.Now().After(ticket.ExpiresAt)) {
+			delete(linkTickets, code)
+		}
+	}
+
+	var code string
+	for {
+		code = generateCode(LoginCodeLength)
+		if _, ok := linkTickets[code]; !ok {
+			break
+		}
+	}
+
+	ticket := &LinkTicket{
+		Code:      code,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(LoginTicketLifetime).UTC(),
+	}
+	linkTickets[code] = ticket
+
+	if err := StoreLinkTickets(ctx, nk, linkTickets); err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// ExchangeLoginCode exchanges a one-time login code for the login ticket, deleting it from storage.
+func ExchangeLoginCode(ctx context.Context, nk runtime.NakamaModule, loginCode string) (*LinkTicket, error) {
+	loginCode = strings.ToUpper(loginCode)
+
+	linkTickets, err := LoadLinkTickets(ctx, nk)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, ok := linkTickets[loginCode]
+	if !ok || ticket.UserID == "" {
+		return nil, runtime.NewError(fmt.Sprintf("login code `%s` not found", loginCode), StatusNotFound)
+	}
+
+	delete(linkTickets, loginCode)
+
+	if err := StoreLinkTickets(ctx, nk, linkTickets); err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(ticket.ExpiresAt) {
+		return nil, runtime.NewError(fmt.Sprintf("login code `%s` has expired", loginCode), StatusNotFound)
+	}
+
+	return ticket, nil
+}
+
 // generateLinkCode generates a 4 character random link code (excluding homoglyphs, vowels, and numbers).
 // The character set .
 // The random number generator is seeded with the current time to ensure randomness.
 // Returns the generated link code as a string.
 // TODO move this to the evrbackend runtime module
 func generateLinkCode() string {
+	return generateCode(4)
+}
+
+func generateCode(length int) string {
 	// Define the set of valid validChars for the link code
 	validChars := "ACDEFGHIJKLMNPRSTUXYZ"
 
-	// Create a new local random generator with a known seed value
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	// Use a cryptographically secure source, since codes are used to log in.
+	charCount := big.NewInt(int64(len(validChars)))
 
-	// Create a byte slice with 4 elements
-	code := make([]byte, 4)
+	// Create a byte slice with the code length
+	code := make([]byte, length)
 
 	// Randomly select an index from the array and generate the code
 	for i := range code {
-		code[i] = validChars[rng.Intn(len(validChars))]
+		n, err := rand.Int(rand.Reader, charCount)
+		if err != nil {
+			panic(fmt.Sprintf("failed to generate random code: %v", err))
+		}
+		code[i] = validChars[n.Int64()]
 	}
 
 	return string(code)
@@ -224,7 +303,7 @@ func ExchangeLinkCode(ctx context.Context, nk runtime.NakamaModule, logger runti
 	}
 
 	linkTicket, ok := linkTickets[linkCode]
-	if !ok {
+	if !ok || linkTicket.UserID != "" {
 		return nil, runtime.NewError(fmt.Sprintf("link code `%s` not found", linkCode), StatusNotFound)
 	}
 
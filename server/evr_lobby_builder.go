@@ -30,11 +30,13 @@ type LobbyBuilder struct {
 	tracker         Tracker
 	profileRegistry *ProfileRegistry
 	metrics         Metrics
+	appBot          *DiscordAppBot
+	queueEstimator  *matchmakingQueueEstimator
 
 	mapQueue map[evr.Symbol][]evr.Symbol // map[mode][]level
 }
 
-func NewLobbyBuilder(logger *zap.Logger, nk runtime.NakamaModule, sessionRegistry SessionRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, profileRegistry *ProfileRegistry) *LobbyBuilder {
+func NewLobbyBuilder(logger *zap.Logger, nk runtime.NakamaModule, sessionRegistry SessionRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, profileRegistry *ProfileRegistry, queueEstimator *matchmakingQueueEstimator) *LobbyBuilder {
 	logger = logger.With(zap.String("module", "lobby_builder"))
 
 	return &LobbyBuilder{
@@ -46,6 +48,7 @@ func NewLobbyBuilder(logger *zap.Logger, nk runtime.NakamaModule, sessionRegistr
 		tracker:         tracker,
 		metrics:         metrics,
 		profileRegistry: profileRegistry,
+		queueEstimator:  queueEstimator,
 
 		mapQueue: make(map[evr.Symbol][]evr.Symbol),
 	}
@@ -139,6 +142,15 @@ func (b *LobbyBuilder) groupByTicket(entrants []*MatchmakerEntry) [][]*Matchmake
 	return parties
 }
 
+// teamAlignmentsFromEntrants returns the matchmaker's team for each entrant, so that it is kept if their reservation expires before they join.
+func teamAlignmentsFromEntrants(entrants []*EvrMatchPresence) TeamAlignments {
+	alignments := make(TeamAlignments, len(entrants))
+	for _, e := range entrants {
+		alignments[e.GetUserId()] = e.RoleAlignment
+	}
+	return alignments
+}
+
 func (b *LobbyBuilder) buildMatch(logger *zap.Logger, entrants []*MatchmakerEntry) (err error) {
 	// Build matches one at a time.
 
@@ -240,6 +252,7 @@ func (b *LobbyBuilder) buildMatch(logger *zap.Logger, entrants []*MatchmakerEntr
 		Reservations:        entrantPresences,
 		ReservationLifetime: 20 * time.Second,
 		StartTime:           time.Now().UTC(),
+		TeamAlignments:      teamAlignmentsFromEntrants(entrantPresences),
 	}
 
 	var label *MatchLabel
@@ -267,6 +280,19 @@ func (b *LobbyBuilder) buildMatch(logger *zap.Logger, entrants []*MatchmakerEntr
 		return fmt.Errorf("failed to get server session")
 	}
 
+	// Record how well the selected server suits the entrants.
+	if rtt, ok := gameServers[label.Broadcaster.Endpoint.GetExternalIP()]; ok {
+		region := "default"
+		if len(label.Broadcaster.Regions) > 0 {
+			region = label.Broadcaster.Regions[0].String()
+		}
+		b.metrics.CustomTimer("matchmaking_selected_server_rtt", map[string]string{
+			"mode":    mode.String(),
+			"region":  region,
+			"groupID": groupID.String(),
+		}, time.Duration(rtt)*time.Millisecond)
+	}
+
 	successful := make([]*EvrMatchPresence, 0, len(entrants))
 	errored := make([]*EvrMatchPresence, 0, len(entrants))
 	wg := &sync.WaitGroup{}
@@ -304,6 +330,30 @@ func (b *LobbyBuilder) buildMatch(logger *zap.Logger, entrants []*MatchmakerEntr
 	b.metrics.CustomCounter("lobby_join_match_made", tags, int64(len(successful)))
 	b.metrics.CustomCounter("lobby_error_match_made", tags, int64(len(errored)))
 
+	// Record how long the matched players waited; only successful matches count towards the queue estimate.
+	submissionTimes := make(map[string]time.Time, len(entrants))
+	for _, e := range entrants {
+		if t, err := time.Parse(time.RFC3339, e.StringProperties["submission_time"]); err == nil {
+			submissionTimes[e.Presence.GetSessionId()] = t
+		}
+	}
+	for _, p := range successful {
+		t, ok := submissionTimes[p.GetSessionId()]
+		if !ok {
+			continue
+		}
+		wait := time.Since(t)
+		b.metrics.CustomTimer("matchmaking_matched_duration", map[string]string{
+			"mode":    label.Mode.String(),
+			"groupID": label.GetGroupID().String(),
+		}, wait)
+		if b.queueEstimator != nil {
+			b.queueEstimator.Record(label.GetGroupID().String(), label.Mode, wait)
+		}
+	}
+
+	go b.appBot.SendMatchmakerMatchedNotification(successful, label)
+
 	logger.Info("Match built.", zap.String("mid", label.ID.UUID.String()), zap.Any("teams", ratedMatch), zap.Any("successful", successful), zap.Any("errored", errored), zap.Any("game_server", label.Broadcaster))
 	return nil
 }
@@ -566,18 +616,34 @@ func AllocateGameServer(ctx context.Context, logger runtime.Logger, nk runtime.N
 		return 0
 	})
 
-	// Find the first available game server
-	var label *MatchLabel
+	// Prepare the first game server that responds
+	return PrepareFirstAvailable(logger, labels, 0, func(matchID MatchID) (*MatchLabel, error) {
+		return LobbyPrepareSession(ctx, nk, matchID, settings)
+	})
+}
+
+// AllocateMaxCandidates is the most game servers tried when allocating a match from Discord, before giving up.
+const AllocateMaxCandidates = 3
+
+// PrepareFirstAvailable prepares a session on the first of the unassigned servers (in order) that succeeds, trying at most
+// maxCandidates of them (no limit if zero). A single unresponsive server does not fail the allocation.
+func PrepareFirstAvailable(logger runtime.Logger, labels []*MatchLabel, maxCandidates int, prepareFn func(matchID MatchID) (*MatchLabel, error)) (*MatchLabel, error) {
+	attempts := 0
 	for _, l := range labels {
 		if l.LobbyType != UnassignedLobby {
 			continue
 		}
+		if maxCandidates > 0 && attempts >= maxCandidates {
+			break
+		}
+		attempts++
 
-		label, err = LobbyPrepareSession(ctx, nk, l.ID, settings)
+		label, err := prepareFn(l.ID)
 		if err != nil {
 			logger.WithFields(map[string]interface{}{
-				"mid": l.ID.UUID.String(),
-				"err": err,
+				"mid":     l.ID.UUID.String(),
+				"attempt": attempts,
+				"err":     err,
 			}).Warn("Failed to prepare session")
 			continue
 		}
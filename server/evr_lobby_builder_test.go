@@ -1,9 +1,13 @@
 package server
 
 import (
+	"errors"
+	"os"
 	"testing"
 
+	"github.com/gofrs/uuid/v5"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
 )
 
 func TestSortGameServerIPs(t *testing.T) {
@@ -166,3 +170,48 @@ func TestGroupByTicket(t *testing.T) {
 		})
 	}
 }
+
+func TestPrepareFirstAvailable(t *testing.T) {
+	logger := NewRuntimeGoLogger(NewJSONLogger(os.Stdout, zapcore.ErrorLevel, JSONFormat))
+
+	labels := []*MatchLabel{
+		{ID: MatchID{uuid.Must(uuid.NewV4()), "node"}, LobbyType: PublicLobby},
+		{ID: MatchID{uuid.Must(uuid.NewV4()), "node"}, LobbyType: UnassignedLobby},
+		{ID: MatchID{uuid.Must(uuid.NewV4()), "node"}, LobbyType: UnassignedLobby},
+		{ID: MatchID{uuid.Must(uuid.NewV4()), "node"}, LobbyType: UnassignedLobby},
+	}
+
+	t.Run("first candidate fails, second succeeds", func(t *testing.T) {
+		tried := make([]MatchID, 0)
+		label, err := PrepareFirstAvailable(logger, labels, AllocateMaxCandidates, func(matchID MatchID) (*MatchLabel, error) {
+			tried = append(tried, matchID)
+			if matchID == labels[1].ID {
+				return nil, errors.New("server unresponsive")
+			}
+			return &MatchLabel{ID: matchID}, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, labels[2].ID, label.ID)
+		assert.Equal(t, []MatchID{labels[1].ID, labels[2].ID}, tried)
+	})
+
+	t.Run("gives up after the maximum candidates", func(t *testing.T) {
+		attempts := 0
+		_, err := PrepareFirstAvailable(logger, labels, 2, func(matchID MatchID) (*MatchLabel, error) {
+			attempts++
+			return nil, errors.New("server unresponsive")
+		})
+		assert.ErrorIs(t, err, ErrMatchmakingNoAvailableServers)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("tries every candidate without a maximum", func(t *testing.T) {
+		attempts := 0
+		_, err := PrepareFirstAvailable(logger, labels, 0, func(matchID MatchID) (*MatchLabel, error) {
+			attempts++
+			return nil, errors.New("server unresponsive")
+		})
+		assert.ErrorIs(t, err, ErrMatchmakingNoAvailableServers)
+		assert.Equal(t, 3, attempts)
+	})
+}
@@ -20,7 +20,7 @@ func (p *EvrPipeline) lobbyCreate(ctx context.Context, logger *zap.Logger, sessi
 	db := p.db
 
 	// Do authorization checks related to the guild.
-	if err := p.lobbyAuthorize(ctx, session, params, params.GroupID.String()); err != nil {
+	if err := p.lobbyAuthorize(ctx, session, params, params.GroupID.String(), params.Mode); err != nil {
 		logger.Warn("Failed to authorize create session request", zap.Error(err))
 		return MatchID{}, err
 	}
@@ -55,13 +55,14 @@ func (p *EvrPipeline) lobbyCreate(ctx context.Context, logger *zap.Logger, sessi
 	label := labels[0]
 
 	settings := MatchSettings{
-		Mode:             params.Mode,
-		Level:            params.Level,
-		GroupID:          params.GroupID,
-		RequiredFeatures: params.RequiredFeatures,
-		StartTime:        time.Now().UTC(),
-		TeamAlignments:   map[string]int{session.UserID().String(): params.Role},
-		SpawnedBy:        session.UserID().String(),
+		Mode:              params.Mode,
+		Level:             params.Level,
+		GroupID:           params.GroupID,
+		RequiredFeatures:  params.RequiredFeatures,
+		PreferredFeatures: params.PreferredFeatures,
+		StartTime:         time.Now().UTC(),
+		TeamAlignments:    map[string]int{session.UserID().String(): params.Role},
+		SpawnedBy:         session.UserID().String(),
 	}
 
 	matchID := label.ID
@@ -138,6 +139,10 @@ func lobbyCreateQuery(ctx context.Context, logger *zap.Logger, db *sql.DB, nk ru
 		}
 	}
 
+	for _, f := range params.PreferredFeatures {
+		qparts = append(qparts, fmt.Sprintf("label.broadcaster.features:/(%s)/^2", Query.Escape(f)))
+	}
+
 	query := strings.Join(qparts, " ")
 	return query, nil
 }
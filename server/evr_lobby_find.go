@@ -35,7 +35,7 @@ func (p *EvrPipeline) lobbyFind(ctx context.Context, logger *zap.Logger, session
 	startTime := time.Now()
 
 	// Do authorization checks related to the guild.
-	if err := p.lobbyAuthorize(ctx, session, lobbyParams, lobbyParams.GroupID.String()); err != nil {
+	if err := p.lobbyAuthorize(ctx, session, lobbyParams, lobbyParams.GroupID.String(), lobbyParams.Mode); err != nil {
 		return err
 	}
 
@@ -58,6 +58,11 @@ func (p *EvrPipeline) lobbyFind(ctx context.Context, logger *zap.Logger, session
 	// Monitor the matchmaking status stream, canceling the context if the stream is closed.
 	go p.monitorMatchmakingStream(ctx, logger, session, lobbyParams, cancel)
 
+	// Keep verbose players informed of their position in the queue.
+	if lobbyParams.Verbose {
+		go p.sendQueueEstimates(ctx, logger, lobbyParams)
+	}
+
 	entrantSessionIDs := []uuid.UUID{session.id}
 
 	var lobbyGroup *LobbyGroup
@@ -89,6 +94,13 @@ func (p *EvrPipeline) lobbyFind(ctx context.Context, logger *zap.Logger, session
 		}
 	}
 
+	// Enforce the guild's minimum party size for the mode.
+	if md, err := GetGuildGroupMetadata(ctx, p.db, lobbyParams.GroupID.String()); err != nil {
+		logger.Warn("Failed to get guild group metadata, skipping the minimum party size check", zap.Error(err))
+	} else if minSize := md.MinPartySize(lobbyParams.Mode); lobbyParams.GetPartySize() < minSize {
+		return NewLobbyError(BadRequest, fmt.Sprintf("This guild requires a party of at least %d players for `%s`.", minSize, lobbyParams.Mode.String()))
+	}
+
 	p.metrics.CustomCounter("lobby_find_match", lobbyParams.MetricsTags(), int64(lobbyParams.GetPartySize()))
 	logger.Info("Finding match", zap.String("mode", lobbyParams.Mode.String()), zap.Int("party_size", lobbyParams.GetPartySize()))
 
@@ -458,6 +470,11 @@ func (p *EvrPipeline) lobbyBackfill(ctx context.Context, logger *zap.Logger, lob
 				return 1
 			}
 
+			// Sort by the most preferred features
+			if s := MatchedFeatures(lobbyParams.PreferredFeatures, b.State.PreferredFeatures) - MatchedFeatures(lobbyParams.PreferredFeatures, a.State.PreferredFeatures); s != 0 {
+				return s
+			}
+
 			// Sort by largest population
 			if s := b.State.PlayerCount - a.State.PlayerCount; s != 0 {
 				return s
@@ -492,6 +509,13 @@ func (p *EvrPipeline) lobbyBackfill(ctx context.Context, logger *zap.Logger, lob
 				continue
 			}
 
+			// Skip matches that require features the entrants do not support
+			if slices.ContainsFunc(entrants, func(e *EvrMatchPresence) bool {
+				return len(MissingFeatures(l.RequiredFeatures, e.SupportedFeatures)) > 0
+			}) {
+				continue
+			}
+
 			// Social lobbies can only have one team
 			if lobbyParams.Mode == evr.ModeSocialPublic {
 				team = evr.TeamSocial
@@ -571,7 +595,11 @@ func (p *EvrPipeline) CheckServerPing(ctx context.Context, logger *zap.Logger, s
 			return true
 		})
 
-		if err := PingGameServers(ctx, logger, session, p.db, activeEndpoints); err != nil {
+		matchConfig := p.config.GetMatch()
+		refreshInterval := time.Duration(matchConfig.LatencyCacheRefreshSec) * time.Second
+		expiry := time.Duration(matchConfig.LatencyCacheExpirySec) * time.Second
+
+		if err := PingGameServers(ctx, logger, session, p.db, activeEndpoints, matchConfig.PingCandidateCount, refreshInterval, expiry); err != nil {
 			doneCh <- err
 		}
 		doneCh <- nil
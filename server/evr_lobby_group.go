@@ -1,7 +1,9 @@
 package server
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/gofrs/uuid/v5"
@@ -9,6 +11,9 @@ import (
 	"github.com/heroiclabs/nakama-common/runtime"
 )
 
+// PartyGroupMaxSize is the most members a party group may have.
+const PartyGroupMaxSize = 4
+
 type LobbyGroup struct {
 	sync.RWMutex
 	session *sessionWS
@@ -100,7 +105,7 @@ func JoinPartyGroup(session *sessionWS, groupName string, partyID uuid.UUID, cur
 	ph, found := partyRegistry.parties.Load(partyID)
 	if !found {
 
-		maxSize := 4
+		maxSize := PartyGroupMaxSize
 		open := true
 
 		// Create the party
@@ -152,3 +157,20 @@ func JoinPartyGroup(session *sessionWS, groupName string, partyID uuid.UUID, cur
 
 	return lobbyGroup, isLeader, nil
 }
+
+// LeavePartyGroup removes all of the user's sessions from the party group's stream.
+func LeavePartyGroup(ctx context.Context, nk runtime.NakamaModule, node, userID string, partyID uuid.UUID) error {
+	presences, err := nk.StreamUserList(StreamModeParty, partyID.String(), "", node, true, true)
+	if err != nil {
+		return fmt.Errorf("failed to list party members: %w", err)
+	}
+	for _, p := range presences {
+		if p.GetUserId() != userID {
+			continue
+		}
+		if err := nk.StreamUserLeave(StreamModeParty, partyID.String(), "", node, userID, p.GetSessionId()); err != nil {
+			return fmt.Errorf("failed to leave party: %w", err)
+		}
+	}
+	return nil
+}
@@ -6,11 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/gofrs/uuid/v5"
 	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama/v3/server/evr"
 	"go.uber.org/zap"
 )
 
@@ -61,11 +63,9 @@ func LobbyJoinEntrants(logger *zap.Logger, matchRegistry MatchRegistry, tracker
 	}
 
 	for _, e := range entrants {
-		for _, feature := range label.RequiredFeatures {
-			if !slices.Contains(e.SupportedFeatures, feature) {
-				logger.With(zap.String("uid", e.UserID.String()), zap.String("sid", e.SessionID.String())).Warn("Player does not support required feature", zap.String("feature", feature), zap.String("mid", label.ID.UUID.String()), zap.String("uid", e.UserID.String()))
-				return NewLobbyErrorf(MissingEntitlement, "player does not support required feature: %s", feature)
-			}
+		if missing := MissingFeatures(label.RequiredFeatures, e.SupportedFeatures); len(missing) > 0 {
+			logger.With(zap.String("uid", e.UserID.String()), zap.String("sid", e.SessionID.String())).Warn("Player does not support required features", zap.Strings("missing_features", missing), zap.String("mid", label.ID.UUID.String()))
+			return NewLobbyErrorf(MissingEntitlement, "player does not support required features: %s", strings.Join(missing, ", "))
 		}
 	}
 
@@ -191,7 +191,7 @@ func LobbyJoinEntrants(logger *zap.Logger, matchRegistry MatchRegistry, tracker
 	return nil
 }
 
-func (p *EvrPipeline) lobbyAuthorize(ctx context.Context, session Session, lobbyParams *LobbySessionParameters, groupID string) error {
+func (p *EvrPipeline) lobbyAuthorize(ctx context.Context, session Session, lobbyParams *LobbySessionParameters, groupID string, mode evr.Symbol) error {
 	userID := session.UserID().String()
 
 	params, ok := LoadParams(ctx)
@@ -213,7 +213,7 @@ func (p *EvrPipeline) lobbyAuthorize(ctx context.Context, session Session, lobby
 
 	membership, ok := params.MembershipsLoad()[groupID]
 
-	if !ok && groupMetadata.MembersOnlyMatchmaking {
+	if !ok && groupMetadata.IsMembersOnly(mode) {
 
 		if sendAuditMessage {
 			if _, err := p.appBot.LogAuditMessage(ctx, groupID, fmt.Sprintf("Rejected non-member <@%s>", userID), true); err != nil {
@@ -253,7 +253,7 @@ func (p *EvrPipeline) lobbyAuthorize(ctx context.Context, session Session, lobby
 
 				accountAge := time.Since(t).Hours() / 24
 
-				if _, err := p.appBot.dg.ChannelMessageSend(groupMetadata.AuditChannelID, fmt.Sprintf("Rejected user <@%s> because of account age (%d days).", discordID, int(accountAge))); err != nil {
+				if _, err := p.appBot.LogAuditMessage(ctx, groupID, fmt.Sprintf("Rejected user <@%s> because of account age (%d days).", discordID, int(accountAge)), false); err != nil {
 					p.logger.Warn("Failed to send audit message", zap.String("channel_id", groupMetadata.AuditChannelID), zap.Error(err))
 				}
 			}
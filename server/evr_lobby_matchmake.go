@@ -17,8 +17,8 @@ import (
 )
 
 const (
-	LatencyCacheRefreshInterval = time.Hour * 3
-	LatencyCacheExpiry          = time.Hour * 72 // 3 hours
+	LatencyCacheRefreshInterval = time.Hour * 3  // The default age after which an RTT is refreshed
+	LatencyCacheExpiry          = time.Hour * 72 // The default age after which an RTT is ignored
 
 	LatencyCacheStorageKey = "LatencyCache"
 )
@@ -109,6 +109,15 @@ var DefaultMatchmakerTicketConfigs = map[evr.Symbol]MatchmakingTicketParameters{
 	},
 }
 
+// matchmakingTicketConfig returns the ticket config for the mode, limited to two teams of the guild's team size (if it has one).
+func matchmakingTicketConfig(mode evr.Symbol, teamSize int) (MatchmakingTicketParameters, bool) {
+	ticketConfig, ok := DefaultMatchmakerTicketConfigs[mode]
+	if ok && teamSize > 0 {
+		ticketConfig.MaxCount = teamSize * 2
+	}
+	return ticketConfig, ok
+}
+
 func (p *EvrPipeline) matchmakingTicketTimeout() time.Duration {
 	maxIntervals := p.config.GetMatchmaker().MaxIntervals
 	intervalSecs := p.config.GetMatchmaker().IntervalSec
@@ -117,7 +126,7 @@ func (p *EvrPipeline) matchmakingTicketTimeout() time.Duration {
 
 func (p *EvrPipeline) lobbyMatchMakeWithFallback(ctx context.Context, logger *zap.Logger, session *sessionWS, lobbyParams *LobbySessionParameters, lobbyGroup *LobbyGroup) (err error) {
 
-	ticketConfig, ok := DefaultMatchmakerTicketConfigs[lobbyParams.Mode]
+	ticketConfig, ok := matchmakingTicketConfig(lobbyParams.Mode, lobbyParams.TeamSize)
 	if !ok {
 		return fmt.Errorf("matchmaking ticket config not found for mode %s", lobbyParams.Mode)
 	}
@@ -267,6 +276,7 @@ type MatchmakingSettings struct {
 	NextMatchID                 MatchID                       `json:"next_match_id"`                            // Try to join this match immediately when finding a match
 	NextMatchRole               string                        `json:"next_match_role"`                          // The role to join the next match as
 	NextMatchDiscordID          string                        `json:"next_match_discord_id"`                    // The discord ID to join the next match as
+	NextMatchMode               evr.Symbol                    `json:"next_match_mode,omitempty"`                // Matchmake for this mode instead of the next social lobby
 	MaxServerRTT                int                           `json:"max_server_rtt,omitempty"`                 // The maximum RTT to allow
 	StaticBaseRankPercentile    float64                       `json:"static_rank_percentile,omitempty"`         // The static rank percentile to use
 	RankPercentileMaxDelta      float64                       `json:"rank_percentile_delta_max,omitempty"`      // The upper limit percentile range to matchmake with
@@ -281,6 +291,7 @@ type MatchmakingSettings struct {
 	FallbackTimeoutSecs         int                           `json:"fallback_timeout_secs,omitempty"`          // The fallback timeout
 	GlobalSettingsVersion       string                        `json:"global_settings_version,omitempty"`        // The global settings version (for caching)
 	PreviousRankPercentile      float64                       `json:"previous_rank_percentile,omitempty"`       // The previous rank percentile
+	NotifyOnMatch               bool                          `json:"notify_on_match,omitempty"`                // DM the player when the matchmaker makes their match
 }
 
 func (MatchmakingSettings) GetStorageID() StorageID {
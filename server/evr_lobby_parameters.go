@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -36,6 +37,7 @@ type LobbySessionParameters struct {
 	Level                  evr.Symbol                    `json:"level"`
 	SupportedFeatures      []string                      `json:"supported_features"`
 	RequiredFeatures       []string                      `json:"required_features"`
+	PreferredFeatures      []string                      `json:"preferred_features"`
 	CurrentMatchID         MatchID                       `json:"current_match_id"`
 	NextMatchID            MatchID                       `json:"next_match_id"`
 	Role                   int                           `json:"role"`
@@ -59,6 +61,7 @@ type LobbySessionParameters struct {
 	FailsafeTimeout        time.Duration                 `json:"failsafe_timeout"` // The failsafe timeout
 	FallbackTimeout        time.Duration                 `json:"fallback_timeout"` // The fallback timeout
 	DisplayName            string                        `json:"display_name"`
+	TeamSize               int                           `json:"team_size"` // The team size of the guild's public matches in the mode
 
 	latencyHistory LatencyHistory
 }
@@ -199,6 +202,21 @@ func NewLobbyParametersFromRequest(ctx context.Context, logger *zap.Logger, sess
 		}()
 	}
 
+	// A player who queued from Discord matchmakes instead of loading into the next social lobby.
+	if _, ok := r.(*evr.LobbyFindSessionRequest); ok && userSettings.NextMatchMode != 0 && userSettings.NextMatchID.IsNil() && mode == evr.ModeSocialPublic {
+		logger.Info("Matchmaking for the queued mode", zap.String("queued_mode", userSettings.NextMatchMode.String()))
+		mode = userSettings.NextMatchMode
+		level = evr.LevelUnspecified
+
+		settings := userSettings
+		settings.NextMatchMode = 0
+		go func() {
+			if _, err := SaveToStorage(ctx, p.runtimeModule, userID, settings); err != nil {
+				logger.Warn("Failed to clear the queued mode", zap.Error(err))
+			}
+		}()
+	}
+
 	matchmakingQueryAddons := []string{
 		globalSettings.MatchmakingQueryAddon,
 		userSettings.MatchmakingQueryAddon,
@@ -360,6 +378,14 @@ func NewLobbyParametersFromRequest(ctx context.Context, logger *zap.Logger, sess
 		}
 	}
 
+	// Use the guild's public team size to find matches with room for the party.
+	groupMetadata, err := GetGuildGroupMetadata(ctx, p.db, groupID.String())
+	if err != nil {
+		logger.Warn("Failed to get guild group metadata", zap.Error(err))
+		groupMetadata = &GroupMetadata{}
+	}
+	teamSize := groupMetadata.PublicTeamSize(mode)
+
 	maximumFailsafeSecs := globalSettings.MatchmakingTimeoutSecs - p.config.GetMatchmaker().IntervalSec*2
 	failsafeTimeoutSecs := min(maximumFailsafeSecs, globalSettings.FailsafeTimeoutSecs)
 
@@ -377,6 +403,7 @@ func NewLobbyParametersFromRequest(ctx context.Context, logger *zap.Logger, sess
 		Level:                  level,
 		SupportedFeatures:      supportedFeatures,
 		RequiredFeatures:       requiredFeatures,
+		PreferredFeatures:      sessionParams.PreferredFeatures,
 		Role:                   entrantRole,
 		DisableArenaBackfill:   globalSettings.DisableArenaBackfill || userSettings.DisableArenaBackfill,
 		BackfillQueryAddon:     strings.Join(backfillQueryAddons, " "),
@@ -400,6 +427,7 @@ func NewLobbyParametersFromRequest(ctx context.Context, logger *zap.Logger, sess
 		FailsafeTimeout:        time.Duration(failsafeTimeoutSecs) * time.Second,
 		FallbackTimeout:        time.Duration(globalSettings.FallbackTimeoutSecs) * time.Second,
 		DisplayName:            sessionParams.AccountMetadata.GetGroupDisplayNameOrDefault(groupID.String()),
+		TeamSize:               teamSize,
 	}, nil
 }
 
@@ -434,6 +462,17 @@ func (p *LobbySessionParameters) BackfillSearchQuery(includeRankRange bool, incl
 		}
 	}
 
+	// Preferred features boost the matches that have them, without excluding those that do not.
+	for _, f := range p.PreferredFeatures {
+		qparts = append(qparts, fmt.Sprintf("label.preferred_features:/.*%s.*/^2", Query.Escape(f)))
+	}
+
+	// Matches that require features can only be expressed as a negative term when the player supports none.
+	// Otherwise, the candidates are filtered with MissingFeatures after listing.
+	if len(p.SupportedFeatures) == 0 {
+		qparts = append(qparts, "-label.features:/.+/")
+	}
+
 	// Do not backfill into the same match
 	if !p.CurrentMatchID.IsNil() {
 		qparts = append(qparts, fmt.Sprintf("-label.id:%s", Query.Escape(p.CurrentMatchID.String())))
@@ -442,10 +481,8 @@ func (p *LobbySessionParameters) BackfillSearchQuery(includeRankRange bool, incl
 	// Ensure the match is not full
 	playerLimit := 0
 	switch p.Mode {
-	case evr.ModeArenaPublic:
-		playerLimit = DefaultPublicArenaTeamSize * 2
-	case evr.ModeCombatPublic:
-		playerLimit = DefaultPublicCombatTeamSize * 2
+	case evr.ModeArenaPublic, evr.ModeCombatPublic:
+		playerLimit = p.TeamSize * 2
 	case evr.ModeSocialPublic:
 		playerLimit = DefaultLobbySize(evr.ModeSocialPublic)
 	}
@@ -465,6 +502,29 @@ func (p *LobbySessionParameters) BackfillSearchQuery(includeRankRange bool, incl
 	return strings.Join(qparts, " ")
 
 }
+
+// MissingFeatures returns the required features that are not in the supported features.
+func MissingFeatures(required, supported []string) []string {
+	missing := make([]string, 0)
+	for _, f := range required {
+		if !slices.Contains(supported, f) {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// MatchedFeatures returns the number of the preferred features that are in the supported features.
+func MatchedFeatures(preferred, supported []string) int {
+	count := 0
+	for _, f := range preferred {
+		if slices.Contains(supported, f) {
+			count++
+		}
+	}
+	return count
+}
+
 func (p *LobbySessionParameters) FromMatchmakerEntry(entry *MatchmakerEntry) {
 
 	// Break out the strings and numerics
@@ -525,6 +585,7 @@ func (p *LobbySessionParameters) MatchmakingParameters(ticketParams *Matchmaking
 		"display_name":              p.DisplayName,
 		"submission_time":           submissionTime,
 		"early_quit_penalty_expiry": p.EarlyQuitPenaltyExpiry.Format(time.RFC3339),
+		"preferred_features":        strings.Join(p.PreferredFeatures, " "),
 	}
 
 	rating := p.GetRating()
@@ -537,6 +598,10 @@ func (p *LobbySessionParameters) MatchmakingParameters(ticketParams *Matchmaking
 		"max_rtt":                   float64(p.MaxServerRTT),
 	}
 
+	if p.TeamSize > 0 {
+		numericProperties["team_size"] = float64(p.TeamSize)
+	}
+
 	qparts := []string{
 		"+properties.game_mode:" + p.Mode.String(),
 		fmt.Sprintf("+properties.group_id:%s", Query.Escape(p.GroupID.String())),
@@ -546,6 +611,11 @@ func (p *LobbySessionParameters) MatchmakingParameters(ticketParams *Matchmaking
 		p.MatchmakingQueryAddon,
 	}
 
+	// Favor players who prefer the same features
+	for _, f := range p.PreferredFeatures {
+		qparts = append(qparts, fmt.Sprintf("properties.preferred_features:/.*%s.*/^2", Query.Escape(f)))
+	}
+
 	// If the user has an early quit penalty, only match them with players who have submitted after now
 	if ticketParams.IncludeEarlyQuitPenalty {
 		// Only match with players who have submitted after this player starts matchmaking
@@ -0,0 +1,25 @@
+package server
+
+import "testing"
+
+func TestMatchedFeatures(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred []string
+		supported []string
+		want      int
+	}{
+		{"none preferred", nil, []string{"cosmetics"}, 0},
+		{"none supported", []string{"cosmetics"}, nil, 0},
+		{"partial", []string{"cosmetics", "emotes"}, []string{"emotes", "replay"}, 1},
+		{"all", []string{"cosmetics", "emotes"}, []string{"emotes", "cosmetics"}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchedFeatures(tt.preferred, tt.supported); got != tt.want {
+				t.Errorf("MatchedFeatures() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package server
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"math/rand"
 	"net"
 	"slices"
+	"time"
 
 	"github.com/heroiclabs/nakama/v3/server/evr"
 	"go.uber.org/zap"
@@ -14,6 +16,9 @@ import (
 const (
 	LatencyHistoryStorageCollection = "LatencyHistory"
 	LatencyHistoryStorageKey        = "store"
+
+	DefaultPingCandidateCount = 16 // The fewest candidates pinged when the count scales with the game servers
+	MaxPingCandidateCount     = 64 // The most candidates pinged when the count scales with the game servers
 )
 
 type endpointCompact struct {
@@ -41,7 +46,7 @@ func (e *endpointCompact) Endpoint() evr.Endpoint {
 		Port:       e.Port()}
 }
 
-func PingGameServers(ctx context.Context, logger *zap.Logger, session Session, db *sql.DB, activeEndpoints []evr.Endpoint) error {
+func PingGameServers(ctx context.Context, logger *zap.Logger, session Session, db *sql.DB, activeEndpoints []evr.Endpoint, candidateCount int, refreshInterval, expiry time.Duration) error {
 	latencyHistory, err := LoadLatencyHistory(ctx, logger, db, session.UserID())
 	if err != nil {
 		return err
@@ -65,11 +70,12 @@ func PingGameServers(ctx context.Context, logger *zap.Logger, session Session, d
 	hostIPs = slices.Compact(hostIPs)
 
 	// Sort the candidates by latency history
-	sortPingCandidatesByLatencyHistory(hostIPs, latencyHistory)
+	sortPingCandidatesByLatencyHistory(hostIPs, latencyHistory, refreshInterval, expiry)
 
-	candidates := make([]evr.Endpoint, 0, len(hostIPs))
+	limit := pingCandidateLimit(candidateCount, len(hostIPs))
+	candidates := make([]evr.Endpoint, 0, limit)
 
-	for i := 0; i < len(hostIPs) && i < 16; i++ {
+	for i := 0; i < len(hostIPs) && i < limit; i++ {
 		candidates = append(candidates, hostMap[hostIPs[i]])
 	}
 
@@ -81,7 +87,18 @@ func PingGameServers(ctx context.Context, logger *zap.Logger, session Session, d
 	return nil
 }
 
-func sortPingCandidatesByLatencyHistory(hostIPs []string, latencyHistory map[string]map[int64]int) {
+// pingCandidateLimit returns the configured candidate count, or, if it is unset, a quarter of the game server hosts (within the default bounds).
+func pingCandidateLimit(configured int, hostCount int) int {
+	if configured > 0 {
+		return configured
+	}
+	return min(max(hostCount/4, DefaultPingCandidateCount), MaxPingCandidateCount)
+}
+
+// sortPingCandidatesByLatencyHistory orders the hosts so that those without a usable RTT are pinged first: hosts with no
+// RTT newer than the expiry, then hosts whose newest RTT is older than the refresh interval, then the rest by the age of
+// their newest RTT (oldest first).
+func sortPingCandidatesByLatencyHistory(hostIPs []string, latencyHistory map[string]map[int64]int, refreshInterval, expiry time.Duration) {
 
 	// Shuffle the candidates
 	for i := len(hostIPs) - 1; i > 0; i-- {
@@ -89,49 +106,36 @@ func sortPingCandidatesByLatencyHistory(hostIPs []string, latencyHistory map[str
 		hostIPs[i], hostIPs[j] = hostIPs[j], hostIPs[i]
 	}
 
-	// Sort the active endpoints
-	slices.SortStableFunc(hostIPs, func(a, b string) int {
-
-		// by whether the endpoint is in the cache
-		var aHistory, bHistory map[int64]int
-		var aInCache, bInCache bool
-		var aOldest, bOldest int64
+	now := time.Now().UTC()
+	expiredBefore := now.Add(-expiry).Unix()
+	staleBefore := now.Add(-refreshInterval).Unix()
 
-		aHistory, aInCache = latencyHistory[a]
-		bHistory, bInCache = latencyHistory[b]
-
-		if !aInCache && bInCache {
-			return -1
-		}
-
-		if aInCache && !bInCache {
-			return 1
-		}
-
-		if !aInCache && !bInCache {
-			return 0
-		}
-
-		for ts := range aHistory {
-			if ts < aOldest {
-				aOldest = ts
+	// The newest RTT timestamp for each host, ignoring expired entries
+	newest := make(map[string]int64, len(hostIPs))
+	for _, ip := range hostIPs {
+		for ts := range latencyHistory[ip] {
+			if ts >= expiredBefore && ts > newest[ip] {
+				newest[ip] = ts
 			}
 		}
+	}
 
-		for ts := range bHistory {
-			if ts < bOldest {
-				bOldest = ts
-			}
-		}
-
-		if aOldest < bOldest {
-			return -1
-		}
-
-		if aOldest > bOldest {
+	rank := func(ip string) int {
+		switch ts, ok := newest[ip]; {
+		case !ok:
+			return 0
+		case ts < staleBefore:
 			return 1
+		default:
+			return 2
 		}
+	}
 
-		return 0
+	// Sort the active endpoints
+	slices.SortStableFunc(hostIPs, func(a, b string) int {
+		if c := cmp.Compare(rank(a), rank(b)); c != 0 {
+			return c
+		}
+		return cmp.Compare(newest[a], newest[b])
 	})
 }
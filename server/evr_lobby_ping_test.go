@@ -0,0 +1,47 @@
+package server
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPingCandidateLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured int
+		hostCount  int
+		want       int
+	}{
+		{"configured", 8, 200, 8},
+		{"few servers", 0, 10, DefaultPingCandidateCount},
+		{"scales with servers", 0, 120, 30},
+		{"capped", 0, 1000, MaxPingCandidateCount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pingCandidateLimit(tt.configured, tt.hostCount); got != tt.want {
+				t.Errorf("pingCandidateLimit() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortPingCandidatesByLatencyHistory(t *testing.T) {
+	now := time.Now().UTC()
+	history := map[string]map[int64]int{
+		"fresh-old": {now.Add(-30 * time.Minute).Unix(): 40},
+		"fresh-new": {now.Add(-5 * time.Minute).Unix(): 40},
+		"stale":     {now.Add(-2 * time.Hour).Unix(): 40},
+		"expired":   {now.Add(-48 * time.Hour).Unix(): 40},
+	}
+
+	hostIPs := []string{"fresh-new", "stale", "fresh-old", "expired"}
+	sortPingCandidatesByLatencyHistory(hostIPs, history, time.Hour, 24*time.Hour)
+
+	want := []string{"expired", "stale", "fresh-old", "fresh-new"}
+	if !slices.Equal(hostIPs, want) {
+		t.Errorf("sortPingCandidatesByLatencyHistory() = %v, want %v", hostIPs, want)
+	}
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/heroiclabs/nakama/v3/server/evr"
+	"go.uber.org/zap"
+)
+
+const (
+	QueueEstimateUpdateInterval = 20 * time.Second
+	queueEstimateSmoothing      = 0.2 // Weight of the newest sample in the moving average
+)
+
+// matchmakingQueueEstimator tracks a moving average of how long matched players waited, per group and mode.
+type matchmakingQueueEstimator struct {
+	sync.Mutex
+	averages map[string]time.Duration
+}
+
+func newMatchmakingQueueEstimator() *matchmakingQueueEstimator {
+	return &matchmakingQueueEstimator{
+		averages: make(map[string]time.Duration),
+	}
+}
+
+func (e *matchmakingQueueEstimator) key(groupID string, mode evr.Symbol) string {
+	return groupID + ":" + mode.String()
+}
+
+func (e *matchmakingQueueEstimator) Record(groupID string, mode evr.Symbol, d time.Duration) {
+	e.Lock()
+	defer e.Unlock()
+	k := e.key(groupID, mode)
+	if avg, ok := e.averages[k]; ok {
+		e.averages[k] = time.Duration(float64(avg)*(1-queueEstimateSmoothing) + float64(d)*queueEstimateSmoothing)
+	} else {
+		e.averages[k] = d
+	}
+}
+
+// Estimate returns the average matched wait, if any have been recorded.
+func (e *matchmakingQueueEstimator) Estimate(groupID string, mode evr.Symbol) (time.Duration, bool) {
+	e.Lock()
+	defer e.Unlock()
+	avg, ok := e.averages[e.key(groupID, mode)]
+	return avg, ok
+}
+
+// queuePosition counts the players searching for the same mode in the matchmaking stream, and how many started before this player.
+func (p *EvrPipeline) queuePosition(lobbyParams *LobbySessionParameters) (ahead int, total int) {
+	for _, presence := range p.tracker.ListByStream(lobbyParams.MatchmakingStream(), true, true) {
+		status := struct {
+			Mode                 evr.Symbol `json:"mode"`
+			MatchmakingTimestamp time.Time  `json:"matchmaking_timestamp"`
+		}{}
+		if err := json.Unmarshal([]byte(presence.Meta.Status), &status); err != nil {
+			continue
+		}
+		if status.Mode != lobbyParams.Mode {
+			continue
+		}
+		total++
+		if presence.UserID != lobbyParams.UserID && status.MatchmakingTimestamp.Before(lobbyParams.MatchmakingTimestamp) {
+			ahead++
+		}
+	}
+	return ahead, total
+}
+
+// sendQueueEstimates DMs the player their rough queue position and wait time, updating the message until matchmaking ends.
+func (p *EvrPipeline) sendQueueEstimates(ctx context.Context, logger *zap.Logger, lobbyParams *LobbySessionParameters) {
+	if !p.appBot.discordEnabled() || lobbyParams.DiscordID == "" {
+		return
+	}
+	dg := p.appBot.dg
+
+	var channelID, messageID string
+
+	ticker := time.NewTicker(QueueEstimateUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if messageID != "" {
+				if _, err := dg.ChannelMessageEdit(channelID, messageID, fmt.Sprintf("Matchmaking for `%s` has ended.", lobbyParams.Mode.String())); err != nil {
+					logger.Debug("Failed to update queue estimate message", zap.Error(err))
+				}
+			}
+			return
+		case <-ticker.C:
+		}
+
+		ahead, total := p.queuePosition(lobbyParams)
+		waited := time.Since(lobbyParams.MatchmakingTimestamp).Round(time.Second)
+
+		content := fmt.Sprintf("Matchmaking for `%s`: position %d of %d searching, waited %s.", lobbyParams.Mode.String(), ahead+1, max(total, ahead+1), waited)
+		if avg, ok := p.queueEstimator.Estimate(lobbyParams.GroupID.String(), lobbyParams.Mode); ok {
+			if remaining := avg - waited; remaining > 0 {
+				content += fmt.Sprintf(" Estimated wait: ~%s.", remaining.Round(time.Second))
+			} else {
+				content += " Estimated wait: any moment now."
+			}
+		}
+
+		if messageID != "" {
+			if _, err := dg.ChannelMessageEdit(channelID, messageID, content); err == nil {
+				continue
+			}
+		}
+
+		if channelID == "" {
+			channel, err := dg.UserChannelCreate(lobbyParams.DiscordID)
+			if err != nil {
+				logger.Warn("Failed to create DM channel for queue estimate", zap.Error(err))
+				return
+			}
+			channelID = channel.ID
+		}
+
+		msg, err := dg.ChannelMessageSend(channelID, content)
+		if err != nil {
+			logger.Warn("Failed to send queue estimate", zap.Error(err))
+			return
+		}
+		messageID = msg.ID
+	}
+}
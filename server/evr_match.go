@@ -8,14 +8,15 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
-	"reflect"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/heroiclabs/nakama-common/runtime"
 	"github.com/heroiclabs/nakama/v3/server/evr"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -32,6 +33,9 @@ const (
 
 	DefaultPublicArenaTeamSize  = 4
 	DefaultPublicCombatTeamSize = 5
+	DefaultMaxTeamSize          = 5 // The largest team size allowed without an override
+	MinPublicTeamSize           = 2 // The smallest team size a guild may set for its public matches
+	MaxPublicTeamSize           = 5 // The largest team size a guild may set for its public matches
 
 	// Defaults for public arena matches
 	RoundDuration              = 300 * time.Second
@@ -69,6 +73,52 @@ const (
 	OpCodeGameServerLobbyStatus
 )
 
+const UnexpectedOpCodeLogInterval = 30 * time.Second // The least time between unexpected opcode logs, per match
+
+var (
+	// The opcodes the match loop accepts for each mode. Modes that are not listed (e.g. an unassigned lobby) use the default.
+	defaultMatchOpCodes = []int64{OpCodeGameServerLobbyStatus}
+	matchOpCodesByMode  = map[evr.Symbol][]int64{
+		evr.ModeArenaPublic:          {OpCodeMatchGameStateUpdate, OpCodeGameServerLobbyStatus},
+		evr.ModeArenaPrivate:         {OpCodeMatchGameStateUpdate, OpCodeGameServerLobbyStatus},
+		evr.ModeArenaTournment:       {OpCodeMatchGameStateUpdate, OpCodeGameServerLobbyStatus},
+		evr.ModeArenaPublicAI:        {OpCodeMatchGameStateUpdate, OpCodeGameServerLobbyStatus},
+		evr.ModeArenaFreezeTag:       {OpCodeMatchGameStateUpdate, OpCodeGameServerLobbyStatus},
+		evr.ModeArenaTutorial:        {OpCodeMatchGameStateUpdate, OpCodeGameServerLobbyStatus},
+		evr.ModeCombatPublic:         {OpCodeMatchGameStateUpdate, OpCodeGameServerLobbyStatus},
+		evr.ModeCombatPrivate:        {OpCodeMatchGameStateUpdate, OpCodeGameServerLobbyStatus},
+		evr.ModeEchoCombatTournament: {OpCodeMatchGameStateUpdate, OpCodeGameServerLobbyStatus},
+		evr.ModeSocialPublic:         {OpCodeMatchGameStateUpdate, OpCodeGameServerLobbyStatus},
+		evr.ModeSocialPrivate:        {OpCodeMatchGameStateUpdate, OpCodeGameServerLobbyStatus},
+		evr.ModeSocialNPE:            {OpCodeMatchGameStateUpdate, OpCodeGameServerLobbyStatus},
+	}
+
+	// The names of the match opcodes, used to tag metrics. Any other opcode is tagged "unknown".
+	matchOpCodeNames = map[int64]string{
+		OpCodeBroadcasterDisconnected: "broadcaster_disconnected",
+		OpCodeEVRPacketData:           "evr_packet_data",
+		OpCodeMatchGameStateUpdate:    "game_state_update",
+		OpCodeGameServerLobbyStatus:   "lobby_status",
+	}
+)
+
+// matchOpCodeName returns the metric tag for the opcode.
+func matchOpCodeName(opCode int64) string {
+	if name, ok := matchOpCodeNames[opCode]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// MatchOpCodeAllowed returns true if the match loop for the mode accepts the opcode.
+func MatchOpCodeAllowed(mode evr.Symbol, opCode int64) bool {
+	opCodes, ok := matchOpCodesByMode[mode]
+	if !ok {
+		opCodes = defaultMatchOpCodes
+	}
+	return slices.Contains(opCodes, opCode)
+}
+
 type MatchStatGroup string
 type MatchLevelSelection string
 
@@ -122,22 +172,34 @@ type MatchSettings struct {
 	SpawnedBy           string
 	GroupID             uuid.UUID
 	RequiredFeatures    []string
+	PreferredFeatures   []string // Features that are favored in matchmaking, but not required to join
 	TeamAlignments      map[string]int
 	Reservations        []*EvrMatchPresence
 	ReservationLifetime time.Duration
+	MaxRounds           int // The number of rounds after which the match ends (private matches only)
 }
 
 // This is the match handler for all matches.
 // There always is one per broadcaster.
 // The match is spawned and managed directly by nakama.
 // The match can only be communicated with through MatchSignal() and MatchData messages.
-type EvrMatch struct{}
+type EvrMatch struct {
+	broadcasterJoinTimeoutSecs int                // How long to wait for the broadcaster to join before shutting down
+	maxRoundsByMode            map[evr.Symbol]int // The number of rounds after which matches end, by mode
+}
 
 // NewEvrMatch is called by the match handler when creating the match.
 func NewEvrMatch(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) (m runtime.Match, err error) {
 	return &EvrMatch{}, nil
 }
 
+func (m *EvrMatch) broadcasterJoinTimeout() int {
+	if m.broadcasterJoinTimeoutSecs <= 0 {
+		return BroadcasterJoinTimeoutSecs
+	}
+	return m.broadcasterJoinTimeoutSecs
+}
+
 // MatchIDFromContext is a helper function to extract the match id from the context.
 func MatchIDFromContext(ctx context.Context) MatchID {
 	matchIDStr, ok := ctx.Value(runtime.RUNTIME_CTX_MATCH_ID).(string)
@@ -149,7 +211,10 @@ func MatchIDFromContext(ctx context.Context) MatchID {
 }
 
 const (
-	BroadcasterJoinTimeoutSecs = 45
+	BroadcasterJoinTimeoutSecs = 60
+	MaxRoundsShutdownGraceSecs = 30 // How long players have to see the results after the last round
+	PresenceReconcileSecs      = 30 // How often the presences are checked against the match stream
+	PresenceReconcileGraceSecs = 30 // How long a new presence has to join the match stream before it is considered stale
 )
 
 // MatchInit is called when the match is created.
@@ -293,6 +358,18 @@ func (m *EvrMatch) MatchJoinAttempt(ctx context.Context, logger runtime.Logger,
 		}
 	}
 
+	// Reservations may only be made for members of the primary entrant's party.
+	for _, r := range meta.Reservations {
+		if meta.Presence.PartyID.IsNil() || r.PartyID != meta.Presence.PartyID {
+			logger.WithFields(map[string]any{
+				"party_id":             meta.Presence.PartyID.String(),
+				"reservation_uid":      r.GetUserId(),
+				"reservation_party_id": r.PartyID.String(),
+			}).Warn("Rejecting reservation for an entrant outside of the party.")
+			return state, false, ErrJoinRejectReasonPartyMembersMustHaveRoles.Error()
+		}
+	}
+
 	// Remove any reservations of existing players (i.e. party members already in the match)
 	for i := 0; i < len(meta.Reservations); i++ {
 		s := meta.Reservations[i].GetSessionId()
@@ -308,12 +385,17 @@ func (m *EvrMatch) MatchJoinAttempt(ctx context.Context, logger runtime.Logger,
 			delete(state.reservationMap, s)
 			state.rebuildCache()
 		}
+	}
 
-		// Ensure the player has the required features
-		for _, f := range state.RequiredFeatures {
-			if !slices.Contains(meta.Reservations[i].SupportedFeatures, f) {
-				return state, false, ErrJoinRejectReasonFeatureMismatch.Error()
-			}
+	// Ensure all entrants have the required features (preferred features are not required to join)
+	for _, p := range meta.Presences() {
+		if missing := MissingFeatures(state.RequiredFeatures, p.SupportedFeatures); len(missing) > 0 {
+			logger.WithFields(map[string]interface{}{
+				"uid":              p.GetUserId(),
+				"sid":              p.GetSessionId(),
+				"missing_features": missing,
+			}).Warn("Entrant does not support required features.")
+			return state, false, fmt.Sprintf("%s: %s", ErrJoinRejectReasonFeatureMismatch.Error(), strings.Join(missing, ", "))
 		}
 	}
 
@@ -373,7 +455,7 @@ func (m *EvrMatch) MatchJoinAttempt(ctx context.Context, logger runtime.Logger,
 		"level":    state.Level.String(),
 		"type":     state.LobbyType.String(),
 		"role":     fmt.Sprintf("%d", meta.Presence.RoleAlignment),
-		"group_id": state.GetGroupID().String(),
+		"group_id": state.groupIDTag(),
 	}
 	if nk != nil { // for testing
 		nk.MetricsCounterAdd("match_entrant_join_count", metricsTags, 1)
@@ -460,7 +542,7 @@ func (m *EvrMatch) MatchJoin(ctx context.Context, logger runtime.Logger, db *sql
 				"level":    state.Level.String(),
 				"type":     state.LobbyType.String(),
 				"role":     fmt.Sprintf("%d", mp.RoleAlignment),
-				"group_id": state.GetGroupID().String(),
+				"group_id": state.groupIDTag(),
 			}
 			nk.MetricsCounterAdd("match_entrant_join_count", tags, 1)
 			nk.MetricsTimerRecord("match_player_join_duration", tags, time.Since(state.joinTimestamps[p.GetSessionId()]))
@@ -511,7 +593,7 @@ func (m *EvrMatch) MatchLeave(ctx context.Context, logger runtime.Logger, db *sq
 				"level":    state.Level.String(),
 				"type":     state.LobbyType.String(),
 				"role":     fmt.Sprintf("%d", mp.RoleAlignment),
-				"group_id": state.GetGroupID().String(),
+				"group_id": state.groupIDTag(),
 			}
 			msg := "Player removed from game server."
 
@@ -562,9 +644,15 @@ func (m *EvrMatch) MatchLeave(ctx context.Context, logger runtime.Logger, db *sq
 		}
 	}
 	if len(state.presenceMap) == 0 {
-		// Lock the match
-		state.Open = false
-		logger.Debug("Match is empty. Closing it.")
+		if state.ReusableWhenEmpty() {
+			// Keep the lobby available for matchmaking.
+			state.Open = true
+			logger.Debug("Match is empty, but reusable. Keeping it open.")
+		} else {
+			// Lock the match
+			state.Open = false
+			logger.Debug("Match is empty. Closing it.")
+		}
 	}
 
 	// Update the label that includes the new player list.
@@ -616,6 +704,11 @@ func (m *EvrMatch) MatchLoop(ctx context.Context, logger runtime.Logger, db *sql
 
 	// Handle the messages, one by one
 	for _, in := range messages {
+		if !MatchOpCodeAllowed(state.Mode, in.GetOpCode()) {
+			m.dropUnexpectedOpCode(logger, nk, state, in)
+			continue
+		}
+
 		switch in.GetOpCode() {
 		case OpCodeMatchGameStateUpdate:
 
@@ -635,6 +728,9 @@ func (m *EvrMatch) MatchLoop(ctx context.Context, logger runtime.Logger, db *sql
 				}
 
 				if state.GameState.RoundClock != nil {
+					if gs.RecordRoundEnd(u.CurrentGameClock, time.Now().UTC()) {
+						nk.MetricsCounterAdd("match_round_complete_count", state.MetricsTags(), 1)
+					}
 					if u.CurrentGameClock != 0 {
 						if u.PauseDuration != 0 {
 							gs.RoundClock.UpdateWithPause(u.CurrentGameClock, u.PauseDuration)
@@ -666,46 +762,14 @@ func (m *EvrMatch) MatchLoop(ctx context.Context, logger runtime.Logger, db *sql
 				presence.Ping = int(s.Ping)
 				presence.RoleAlignment = int(s.TeamIndex)
 			}
-
-		default:
-			typ, found := evr.SymbolTypes[uint64(in.GetOpCode())]
-			if !found {
-				logger.Error("Unknown opcode: %v", in.GetOpCode())
-				continue
-			}
-
-			logger.Debug("Received match message %T(%s) from %s (%s)", typ, string(in.GetData()), in.GetUsername(), in.GetSessionId())
-			// Unmarshal the message into an interface, then switch on the type.
-			msg := reflect.New(reflect.TypeOf(typ).Elem()).Interface().(evr.Message)
-			if err := json.Unmarshal(in.GetData(), &msg); err != nil {
-				logger.Error("Failed to unmarshal message: %v", err)
-			}
-
-			var messageFn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, state *MatchLabel, in runtime.MatchData, msg evr.Message) (*MatchLabel, error)
-
-			// Switch on the message type. This is where the match logic is handled.
-			switch msg := msg.(type) {
-			default:
-				logger.Warn("Unknown message type: %T", msg)
-			}
-
-			// Time the execution
-			start := time.Now()
-			// Execute the message function
-			if messageFn != nil {
-				state, err = messageFn(ctx, logger, db, nk, dispatcher, state, in, msg)
-				if err != nil {
-					logger.Error("match pipeline: %v", err)
-				}
-			}
-			logger.Debug("Message %T took %dms", msg, time.Since(start)/time.Millisecond)
 		}
 	}
 
 	if state.server == nil {
 		state.emptyTicks++
-		if state.emptyTicks > 60*state.tickRate {
-			logger.Warn("Match has been empty for too long. Shutting down.")
+		if state.emptyTicks > int64(m.broadcasterJoinTimeout())*state.tickRate {
+			logger.WithField("timeout_secs", m.broadcasterJoinTimeout()).Warn("Broadcaster did not join the match in time. Shutting down.")
+			nk.MetricsCounterAdd("match_broadcaster_join_timeout_count", state.MetricsTags(), 1)
 			return m.MatchShutdown(ctx, logger, db, nk, dispatcher, tick, state, 20)
 		}
 	} else if state.emptyTicks > 0 {
@@ -761,6 +825,19 @@ func (m *EvrMatch) MatchLoop(ctx context.Context, logger runtime.Logger, db *sql
 	if tick%state.tickRate == 0 && state.GameState != nil {
 		state.GameState.Update(state.goals)
 		updateLabel = true
+
+		if state.RoundLimitReached() {
+			logger.WithField("round_results", state.GameState.RoundResults).Info("Round limit reached, shutting down the match.")
+			nk.MetricsCounterAdd("match_round_limit_count", state.MetricsTags(), 1)
+			return m.MatchShutdown(ctx, logger, db, nk, dispatcher, tick, state, MaxRoundsShutdownGraceSecs)
+		}
+	}
+
+	// Remove any presences that have drifted from the match stream.
+	if tick%(PresenceReconcileSecs*state.tickRate) == 0 && len(state.presenceMap) > 0 {
+		if m.reconcilePresences(logger, nk, state) > 0 {
+			updateLabel = true
+		}
 	}
 
 	// If the arena score is close, then lock later than usual.
@@ -855,6 +932,40 @@ func (m *EvrMatch) MatchShutdown(ctx context.Context, logger runtime.Logger, db
 	return state
 }
 
+// reservationsFit returns an error if the reservations cannot all join the match.
+func reservationsFit(state *MatchLabel, reservations []*EvrMatchPresence) error {
+	if len(reservations) > state.MaxSize {
+		return fmt.Errorf("%d reservations exceed the match size of %d", len(reservations), state.MaxSize)
+	}
+
+	players, nonPlayers := 0, 0
+	teams := make(map[int]int, 2)
+	for _, r := range reservations {
+		if r.IsPlayer() {
+			players++
+			teams[r.RoleAlignment]++
+		} else {
+			nonPlayers++
+		}
+	}
+
+	if players > state.PlayerLimit {
+		return fmt.Errorf("%d player reservations exceed the player limit of %d", players, state.PlayerLimit)
+	}
+
+	if nonPlayers > state.MaxSize-state.PlayerLimit {
+		return fmt.Errorf("%d spectator reservations exceed the spectator limit of %d", nonPlayers, state.MaxSize-state.PlayerLimit)
+	}
+
+	for _, team := range []int{evr.TeamBlue, evr.TeamOrange} {
+		if teams[team] > state.TeamSize {
+			return fmt.Errorf("%d reservations exceed the team size of %d", teams[team], state.TeamSize)
+		}
+	}
+
+	return nil
+}
+
 // MatchSignal is called when a signal is sent into the match.
 func (m *EvrMatch) MatchSignal(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state_ interface{}, data string) (interface{}, string) {
 	state, ok := state_.(*MatchLabel)
@@ -870,6 +981,11 @@ func (m *EvrMatch) MatchSignal(ctx context.Context, logger runtime.Logger, db *s
 		return state, SignalResponse{Message: fmt.Sprintf("failed to unmarshal signal: %v", err)}.String()
 	}
 
+	if signal.CorrelationID != "" {
+		logger = logger.WithField("correlation_id", signal.CorrelationID)
+	}
+	logger.Debug("Received match signal %d.", signal.OpCode)
+
 	switch signal.OpCode {
 	case SignalShutdown:
 
@@ -923,6 +1039,18 @@ func (m *EvrMatch) MatchSignal(ctx context.Context, logger runtime.Logger, db *s
 		}
 		return state, SignalResponse{Success: true, Payload: string(jsonData)}.String()
 
+	case SignalGetStats:
+		// Return the statistics of the match so far.
+		presences := make([]*EvrMatchPresence, 0, len(state.presenceMap))
+		for _, p := range state.presenceMap {
+			presences = append(presences, p)
+		}
+		jsonData, err := json.Marshal(NewMatchLiveStats(state.GameState, state.goals, presences))
+		if err != nil {
+			return state, fmt.Sprintf("failed to marshal stats: %v", err)
+		}
+		return state, SignalResponse{Success: true, Payload: string(jsonData)}.String()
+
 	case SignalPrepareSession:
 
 		// if the match is already started, return an error.
@@ -943,9 +1071,26 @@ func (m *EvrMatch) MatchSignal(ctx context.Context, logger runtime.Logger, db *s
 			}
 		}
 
-		if ok, err := CheckSystemGroupMembership(ctx, db, settings.SpawnedBy, GroupGlobalDevelopers); err != nil {
+		// Preferred features the game server does not support are dropped, rather than rejecting the settings.
+		preferredFeatures := make([]string, 0, len(settings.PreferredFeatures))
+		for _, f := range settings.PreferredFeatures {
+			if slices.Contains(state.Broadcaster.Features, f) {
+				preferredFeatures = append(preferredFeatures, f)
+			}
+		}
+
+		// Public matches must belong to a group; only unassigned lobbies may be group-less.
+		switch settings.Mode {
+		case evr.ModeArenaPublic, evr.ModeCombatPublic, evr.ModeSocialPublic:
+			if settings.GroupID.IsNil() {
+				return state, SignalResponse{Message: "public matches require a group"}.String()
+			}
+		}
+
+		isDeveloper, err := CheckSystemGroupMembership(ctx, db, settings.SpawnedBy, GroupGlobalDevelopers)
+		if err != nil {
 			return state, SignalResponse{Message: fmt.Sprintf("failed to check group membership: %v", err)}.String()
-		} else if !ok {
+		} else if !isDeveloper {
 			if levels, ok := evr.LevelsByMode[settings.Mode]; !ok {
 				return state, SignalResponse{Message: fmt.Sprintf("invalid mode: %v", settings.Mode)}.String()
 			} else {
@@ -960,9 +1105,13 @@ func (m *EvrMatch) MatchSignal(ctx context.Context, logger runtime.Logger, db *s
 			}
 		}
 
+		// Keep the unprepared state, in case the settings are rejected after they have been applied.
+		unprepared := *state
+
 		state.Mode = settings.Mode
 		state.Level = settings.Level
 		state.RequiredFeatures = settings.RequiredFeatures
+		state.PreferredFeatures = preferredFeatures
 		state.SessionSettings = evr.NewSessionSettings(strconv.FormatUint(PcvrAppId, 10), state.Mode, state.Level, state.RequiredFeatures)
 		state.GroupID = &settings.GroupID
 
@@ -1018,11 +1167,53 @@ func (m *EvrMatch) MatchSignal(ctx context.Context, logger runtime.Logger, db *s
 			state.PlayerLimit = state.MaxSize
 		}
 
-		if settings.TeamSize > 0 && settings.TeamSize <= 5 {
+		// Public arena and combat matches use the guild's team size, if it has been set.
+		if state.Mode == evr.ModeArenaPublic || state.Mode == evr.ModeCombatPublic {
+			if md, err := GetGuildGroupMetadata(ctx, db, settings.GroupID.String()); err != nil {
+				logger.Warn("Failed to get guild group metadata: %v", err)
+			} else {
+				state.TeamSize = md.PublicTeamSize(state.Mode)
+				state.PlayerLimit = min(state.TeamSize*2, state.MaxSize)
+			}
+		}
+
+		// Private matches may use larger teams for community scrim formats, if allowed.
+		maxTeamSize := DefaultMaxTeamSize
+		if state.LobbyType == PrivateLobby && settings.TeamSize > maxTeamSize {
+			if isDeveloper {
+				maxTeamSize = state.MaxSize / 2
+			} else if md, err := GetGuildGroupMetadata(ctx, db, settings.GroupID.String()); err != nil {
+				logger.Warn("Failed to get guild group metadata: %v", err)
+			} else if md.AllowLargePrivateTeams {
+				maxTeamSize = state.MaxSize / 2
+			}
+		}
+
+		if settings.TeamSize > 0 && settings.TeamSize <= maxTeamSize {
 			state.TeamSize = settings.TeamSize
 			state.PlayerLimit = min(state.TeamSize*2, state.MaxSize)
 		}
 
+		// Public arena matches use the guild's round timing, if it has been tuned.
+		state.pacing = nil
+		if state.Mode == evr.ModeArenaPublic {
+			if md, err := GetGuildGroupMetadata(ctx, db, settings.GroupID.String()); err != nil {
+				logger.Warn("Failed to get guild group metadata: %v", err)
+			} else if md.MatchPacing != nil {
+				if err := md.MatchPacing.Validate(); err != nil {
+					logger.Warn("Ignoring invalid match pacing for group %s: %v", settings.GroupID.String(), err)
+				} else {
+					state.pacing = md.MatchPacing
+				}
+			}
+		}
+
+		// Public matches use the configured round limit; private matches may set their own.
+		state.MaxRounds = m.maxRoundsByMode[state.Mode]
+		if state.LobbyType == PrivateLobby && settings.MaxRounds > 0 {
+			state.MaxRounds = settings.MaxRounds
+		}
+
 		state.TeamAlignments = make(map[string]int, state.MaxSize)
 
 		for userID, role := range settings.TeamAlignments {
@@ -1031,6 +1222,11 @@ func (m *EvrMatch) MatchSignal(ctx context.Context, logger runtime.Logger, db *s
 			}
 		}
 
+		if err := reservationsFit(state, settings.Reservations); err != nil {
+			*state = unprepared
+			return state, SignalResponse{Message: fmt.Sprintf("failed to reserve slots: %v", err)}.String()
+		}
+
 		for _, e := range settings.Reservations {
 			expiry := time.Now().Add(settings.ReservationLifetime)
 			state.reservationMap[e.GetSessionId()] = &slotReservation{
@@ -1047,6 +1243,35 @@ func (m *EvrMatch) MatchSignal(ctx context.Context, logger runtime.Logger, db *s
 			return state, SignalResponse{Message: "failed to start session: already started"}.String()
 		}
 
+	case SignalEditLabel:
+
+		var data SignalEditLabelPayload
+
+		if err := json.Unmarshal(signal.Payload, &data); err != nil {
+			return state, SignalResponse{Message: fmt.Sprintf("failed to unmarshal edit label payload: %v", err)}.String()
+		}
+		if data.Regions == nil && data.Tags == nil {
+			return state, SignalResponse{Message: "no label fields to edit"}.String()
+		}
+
+		if data.Regions != nil {
+			state.Broadcaster.Regions = *data.Regions
+		}
+		if data.Tags != nil {
+			state.Broadcaster.Tags = *data.Tags
+		}
+		logger.WithFields(map[string]any{
+			"regions": state.Broadcaster.Regions,
+			"tags":    state.Broadcaster.Tags,
+		}).Info("Edited match label.")
+
+		// Editing the label does not prepare the match, so it is not counted as one.
+		if err := m.updateLabel(dispatcher, state); err != nil {
+			logger.Error("failed to update label: %v", err)
+			return state, SignalResponse{Message: fmt.Sprintf("failed to update label: %v", err)}.String()
+		}
+		return state, SignalResponse{Success: true, Payload: state.GetLabel()}.String()
+
 	case SignalLockSession:
 		logger.Debug("Locking session")
 		state.LockedAt = time.Now().UTC()
@@ -1078,6 +1303,25 @@ func (m *EvrMatch) MatchSignal(ctx context.Context, logger runtime.Logger, db *s
 
 }
 
+// dropUnexpectedOpCode counts a message the match loop does not handle, and logs it at most once per interval.
+func (m *EvrMatch) dropUnexpectedOpCode(logger runtime.Logger, nk runtime.NakamaModule, state *MatchLabel, in runtime.MatchData) {
+	tags := state.MetricsTags()
+	tags["opcode"] = matchOpCodeName(in.GetOpCode())
+	nk.MetricsCounterAdd("match_unexpected_opcode_count", tags, 1)
+
+	if state.opCodeLogLimiter == nil {
+		state.opCodeLogLimiter = rate.NewLimiter(rate.Every(UnexpectedOpCodeLogInterval), 1)
+	}
+	if state.opCodeLogLimiter.Allow() {
+		logger.WithFields(map[string]any{
+			"opcode":     in.GetOpCode(),
+			"user_id":    in.GetUserId(),
+			"session_id": in.GetSessionId(),
+			"size":       len(in.GetData()),
+		}).Warn("Dropped unexpected match message opcode.")
+	}
+}
+
 func (m *EvrMatch) MatchStart(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, state *MatchLabel) (*MatchLabel, error) {
 	groupID := uuid.Nil
 	if state.GroupID != nil {
@@ -1087,7 +1331,7 @@ func (m *EvrMatch) MatchStart(ctx context.Context, logger runtime.Logger, nk run
 	switch state.Mode {
 	case evr.ModeArenaPublic:
 		state.GameState = &GameState{
-			RoundClock: NewRoundClock(RoundDuration, time.Now().Add(PublicMatchWaitTime)),
+			RoundClock: NewRoundClock(state.pacing.RoundDuration(), time.Now().Add(state.pacing.PublicMatchWaitTime())),
 		}
 	case evr.ModeArenaPrivate:
 		state.GameState = &GameState{
@@ -1098,6 +1342,7 @@ func (m *EvrMatch) MatchStart(ctx context.Context, logger runtime.Logger, nk run
 	state.StartTime = time.Now().UTC()
 	entrants := make([]evr.XPID, 0)
 	message := evr.NewGameServerSessionStart(state.ID.UUID, groupID, uint8(state.MaxSize), uint8(state.LobbyType), state.Broadcaster.AppId, state.Mode, state.Level, state.RequiredFeatures, entrants)
+	message.Settings.Pacing = state.pacing.SessionPacing()
 
 	logger.WithField("message", message).Info("Starting session.")
 
@@ -1131,6 +1376,58 @@ func (m *EvrMatch) dispatchMessages(_ context.Context, logger runtime.Logger, di
 	return nil
 }
 
+// reconcilePresences removes the presences (and duplicate entries for a player) that are no longer in the match stream.
+// Presences that have not had time to join the stream are left alone. It returns the number of presences removed.
+func (m *EvrMatch) reconcilePresences(logger runtime.Logger, nk runtime.NakamaModule, state *MatchLabel) int {
+	streamPresences, err := nk.StreamUserList(StreamModeMatchAuthoritative, state.ID.UUID.String(), "", state.ID.Node, true, true)
+	if err != nil {
+		logger.Warn("Failed to list match stream presences: %v", err)
+		return 0
+	}
+
+	inStream := make(map[string]struct{}, len(streamPresences))
+	for _, p := range streamPresences {
+		inStream[p.GetSessionId()] = struct{}{}
+	}
+
+	removed := 0
+	for sessionID, mp := range state.presenceMap {
+		if _, ok := inStream[sessionID]; ok {
+			continue
+		}
+		if time.Since(state.joinTimestamps[sessionID]) < PresenceReconcileGraceSecs*time.Second {
+			continue
+		}
+
+		logger.WithFields(map[string]any{
+			"username":   mp.GetUsername(),
+			"uid":        mp.GetUserId(),
+			"session_id": sessionID,
+		}).Warn("Removing presence that is not in the match stream.")
+
+		delete(state.presenceMap, sessionID)
+		delete(state.joinTimestamps, sessionID)
+		delete(state.joinTimeMilliseconds, sessionID)
+		if p, ok := state.presenceByXPID[mp.XPID]; ok && p == mp {
+			delete(state.presenceByXPID, mp.XPID)
+		}
+		removed++
+	}
+
+	// Drop any XPID entries left pointing at a removed presence.
+	for xpid, mp := range state.presenceByXPID {
+		if p, ok := state.presenceMap[mp.GetSessionId()]; !ok || p != mp {
+			delete(state.presenceByXPID, xpid)
+			removed++
+		}
+	}
+
+	if removed > 0 {
+		nk.MetricsCounterAdd("match_presence_drift_count", state.MetricsTags(), int64(removed))
+	}
+	return removed
+}
+
 func (m *EvrMatch) updateLabel(dispatcher runtime.MatchDispatcher, state *MatchLabel) error {
 	state.rebuildCache()
 	if dispatcher != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/gofrs/uuid/v5"
 	"github.com/heroiclabs/nakama-common/runtime"
 	"github.com/heroiclabs/nakama/v3/server/evr"
+	"golang.org/x/time/rate"
 )
 
 type slotReservation struct {
@@ -30,10 +31,12 @@ type MatchLabel struct {
 	RankPercentile float64      `json:"rank_percentile,omitempty"` // The average percentile rank of the players in the match.
 	GameState      *GameState   `json:"game_state,omitempty"`      // The game state for the match.
 
-	TeamSize         int      `json:"team_size,omitempty"`    // The size of each team in arena/combat (either 4 or 5)
-	MaxSize          int      `json:"limit,omitempty"`        // The total lobby size limit (players + specs)
-	PlayerLimit      int      `json:"player_limit,omitempty"` // The number of players in the match (not including spectators).
-	RequiredFeatures []string `json:"features,omitempty"`     // The required features for the match. map[feature][hmdtype]isRequired
+	TeamSize          int      `json:"team_size,omitempty"`          // The size of each team in arena/combat (either 4 or 5)
+	MaxSize           int      `json:"limit,omitempty"`              // The total lobby size limit (players + specs)
+	PlayerLimit       int      `json:"player_limit,omitempty"`       // The number of players in the match (not including spectators).
+	RequiredFeatures  []string `json:"features,omitempty"`           // The required features for the match. map[feature][hmdtype]isRequired
+	PreferredFeatures []string `json:"preferred_features,omitempty"` // The preferred features for the match; entrants are not required to support them.
+	MaxRounds         int      `json:"max_rounds,omitempty"`         // The number of rounds after which the match ends (0 is unlimited)
 
 	GroupID         *uuid.UUID                `json:"group_id,omitempty"`         // The channel id of the broadcaster. (EVR)
 	SpawnedBy       string                    `json:"spawned_by,omitempty"`       // The userId of the player that spawned this match.
@@ -56,6 +59,8 @@ type MatchLabel struct {
 	emptyTicks           int64                // The number of ticks the match has been empty.
 	terminateTick        int64                // The tick count at which the match will be shut down.
 	goals                []*MatchGoal         // The goals scored in the match.
+	pacing               *MatchPacing         // The guild's round timing overrides for public arena matches.
+	opCodeLogLimiter     *rate.Limiter        // Limits the logging of unexpected opcodes.
 }
 
 func (s *MatchLabel) LoadAndDeleteReservation(sessionID string) (*EvrMatchPresence, bool) {
@@ -104,6 +109,11 @@ func (s *MatchLabel) IsPublicMatch() bool {
 	return s.Mode == evr.ModeArenaPublic || s.Mode == evr.ModeCombatPublic
 }
 
+// RoundLimitReached returns true if the match has played its maximum number of rounds.
+func (s *MatchLabel) RoundLimitReached() bool {
+	return s.MaxRounds > 0 && s.GameState != nil && s.GameState.RoundsPlayed >= s.MaxRounds
+}
+
 func (s *MatchLabel) IsLocked() bool {
 	if !s.Open || !s.LockedAt.IsZero() {
 		return true
@@ -201,6 +211,12 @@ func (s *MatchLabel) Started() bool {
 	return !s.StartTime.IsZero() && time.Now().After(s.StartTime)
 }
 
+// ReusableWhenEmpty returns true if the match can be reused for matchmaking once it is empty: a public lobby that still
+// has its game server, has not started, and is neither locked nor shutting down.
+func (s *MatchLabel) ReusableWhenEmpty() bool {
+	return s.LobbyType == PublicLobby && s.server != nil && !s.Started() && s.terminateTick == 0 && s.LockedAt.IsZero()
+}
+
 func (s *MatchLabel) GetLabel() string {
 	labelJson, err := json.Marshal(s)
 	if err != nil {
@@ -232,13 +248,21 @@ func (s *MatchLabel) GetEntrantConnectMessage(role int, isPCVR bool, disableEncr
 	return evr.NewLobbySessionSuccess(s.Mode, s.ID.UUID, s.GetGroupID(), s.Broadcaster.Endpoint, int16(role), isPCVR, disableEncryption, disableMAC).Version5()
 }
 
+// groupIDTag returns the group ID for metrics tags, or "none" for group-less matches (i.e. unassigned lobbies).
+func (s *MatchLabel) groupIDTag() string {
+	if s.GroupID == nil || s.GroupID.IsNil() {
+		return "none"
+	}
+	return s.GroupID.String()
+}
+
 func (s *MatchLabel) MetricsTags() map[string]string {
 
 	tags := map[string]string{
 		"mode":        s.Mode.String(),
 		"level":       s.Level.String(),
 		"type":        s.LobbyType.String(),
-		"group_id":    s.GetGroupID().String(),
+		"group_id":    s.groupIDTag(),
 		"operator_id": s.Broadcaster.OperatorID,
 	}
 
@@ -428,9 +452,10 @@ func (l *MatchLabel) PublicView() *MatchLabel {
 	var gs *GameState
 	if l.GameState != nil {
 		gs = &GameState{
-			BlueScore:   l.GameState.BlueScore,
-			OrangeScore: l.GameState.OrangeScore,
-			Teams:       l.GameState.Teams,
+			BlueScore:    l.GameState.BlueScore,
+			OrangeScore:  l.GameState.OrangeScore,
+			Teams:        l.GameState.Teams,
+			RoundsPlayed: l.GameState.RoundsPlayed,
 		}
 		if l.GameState.RoundClock != nil {
 			gs.RoundClock = l.GameState.RoundClock.LatestAsNewClock()
@@ -438,23 +463,25 @@ func (l *MatchLabel) PublicView() *MatchLabel {
 	}
 
 	v := &MatchLabel{
-		LobbyType:        l.LobbyType,
-		ID:               l.ID,
-		Open:             l.Open,
-		LockedAt:         l.LockedAt,
-		GameState:        gs,
-		StartTime:        l.StartTime,
-		CreatedAt:        l.CreatedAt,
-		GroupID:          l.GroupID,
-		SpawnedBy:        l.SpawnedBy,
-		Mode:             l.Mode,
-		Level:            l.Level,
-		RequiredFeatures: l.RequiredFeatures,
-		MaxSize:          l.MaxSize,
-		Size:             l.Size,
-		PlayerCount:      l.PlayerCount,
-		PlayerLimit:      l.PlayerLimit,
-		TeamSize:         l.TeamSize,
+		LobbyType:         l.LobbyType,
+		ID:                l.ID,
+		Open:              l.Open,
+		LockedAt:          l.LockedAt,
+		GameState:         gs,
+		StartTime:         l.StartTime,
+		CreatedAt:         l.CreatedAt,
+		GroupID:           l.GroupID,
+		SpawnedBy:         l.SpawnedBy,
+		Mode:              l.Mode,
+		Level:             l.Level,
+		RequiredFeatures:  l.RequiredFeatures,
+		PreferredFeatures: l.PreferredFeatures,
+		MaxSize:           l.MaxSize,
+		Size:              l.Size,
+		PlayerCount:       l.PlayerCount,
+		PlayerLimit:       l.PlayerLimit,
+		TeamSize:          l.TeamSize,
+		MaxRounds:         l.MaxRounds,
 		Broadcaster: MatchBroadcaster{
 			OperatorID:  l.Broadcaster.OperatorID,
 			GroupIDs:    l.Broadcaster.GroupIDs,
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/heroiclabs/nakama/v3/server/evr"
+)
+
+const (
+	MinRoundDurationSecs     = 60
+	MaxRoundDurationSecs     = 1200
+	MinAfterGoalDurationSecs = 5
+	MaxAfterGoalDurationSecs = 60
+	MinPreMatchWaitSecs      = 10
+	MaxPreMatchWaitSecs      = 300
+)
+
+// MatchPacing overrides the public arena timing for a guild's lobbies. Zero values use the defaults.
+type MatchPacing struct {
+	RoundDurationSecs     int `json:"round_duration_secs,omitempty"`      // The length of a round
+	AfterGoalDurationSecs int `json:"after_goal_duration_secs,omitempty"` // The pause after a goal, before the respawn and catapult
+	PreMatchWaitSecs      int `json:"pre_match_wait_secs,omitempty"`      // The wait before the first catapult
+}
+
+func (p *MatchPacing) Validate() error {
+	if p.RoundDurationSecs != 0 && (p.RoundDurationSecs < MinRoundDurationSecs || p.RoundDurationSecs > MaxRoundDurationSecs) {
+		return fmt.Errorf("round duration must be between %d and %d seconds", MinRoundDurationSecs, MaxRoundDurationSecs)
+	}
+	if p.AfterGoalDurationSecs != 0 && (p.AfterGoalDurationSecs < MinAfterGoalDurationSecs || p.AfterGoalDurationSecs > MaxAfterGoalDurationSecs) {
+		return fmt.Errorf("after goal duration must be between %d and %d seconds", MinAfterGoalDurationSecs, MaxAfterGoalDurationSecs)
+	}
+	if p.PreMatchWaitSecs != 0 && (p.PreMatchWaitSecs < MinPreMatchWaitSecs || p.PreMatchWaitSecs > MaxPreMatchWaitSecs) {
+		return fmt.Errorf("pre-match wait must be between %d and %d seconds", MinPreMatchWaitSecs, MaxPreMatchWaitSecs)
+	}
+	return nil
+}
+
+func (p *MatchPacing) RoundDuration() time.Duration {
+	if p == nil || p.RoundDurationSecs == 0 {
+		return RoundDuration
+	}
+	return time.Duration(p.RoundDurationSecs) * time.Second
+}
+
+// AfterGoalDuration is how long play is paused after a goal, before the respawn and catapult.
+func (p *MatchPacing) AfterGoalDuration() time.Duration {
+	if p == nil || p.AfterGoalDurationSecs == 0 {
+		return AfterGoalDuration
+	}
+	return time.Duration(p.AfterGoalDurationSecs) * time.Second
+}
+
+// PublicMatchWaitTime is how long after the match starts that the round clock starts.
+func (p *MatchPacing) PublicMatchWaitTime() time.Duration {
+	if p == nil || p.PreMatchWaitSecs == 0 {
+		return PublicMatchWaitTime
+	}
+	return time.Duration(p.PreMatchWaitSecs)*time.Second + CatapultDuration + RoundCatapultDelayDuration
+}
+
+// SessionPacing returns the overrides to send to the game server, or nil if there are none.
+func (p *MatchPacing) SessionPacing() *evr.SessionPacing {
+	if p == nil {
+		return nil
+	}
+	return &evr.SessionPacing{
+		RoundDurationSecs:     p.RoundDurationSecs,
+		AfterGoalDurationSecs: p.AfterGoalDurationSecs,
+		PreMatchWaitSecs:      p.PreMatchWaitSecs,
+	}
+}
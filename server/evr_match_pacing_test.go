@@ -0,0 +1,56 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/heroiclabs/nakama/v3/server/evr"
+)
+
+func TestMatchPacing_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pacing  MatchPacing
+		wantErr bool
+	}{
+		{"defaults", MatchPacing{}, false},
+		{"in bounds", MatchPacing{RoundDurationSecs: 180, AfterGoalDurationSecs: 10, PreMatchWaitSecs: 30}, false},
+		{"round too short", MatchPacing{RoundDurationSecs: 10}, true},
+		{"after goal too long", MatchPacing{AfterGoalDurationSecs: 600}, true},
+		{"pre-match wait too short", MatchPacing{PreMatchWaitSecs: 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.pacing.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchPacing_Durations(t *testing.T) {
+	var unset *MatchPacing
+	if got := unset.RoundDuration(); got != RoundDuration {
+		t.Errorf("RoundDuration() = %v, want %v", got, RoundDuration)
+	}
+	if got := unset.PublicMatchWaitTime(); got != PublicMatchWaitTime {
+		t.Errorf("PublicMatchWaitTime() = %v, want %v", got, PublicMatchWaitTime)
+	}
+
+	pacing := &MatchPacing{RoundDurationSecs: 180, AfterGoalDurationSecs: 10}
+	if got := pacing.RoundDuration(); got != 180*time.Second {
+		t.Errorf("RoundDuration() = %v, want %v", got, 180*time.Second)
+	}
+	if got := pacing.AfterGoalDuration(); got != 10*time.Second {
+		t.Errorf("AfterGoalDuration() = %v, want %v", got, 10*time.Second)
+	}
+	if got := unset.AfterGoalDuration(); got != AfterGoalDuration {
+		t.Errorf("AfterGoalDuration() = %v, want %v", got, AfterGoalDuration)
+	}
+	if got := unset.SessionPacing(); got != nil {
+		t.Errorf("SessionPacing() = %v, want nil", got)
+	}
+	if got, want := pacing.SessionPacing(), (&evr.SessionPacing{RoundDurationSecs: 180, AfterGoalDurationSecs: 10}); *got != *want {
+		t.Errorf("SessionPacing() = %v, want %v", got, want)
+	}
+}
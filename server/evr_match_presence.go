@@ -106,6 +106,38 @@ func NewEntrantID(matchID MatchID, xpID evr.XPID) uuid.UUID {
 	return uuid.NewV5(matchID.UUID, EntrantIDSaltStr+xpID.String())
 }
 
+// EntrantPresenceFromSession creates an entrant presence from a lobby session, for players that are not matchmaking.
+func EntrantPresenceFromSession(session Session, groupID string) (*EvrMatchPresence, error) {
+	params, ok := LoadParams(session.Context())
+	if !ok {
+		return nil, errors.New("failed to get session parameters")
+	}
+
+	loginSession := params.LoginSession.Load()
+	if loginSession == nil {
+		return nil, errors.New("failed to get login session")
+	}
+
+	return &EvrMatchPresence{
+		Node:              params.Node,
+		UserID:            session.UserID(),
+		SessionID:         session.ID(),
+		LoginSessionID:    loginSession.id,
+		Username:          session.Username(),
+		DisplayName:       params.AccountMetadata.GetGroupDisplayNameOrDefault(groupID),
+		XPID:              params.XPID,
+		RoleAlignment:     int(AnyTeam),
+		DiscordID:         params.DiscordID,
+		ClientIP:          session.ClientIP(),
+		ClientPort:        session.ClientPort(),
+		IsPCVR:            params.IsPCVR.Load(),
+		SupportedFeatures: params.SupportedFeatures,
+
+		DisableEncryption: params.DisableEncryption,
+		DisableMAC:        params.DisableMAC,
+	}, nil
+}
+
 func EntrantPresenceFromLobbyParams(session Session, lobbyParams *LobbySessionParameters) (*EvrMatchPresence, error) {
 
 	sessionCtx := session.Context()
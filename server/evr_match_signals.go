@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama/v3/server/evr"
 )
 
 type SignalOpCode int
@@ -22,12 +23,28 @@ const (
 	SignalReserveSlots
 	SignalPruneUnderutilized
 	SignalShutdown
+	SignalGetStats
+	SignalEditLabel
 )
 
+type ctxSignalCorrelationIDKey struct{}
+
+// WithSignalCorrelationID returns a context whose match signals carry the correlation ID, so that an action can be traced through to the match.
+func WithSignalCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, ctxSignalCorrelationIDKey{}, correlationID)
+}
+
+// SignalCorrelationID returns the correlation ID of the context, if it has one.
+func SignalCorrelationID(ctx context.Context) string {
+	correlationID, _ := ctx.Value(ctxSignalCorrelationIDKey{}).(string)
+	return correlationID
+}
+
 type SignalEnvelope struct {
-	UserID  string
-	OpCode  SignalOpCode
-	Payload []byte
+	UserID        string
+	OpCode        SignalOpCode
+	Payload       []byte
+	CorrelationID string // Identifies the action that sent the signal
 }
 
 func NewSignalEnvelope(userID string, signal SignalOpCode, data any) *SignalEnvelope {
@@ -86,6 +103,12 @@ type SignalShutdownPayload struct {
 	DisconnectUsers      bool `json:"disconnect_users"`
 }
 
+// SignalEditLabelPayload holds the label fields that may be corrected on a running match; nil fields are left unchanged.
+type SignalEditLabelPayload struct {
+	Regions *[]evr.Symbol `json:"regions,omitempty"`
+	Tags    *[]string     `json:"tags,omitempty"`
+}
+
 type SignalReserveSlotsPayload struct {
 	SessionIDs    []string
 	RoleAlignment int
@@ -100,8 +123,9 @@ func SignalMatch(ctx context.Context, nk runtime.NakamaModule, matchID MatchID,
 	}
 
 	signal := SignalEnvelope{
-		OpCode:  opCode,
-		Payload: dataJson,
+		OpCode:        opCode,
+		Payload:       dataJson,
+		CorrelationID: SignalCorrelationID(ctx),
 	}
 	payload, err := json.Marshal(signal)
 	if err != nil {
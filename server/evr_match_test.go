@@ -8,6 +8,7 @@ import (
 	"net"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"testing"
 	"time"
@@ -458,6 +459,37 @@ func TestSelectTeamForPlayer_With_Alighment(t *testing.T) {
 	}
 }
 
+// testEvrMatchModule is a NakamaModule that discards metrics.
+type testEvrMatchModule struct {
+	runtime.NakamaModule
+}
+
+func (m *testEvrMatchModule) MetricsCounterAdd(name string, tags map[string]string, delta int64) {}
+
+func (m *testEvrMatchModule) MetricsGaugeSet(name string, tags map[string]string, value float64) {}
+
+func (m *testEvrMatchModule) MetricsTimerRecord(name string, tags map[string]string, value time.Duration) {
+}
+
+// testEvrMatchDispatcher is a MatchDispatcher that discards messages.
+type testEvrMatchDispatcher struct{}
+
+func (d *testEvrMatchDispatcher) BroadcastMessage(opCode int64, data []byte, presences []runtime.Presence, sender runtime.Presence, reliable bool) error {
+	return nil
+}
+
+func (d *testEvrMatchDispatcher) BroadcastMessageDeferred(opCode int64, data []byte, presences []runtime.Presence, sender runtime.Presence, reliable bool) error {
+	return nil
+}
+
+func (d *testEvrMatchDispatcher) MatchKick(presences []runtime.Presence) error {
+	return nil
+}
+
+func (d *testEvrMatchDispatcher) MatchLabelUpdate(label string) error {
+	return nil
+}
+
 func TestEvrMatch_MatchLoop(t *testing.T) {
 	type args struct {
 		tick     int64
@@ -482,7 +514,7 @@ func TestEvrMatch_MatchLoop(t *testing.T) {
 			args: args{
 				tick: 15 * 60 * 10 * 2,
 				state_: func() *MatchLabel {
-					state := &MatchLabel{}
+					state := &MatchLabel{tickRate: 10}
 					state.sessionStartExpiry = 10 * 10
 					state.server = &Presence{}
 					return state
@@ -498,7 +530,7 @@ func TestEvrMatch_MatchLoop(t *testing.T) {
 			args: args{
 				tick: 500,
 				state_: func() *MatchLabel {
-					state := &MatchLabel{}
+					state := &MatchLabel{tickRate: 10}
 					state.sessionStartExpiry = 10 * 10
 					state.server = &Presence{}
 
@@ -548,7 +580,7 @@ func TestEvrMatch_MatchLoop(t *testing.T) {
 			args: args{
 				tick: 5 * 10,
 				state_: func() *MatchLabel {
-					state := &MatchLabel{}
+					state := &MatchLabel{tickRate: 10}
 					state.sessionStartExpiry = 10 * 10
 					state.presenceMap = map[string]*EvrMatchPresence{
 						uuid.Must(uuid.NewV4()).String(): {},
@@ -568,8 +600,8 @@ func TestEvrMatch_MatchLoop(t *testing.T) {
 			m := &EvrMatch{}
 			ctx := context.Background()
 			var db *sql.DB
-			var nk runtime.NakamaModule
-			var dispatcher runtime.MatchDispatcher
+			nk := &testEvrMatchModule{}
+			dispatcher := &testEvrMatchDispatcher{}
 
 			if got := m.MatchLoop(ctx, logger, db, nk, dispatcher, tt.args.tick, tt.args.state_, tt.args.messages); !reflect.DeepEqual(got, tt.want) {
 				t.Fatalf("- want / + got = %s", cmp.Diff(tt.want, got))
@@ -637,7 +669,7 @@ func TestEvrMatch_MatchJoinAttempt(t *testing.T) {
 			SessionID:      uuid.NewV5(uuid.Nil, fmt.Sprintf("session-%d", i)),
 			LoginSessionID: uuid.NewV5(uuid.Nil, fmt.Sprintf("login-%d", i)),
 			UserID:         uuid.NewV5(uuid.Nil, fmt.Sprintf("user-%d", i)),
-			XPID:           evr.NewXPID(4, evr.AccountID(i)),
+			XPID:           evr.NewXPID(evr.OVR, evr.AccountID(i)),
 			DiscordID:      "10000" + s,
 			ClientIP:       "127.0.0." + s,
 			ClientPort:     "100" + s,
@@ -803,7 +835,10 @@ func TestEvrMatch_MatchJoinAttempt(t *testing.T) {
 			m:    &EvrMatch{},
 			args: args{
 				state_: func() *MatchLabel {
-					state := &MatchLabel{}
+					state := &MatchLabel{
+						presenceByXPID: make(map[evr.XPID]*EvrMatchPresence),
+						joinTimestamps: make(map[string]time.Time),
+					}
 					state.Open = true
 					state.LobbyType = PublicLobby
 					state.Mode = evr.ModeArenaPublic
@@ -847,7 +882,10 @@ func TestEvrMatch_MatchJoinAttempt(t *testing.T) {
 			m:    &EvrMatch{},
 			args: args{
 				state_: func() *MatchLabel {
-					state := &MatchLabel{}
+					state := &MatchLabel{
+						presenceByXPID: make(map[evr.XPID]*EvrMatchPresence),
+						joinTimestamps: make(map[string]time.Time),
+					}
 					state.LobbyType = PublicLobby
 					state.Mode = evr.ModeArenaPublic
 					state.MaxSize = 3
@@ -885,6 +923,37 @@ func TestEvrMatch_MatchJoinAttempt(t *testing.T) {
 			}(),
 			want1: false,
 		},
+		{
+			name: "MatchJoinAttempt rejects reservations for entrants outside of the party.",
+			m:    &EvrMatch{},
+			args: args{
+				state_: func() *MatchLabel {
+					state := testStateFn()
+					state.Open = true
+					state.LobbyType = PublicLobby
+					state.Mode = evr.ModeArenaPublic
+					state.MaxSize = 8
+					state.PlayerLimit = 8
+					return state
+				}(),
+				presence: presences[0],
+				metadata: EntrantMetadata{
+					Presence:     presences[0],
+					Reservations: []*EvrMatchPresence{presences[1]}, // A different party
+				}.ToMatchMetadata(),
+			},
+			want: func() *MatchLabel {
+				state := testStateFn()
+				state.Open = true
+				state.LobbyType = PublicLobby
+				state.Mode = evr.ModeArenaPublic
+				state.MaxSize = 8
+				state.PlayerLimit = 8
+				return state
+			}(),
+			want1: false,
+			want2: ErrJoinRejectReasonPartyMembersMustHaveRoles.Error(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1220,3 +1289,244 @@ func TestEvrMatch_processJoin(t *testing.T) {
 }
 
 */
+
+func TestEvrMatch_MatchSignal_PrepareRejectsPublicWithoutGroup(t *testing.T) {
+	consoleLogger := NewJSONLogger(os.Stdout, zapcore.ErrorLevel, JSONFormat)
+	logger := NewRuntimeGoLogger(consoleLogger)
+
+	for _, mode := range []evr.Symbol{evr.ModeArenaPublic, evr.ModeCombatPublic, evr.ModeSocialPublic} {
+		t.Run(mode.String(), func(t *testing.T) {
+			m := &EvrMatch{}
+			state := &MatchLabel{
+				LobbyType: UnassignedLobby,
+			}
+
+			signal := NewSignalEnvelope(SystemUserID, SignalPrepareSession, MatchSettings{
+				Mode: mode,
+			})
+			data, err := json.Marshal(signal)
+			if err != nil {
+				t.Fatalf("failed to marshal signal: %v", err)
+			}
+
+			_, result := m.MatchSignal(context.Background(), logger, nil, nil, nil, 0, state, string(data))
+
+			response := SignalResponse{}
+			if err := json.Unmarshal([]byte(result), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			if response.Success {
+				t.Errorf("MatchSignal() succeeded, want rejection")
+			}
+			if state.LobbyType != UnassignedLobby {
+				t.Errorf("LobbyType = %v, want %v", state.LobbyType, UnassignedLobby)
+			}
+			if state.GroupID != nil {
+				t.Errorf("GroupID = %v, want nil", state.GroupID)
+			}
+		})
+	}
+}
+
+func TestEvrMatch_MatchJoinAttempt_TeamAlignments(t *testing.T) {
+	presences := make([]*EvrMatchPresence, 0, 2)
+	for i := 0; i < 2; i++ {
+		presences = append(presences, &EvrMatchPresence{
+			Node:          "testnode",
+			SessionID:     uuid.NewV5(uuid.Nil, fmt.Sprintf("session-%d", i)),
+			UserID:        uuid.NewV5(uuid.Nil, fmt.Sprintf("user-%d", i)),
+			XPID:          evr.NewXPID(4, evr.AccountID(i)),
+			RoleAlignment: evr.TeamOrange,
+		})
+	}
+
+	state := &MatchLabel{
+		Open:                 true,
+		LobbyType:            PublicLobby,
+		Mode:                 evr.ModeArenaPublic,
+		MaxSize:              8,
+		PlayerLimit:          8,
+		TeamSize:             4,
+		RequiredFeatures:     make([]string, 0),
+		Players:              make([]PlayerInfo, 0, 8),
+		presenceMap:          map[string]*EvrMatchPresence{presences[1].GetSessionId(): presences[1]},
+		reservationMap:       make(map[string]*slotReservation),
+		presenceByXPID:       make(map[evr.XPID]*EvrMatchPresence),
+		joinTimestamps:       make(map[string]time.Time),
+		joinTimeMilliseconds: make(map[string]int64),
+		// The matchmaker placed both players on orange.
+		TeamAlignments: teamAlignmentsFromEntrants(presences),
+	}
+	state.rebuildCache()
+
+	// The player's reservation has expired, so they join without a role.
+	joining := *presences[0]
+	joining.RoleAlignment = evr.TeamUnassigned
+
+	logger := NewRuntimeGoLogger(NewJSONLogger(os.Stdout, zapcore.ErrorLevel, JSONFormat))
+	m := &EvrMatch{}
+	_, ok, reason := m.MatchJoinAttempt(context.Background(), logger, nil, nil, nil, 10, state, &joining, NewJoinMetadata(&joining).ToMatchMetadata())
+	if !ok {
+		t.Fatalf("MatchJoinAttempt() rejected the join: %s", reason)
+	}
+
+	if got := state.presenceMap[joining.GetSessionId()].RoleAlignment; got != evr.TeamOrange {
+		t.Errorf("RoleAlignment = %d, want %d (the matchmaker-assigned team)", got, evr.TeamOrange)
+	}
+}
+
+func TestReservationsFit(t *testing.T) {
+	reservations := func(roles ...int) []*EvrMatchPresence {
+		presences := make([]*EvrMatchPresence, 0, len(roles))
+		for i, role := range roles {
+			presences = append(presences, &EvrMatchPresence{
+				SessionID:     uuid.NewV5(uuid.Nil, fmt.Sprintf("session-%d", i)),
+				RoleAlignment: role,
+			})
+		}
+		return presences
+	}
+
+	// A public arena match: 4v4 with 8 spectator slots.
+	state := &MatchLabel{
+		MaxSize:     16,
+		PlayerLimit: 8,
+		TeamSize:    4,
+	}
+
+	tests := []struct {
+		name         string
+		reservations []*EvrMatchPresence
+		wantErr      bool
+	}{
+		{"none", nil, false},
+		{"full teams", reservations(evr.TeamBlue, evr.TeamBlue, evr.TeamBlue, evr.TeamBlue, evr.TeamOrange, evr.TeamOrange, evr.TeamOrange, evr.TeamOrange), false},
+		{"unassigned players fit the player limit", reservations(evr.TeamUnassigned, evr.TeamUnassigned, evr.TeamUnassigned, evr.TeamUnassigned, evr.TeamUnassigned, evr.TeamUnassigned, evr.TeamUnassigned, evr.TeamUnassigned), false},
+		{"over the team size", reservations(evr.TeamBlue, evr.TeamBlue, evr.TeamBlue, evr.TeamBlue, evr.TeamBlue), true},
+		{"over the player limit", reservations(evr.TeamUnassigned, evr.TeamUnassigned, evr.TeamUnassigned, evr.TeamUnassigned, evr.TeamUnassigned, evr.TeamUnassigned, evr.TeamUnassigned, evr.TeamUnassigned, evr.TeamUnassigned), true},
+		{"spectators use the spectator slots", reservations(evr.TeamSpectator, evr.TeamSpectator, evr.TeamSpectator, evr.TeamSpectator, evr.TeamSpectator, evr.TeamSpectator, evr.TeamSpectator, evr.TeamSpectator), false},
+		{"over the spectator limit", reservations(evr.TeamSpectator, evr.TeamSpectator, evr.TeamSpectator, evr.TeamSpectator, evr.TeamSpectator, evr.TeamSpectator, evr.TeamSpectator, evr.TeamSpectator, evr.TeamModerator), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := reservationsFit(state, tt.reservations); (err != nil) != tt.wantErr {
+				t.Errorf("reservationsFit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchOpCodeAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   evr.Symbol
+		opCode int64
+		want   bool
+	}{
+		{"arena game state update", evr.ModeArenaPublic, OpCodeMatchGameStateUpdate, true},
+		{"combat lobby status", evr.ModeCombatPrivate, OpCodeGameServerLobbyStatus, true},
+		{"social packet data", evr.ModeSocialPublic, OpCodeEVRPacketData, false},
+		{"tournament game state update", evr.ModeArenaTournment, OpCodeMatchGameStateUpdate, true},
+		{"social NPE lobby status", evr.ModeSocialNPE, OpCodeGameServerLobbyStatus, true},
+		{"unassigned lobby status", evr.Symbol(0), OpCodeGameServerLobbyStatus, true},
+		{"unassigned game state update", evr.Symbol(0), OpCodeMatchGameStateUpdate, false},
+		{"unknown opcode", evr.ModeArenaPublic, 0x7fffffff, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchOpCodeAllowed(tt.mode, tt.opCode); got != tt.want {
+				t.Errorf("MatchOpCodeAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvrMatch_MatchLeave_EmptyMatch(t *testing.T) {
+	consoleLogger := NewJSONLogger(os.Stdout, zapcore.ErrorLevel, JSONFormat)
+	logger := NewRuntimeGoLogger(consoleLogger)
+
+	server := &EvrMatchPresence{SessionID: uuid.Must(uuid.NewV4())}
+
+	tests := []struct {
+		name          string
+		lobbyType     LobbyType
+		startTime     time.Time
+		lockedAt      time.Time
+		terminateTick int64
+		wantOpen      bool
+	}{
+		{"public lobby that has not started is reopened", PublicLobby, time.Now().Add(time.Minute), time.Time{}, 0, true},
+		{"public lobby that has started is closed", PublicLobby, time.Now().Add(-time.Minute), time.Time{}, 0, false},
+		{"private lobby is closed", PrivateLobby, time.Now().Add(time.Minute), time.Time{}, 0, false},
+		{"locked public lobby is closed", PublicLobby, time.Now().Add(time.Minute), time.Now(), 0, false},
+		{"public lobby that is shutting down is closed", PublicLobby, time.Now().Add(time.Minute), time.Time{}, 100, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &EvrMatch{}
+			state := &MatchLabel{
+				Mode:          evr.ModeArenaPublic,
+				LobbyType:     tt.lobbyType,
+				Open:          !tt.wantOpen,
+				StartTime:     tt.startTime,
+				LockedAt:      tt.lockedAt,
+				Broadcaster:   MatchBroadcaster{SessionID: server.SessionID.String()},
+				presenceMap:   make(map[string]*EvrMatchPresence),
+				server:        server,
+				terminateTick: tt.terminateTick,
+			}
+
+			got := m.MatchLeave(context.Background(), logger, nil, nil, nil, 0, state, []runtime.Presence{})
+			if got == nil {
+				t.Fatalf("MatchLeave() shut down the match")
+			}
+			if state.Open != tt.wantOpen {
+				t.Errorf("Open = %v, want %v", state.Open, tt.wantOpen)
+			}
+		})
+	}
+}
+
+func TestEvrMatch_MatchSignal_EditLabel(t *testing.T) {
+	consoleLogger := NewJSONLogger(os.Stdout, zapcore.ErrorLevel, JSONFormat)
+	logger := NewRuntimeGoLogger(consoleLogger)
+
+	signal := func(state *MatchLabel, payload SignalEditLabelPayload) SignalResponse {
+		data, err := json.Marshal(NewSignalEnvelope(SystemUserID, SignalEditLabel, payload))
+		if err != nil {
+			t.Fatalf("failed to marshal signal: %v", err)
+		}
+		_, result := (&EvrMatch{}).MatchSignal(context.Background(), logger, nil, &testEvrMatchModule{}, &testEvrMatchDispatcher{}, 0, state, string(data))
+		response := SignalResponse{}
+		if err := json.Unmarshal([]byte(result), &response); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		return response
+	}
+
+	state := &MatchLabel{
+		Broadcaster: MatchBroadcaster{
+			Regions: []evr.Symbol{evr.ToSymbol("us-east")},
+			Tags:    []string{"old"},
+		},
+	}
+
+	if response := signal(state, SignalEditLabelPayload{}); response.Success {
+		t.Errorf("MatchSignal() with no fields succeeded, want rejection")
+	}
+
+	tags := []string{"tournament"}
+	if response := signal(state, SignalEditLabelPayload{Tags: &tags}); !response.Success {
+		t.Fatalf("MatchSignal() failed: %s", response.Message)
+	}
+	if !slices.Equal(state.Broadcaster.Tags, tags) {
+		t.Errorf("Tags = %v, want %v", state.Broadcaster.Tags, tags)
+	}
+	if !slices.Equal(state.Broadcaster.Regions, []evr.Symbol{evr.ToSymbol("us-east")}) {
+		t.Errorf("Regions = %v, want them unchanged", state.Broadcaster.Regions)
+	}
+}
@@ -145,6 +145,9 @@ func (m *SkillBasedMatchmaker) processPotentialMatches(candidates [][]runtime.Ma
 	// Filter out odd-sized teams
 	filterCounts["odd_sized_teams"] = m.filterOddSizedTeams(candidates)
 
+	// Filter out matches larger than the guild's team size allows
+	filterCounts["oversized_teams"] = m.filterOversizedTeams(candidates)
+
 	// Filter out players who are too far away from each other
 	filterCounts["max_rtt"] = m.filterWithinMaxRTT(candidates)
 
@@ -208,6 +211,31 @@ func (m *SkillBasedMatchmaker) filterOddSizedTeams(candidates [][]runtime.Matchm
 	return oddSizedCount
 }
 
+func (m *SkillBasedMatchmaker) filterOversizedTeams(candidates [][]runtime.MatchmakerEntry) int {
+	oversizedCount := 0
+	for i := 0; i < len(candidates); i++ {
+		if candidates[i] == nil {
+			continue
+		}
+		if len(candidates[i]) > candidateTeamSize(candidates[i])*2 {
+			oversizedCount++
+			candidates[i] = nil
+		}
+	}
+	return oversizedCount
+}
+
+// candidateTeamSize returns the team size of the candidate match; half its entries, unless an entry's guild uses smaller teams.
+func candidateTeamSize(candidate []runtime.MatchmakerEntry) int {
+	teamSize := (len(candidate) + 1) / 2
+	for _, e := range candidate {
+		if ts, ok := e.GetProperties()["team_size"].(float64); ok && ts > 0 && int(ts) < teamSize {
+			teamSize = int(ts)
+		}
+	}
+	return teamSize
+}
+
 // Ensure that everyone in the match is within their max_rtt of a common server
 func (m *SkillBasedMatchmaker) filterWithinMaxRTT(candidates [][]runtime.MatchmakerEntry) int {
 
@@ -436,7 +464,7 @@ func (m *SkillBasedMatchmaker) balanceByTicket(candidate []runtime.MatchmakerEnt
 		byTicket = append(byTicket, entries)
 	}
 
-	team1, team2 := m.createBalancedMatch(byTicket, len(candidate)/2)
+	team1, team2 := m.createBalancedMatch(byTicket, candidateTeamSize(candidate))
 	return RatedMatch{team1, team2}
 }
 
@@ -19,6 +19,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/heroiclabs/nakama-common/rtapi"
 	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama/v3/server/evr"
 	"github.com/intinig/go-openskill/types"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -1272,3 +1273,35 @@ func newMatchmakingEntryFromExisting(entry *MatchmakerEntry, minCount, maxCount,
 
 	return newEntry
 }
+
+func TestGuildTeamSizeMatchmaking(t *testing.T) {
+	if cfg, _ := matchmakingTicketConfig(evr.ModeArenaPublic, 3); cfg.MaxCount != 6 {
+		t.Errorf("matchmakingTicketConfig() MaxCount = %d, want 6 for a 3v3 guild", cfg.MaxCount)
+	}
+	if cfg, _ := matchmakingTicketConfig(evr.ModeCombatPublic, 0); cfg.MaxCount != 10 {
+		t.Errorf("matchmakingTicketConfig() MaxCount = %d, want the default of 10", cfg.MaxCount)
+	}
+
+	entries := func(n int, teamSize float64) []runtime.MatchmakerEntry {
+		entries := make([]runtime.MatchmakerEntry, 0, n)
+		for i := 0; i < n; i++ {
+			entries = append(entries, &MatchmakerEntry{
+				Ticket:     fmt.Sprintf("ticket-%d", i),
+				Presence:   &MatchmakerPresence{SessionId: uuid.NewV5(uuid.Nil, fmt.Sprintf("%d", i)).String()},
+				Properties: map[string]interface{}{"team_size": teamSize},
+			})
+		}
+		return entries
+	}
+
+	m := NewSkillBasedMatchmaker()
+	candidates := [][]runtime.MatchmakerEntry{entries(8, 3), entries(6, 3)}
+	if count := m.filterOversizedTeams(candidates); count != 1 || candidates[0] != nil || candidates[1] == nil {
+		t.Errorf("filterOversizedTeams() = %d, want only the 8 player candidate removed", count)
+	}
+
+	match := m.balanceByTicket(candidates[1])
+	if len(match[0]) != 3 || len(match[1]) != 3 {
+		t.Errorf("balanceByTicket() = %dv%d, want 3v3", len(match[0]), len(match[1]))
+	}
+}
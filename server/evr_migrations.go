@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -109,3 +110,92 @@ func CombineHistoryRecords(ctx context.Context, nk runtime.NakamaModule, db *sql
 
 	return nil
 }
+
+// MergeUserAccounts merges the secondary account into the primary account. The login history of the secondary is
+// combined into the primary, the secondary's devices are relinked to the primary, and the secondary account is disabled.
+// Returns the device IDs that were relinked.
+func MergeUserAccounts(ctx context.Context, nk runtime.NakamaModule, db *sql.DB, primaryUserID, secondaryUserID string) ([]string, error) {
+	if primaryUserID == secondaryUserID {
+		return nil, errors.New("primary and secondary accounts must be different")
+	}
+
+	primaryHistory, err := LoginHistoryLoad(ctx, nk, primaryUserID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading primary login history: %w", err)
+	}
+	primaryHistory.userID = primaryUserID
+
+	secondaryHistory, err := LoginHistoryLoad(ctx, nk, secondaryUserID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading secondary login history: %w", err)
+	}
+
+	// Combine the login history of the secondary into the primary
+	for _, e := range secondaryHistory.History {
+		primaryHistory.Insert(e)
+	}
+
+	if primaryHistory.AuthorizedIPs == nil {
+		primaryHistory.AuthorizedIPs = make(map[string]time.Time)
+	}
+	for ip, t := range secondaryHistory.AuthorizedIPs {
+		if _, found := primaryHistory.AuthorizedIPs[ip]; !found {
+			primaryHistory.AuthorizedIPs[ip] = t
+		}
+	}
+
+	// Relink the devices to the primary account
+	relinked, err := relinkUserDevices(ctx, db, primaryUserID, secondaryUserID)
+	if err != nil {
+		return nil, fmt.Errorf("error relinking devices: %w", err)
+	}
+
+	if err := MigrateUserData(ctx, nk, db, primaryUserID, primaryHistory); err != nil {
+		return relinked, fmt.Errorf("error migrating user data: %w", err)
+	}
+
+	if err := LoginHistoryStore(ctx, nk, primaryUserID, primaryHistory); err != nil {
+		return relinked, fmt.Errorf("error storing primary login history: %w", err)
+	}
+
+	// Disable the secondary account
+	if err := nk.UsersBanId(ctx, []string{secondaryUserID}); err != nil {
+		return relinked, fmt.Errorf("error disabling secondary account: %w", err)
+	}
+
+	if _, err := DisconnectUserID(ctx, nk, secondaryUserID); err != nil {
+		return relinked, fmt.Errorf("error disconnecting secondary account: %w", err)
+	}
+
+	return relinked, nil
+}
+
+// relinkUserDevices moves all of the devices of the secondary user to the primary user, in a single transaction; either
+// all of the devices are moved, or none are.
+func relinkUserDevices(ctx context.Context, db *sql.DB, primaryUserID, secondaryUserID string) ([]string, error) {
+	relinked := make([]string, 0)
+	if err := ExecuteInTx(ctx, db, func(tx *sql.Tx) error {
+		relinked = relinked[:0]
+		rows, err := tx.QueryContext(ctx, "UPDATE user_device SET user_id = $1 WHERE user_id = $2 RETURNING id", primaryUserID, secondaryUserID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var deviceID string
+			if err := rows.Scan(&deviceID); err != nil {
+				return err
+			}
+			relinked = append(relinked, deviceID)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE users SET update_time = now() WHERE id IN ($1, $2)", primaryUserID, secondaryUserID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return relinked, nil
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+const (
+	OccupancyHistoryStorageCollection = "Analytics"
+	OccupancyHistoryStorageKey        = "occupancy"
+	OccupancySampleInterval           = 10 * time.Minute
+	OccupancyHistoryWindow            = 48 * time.Hour
+	OccupancyDefaultHours             = 24
+
+	occupancyBarWidth   = 30
+	occupancyMaxSummary = 24 // The number of rows that fit in a Discord message
+)
+
+type OccupancySample struct {
+	Timestamp time.Time      `json:"ts"`
+	Players   int            `json:"players"`
+	Matches   int            `json:"matches"`
+	Modes     map[string]int `json:"modes,omitempty"` // The player count by mode
+}
+
+// OccupancyHistory is a rolling window of match occupancy samples.
+type OccupancyHistory struct {
+	Samples []OccupancySample `json:"samples"`
+}
+
+// Append adds the sample, and drops the samples that have aged out of the window.
+func (h *OccupancyHistory) Append(sample OccupancySample) {
+	h.Samples = append(h.Samples, sample)
+
+	cutoff := sample.Timestamp.Add(-OccupancyHistoryWindow)
+	i := 0
+	for i < len(h.Samples) && h.Samples[i].Timestamp.Before(cutoff) {
+		i++
+	}
+	h.Samples = h.Samples[i:]
+}
+
+// Since returns the samples taken after t.
+func (h *OccupancyHistory) Since(t time.Time) []OccupancySample {
+	i := sort.Search(len(h.Samples), func(i int) bool {
+		return !h.Samples[i].Timestamp.Before(t)
+	})
+	return h.Samples[i:]
+}
+
+func LoadOccupancyHistory(ctx context.Context, nk runtime.NakamaModule) (*OccupancyHistory, error) {
+	objs, err := nk.StorageRead(ctx, []*runtime.StorageRead{
+		{
+			Collection: OccupancyHistoryStorageCollection,
+			Key:        OccupancyHistoryStorageKey,
+			UserID:     SystemUserID,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read occupancy history: %w", err)
+	}
+
+	history := &OccupancyHistory{
+		Samples: make([]OccupancySample, 0),
+	}
+	if len(objs) > 0 {
+		if err := json.Unmarshal([]byte(objs[0].Value), history); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal occupancy history: %w", err)
+		}
+	}
+	return history, nil
+}
+
+func StoreOccupancyHistory(ctx context.Context, nk runtime.NakamaModule, history *OccupancyHistory) error {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to marshal occupancy history: %w", err)
+	}
+
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{
+		{
+			Collection:      OccupancyHistoryStorageCollection,
+			Key:             OccupancyHistoryStorageKey,
+			UserID:          SystemUserID,
+			PermissionRead:  0,
+			PermissionWrite: 0,
+			Value:           string(data),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to write occupancy history: %w", err)
+	}
+	return nil
+}
+
+// RecordOccupancySample appends the sample to the stored occupancy history.
+func RecordOccupancySample(ctx context.Context, nk runtime.NakamaModule, sample OccupancySample) error {
+	history, err := LoadOccupancyHistory(ctx, nk)
+	if err != nil {
+		return err
+	}
+	history.Append(sample)
+	return StoreOccupancyHistory(ctx, nk, history)
+}
+
+// occupancySummary renders the average player count over the hours (at most one row per hour), and the peak player count by mode.
+func occupancySummary(samples []OccupancySample, start time.Time, hours int) string {
+	if len(samples) == 0 {
+		return "No occupancy samples have been recorded."
+	}
+
+	rows := min(hours, occupancyMaxSummary)
+	bucket := time.Duration(hours) * time.Hour / time.Duration(rows)
+
+	totals := make([]int, rows)
+	counts := make([]int, rows)
+	modePeaks := make(map[string]int)
+	peak := 0
+	for _, s := range samples {
+		if r := int(s.Timestamp.Sub(start) / bucket); r >= 0 && r < rows {
+			totals[r] += s.Players
+			counts[r]++
+		}
+		peak = max(peak, s.Players)
+		for mode, n := range s.Modes {
+			modePeaks[mode] = max(modePeaks[mode], n)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("```\n")
+	for r := 0; r < rows; r++ {
+		bar := ""
+		avg := 0
+		if counts[r] > 0 {
+			avg = totals[r] / counts[r]
+			if peak > 0 {
+				bar = strings.Repeat("#", avg*occupancyBarWidth/peak)
+			}
+		}
+		fmt.Fprintf(&b, "%s %4d %s\n", start.Add(time.Duration(r)*bucket).UTC().Format("01-02 15:04"), avg, bar)
+	}
+	b.WriteString("```\n")
+
+	modes := make([]string, 0, len(modePeaks))
+	for mode := range modePeaks {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+
+	fmt.Fprintf(&b, "Peak: **%d** players\n", peak)
+	for _, mode := range modes {
+		fmt.Fprintf(&b, "- `%s`: %d\n", mode, modePeaks[mode])
+	}
+	return b.String()
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOccupancyHistory_Append(t *testing.T) {
+	now := time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC)
+
+	history := &OccupancyHistory{}
+	for _, age := range []time.Duration{72 * time.Hour, 47 * time.Hour, time.Hour} {
+		history.Append(OccupancySample{Timestamp: now.Add(-age), Players: 1})
+	}
+	history.Append(OccupancySample{Timestamp: now, Players: 2})
+
+	if got := len(history.Samples); got != 3 {
+		t.Fatalf("len(Samples) = %d, want 3", got)
+	}
+	if got := len(history.Since(now.Add(-2 * time.Hour))); got != 2 {
+		t.Errorf("len(Since()) = %d, want 2", got)
+	}
+}
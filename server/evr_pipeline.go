@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -75,10 +76,15 @@ type EvrPipeline struct {
 
 	createLobbyMu                    sync.Mutex
 	broadcasterRegistrationBySession *MapOf[string, *MatchBroadcaster] // sessionID -> MatchBroadcaster
+	queueEstimator                   *matchmakingQueueEstimator
 
 	placeholderEmail string
 	linkDeviceURL    string
 
+	require2FAGraceLogins    int           // Number of logins allowed without 2FA after the requirement is applied
+	require2FATimeout        time.Duration // How long to wait for Discord to report the user's 2FA status
+	require2FAAllowOnTimeout bool          // Allow the login if the 2FA status can not be determined
+
 	cacheMu sync.Mutex // Writers only
 
 	messageCache *atomic.Value // map[string]evr.Message
@@ -120,9 +126,14 @@ func NewEvrPipeline(logger *zap.Logger, startupLogger *zap.Logger, db *sql.DB, p
 	}
 
 	leaderboardRegistry := NewLeaderboardRegistry(runtimeLogger, nk, config.GetName())
-	profileRegistry := NewProfileRegistry(nk, db, runtimeLogger, tracker, metrics)
+	profileCacheMaxSize := DefaultProfileCacheMaxSize
+	if n, err := strconv.Atoi(vars["PROFILE_CACHE_MAX_SIZE"]); err == nil {
+		profileCacheMaxSize = n
+	}
+	profileRegistry := NewProfileRegistry(nk, db, runtimeLogger, tracker, metrics, profileCacheMaxSize)
 	broadcasterRegistrationBySession := MapOf[string, *MatchBroadcaster]{}
-	lobbyBuilder := NewLobbyBuilder(logger, nk, sessionRegistry, matchRegistry, tracker, metrics, profileRegistry)
+	queueEstimator := newMatchmakingQueueEstimator()
+	lobbyBuilder := NewLobbyBuilder(logger, nk, sessionRegistry, matchRegistry, tracker, metrics, profileRegistry, queueEstimator)
 	matchmaker.OnMatchedEntries(lobbyBuilder.handleMatchedEntries)
 	userRemoteLogJournalRegistry := NewUserRemoteLogJournalRegistry(logger, nk, sessionRegistry)
 
@@ -148,13 +159,12 @@ func NewEvrPipeline(logger *zap.Logger, startupLogger *zap.Logger, db *sql.DB, p
 
 		}
 		if err := appBot.InitializeDiscordBot(); err != nil {
-			logger.Error("Failed to initialize app bot", zap.Error(err))
-		}
-		if err = appBot.dg.Open(); err != nil {
-			logger.Warn("Failed to open discord bot connection: %w", zap.Error(err))
+			logger.Warn("Failed to initialize app bot", zap.Error(err))
 		}
 	}
 
+	lobbyBuilder.appBot = appBot
+
 	matchLogManager := NewMatchLogManager(ctx, logger, vars["MONGO_URI"])
 	matchLogManager.Start()
 
@@ -194,6 +204,7 @@ func NewEvrPipeline(logger *zap.Logger, startupLogger *zap.Logger, db *sql.DB, p
 		profileRegistry:                  profileRegistry,
 		leaderboardRegistry:              leaderboardRegistry,
 		broadcasterRegistrationBySession: &broadcasterRegistrationBySession,
+		queueEstimator:                   queueEstimator,
 		userRemoteLogJournalRegistry:     userRemoteLogJournalRegistry,
 		ipqsClient:                       ipqsClient,
 		matchLogManager:                  matchLogManager,
@@ -201,9 +212,16 @@ func NewEvrPipeline(logger *zap.Logger, startupLogger *zap.Logger, db *sql.DB, p
 		placeholderEmail: config.GetRuntime().Environment["PLACEHOLDER_EMAIL_DOMAIN"],
 		linkDeviceURL:    config.GetRuntime().Environment["LINK_DEVICE_URL"],
 
+		require2FAGraceLogins: config.GetSession().Require2FAGraceLogins,
+		require2FATimeout:     time.Duration(config.GetSession().Require2FATimeoutMs) * time.Millisecond,
+		// Fail open by default so that users are not locked out by a flaky Discord API.
+		require2FAAllowOnTimeout: !config.GetSession().Require2FADenyOnTimeout,
+
 		messageCache: messageCache,
 	}
 
+	go evrPipeline.runUnassignedLobbyReconciler(evrPipeline.ctx, logger, UnassignedLobbyReconcileInterval)
+
 	go func() {
 		interval := 3 * time.Minute
 
@@ -669,12 +687,10 @@ func ProcessOutgoing(logger *zap.Logger, session *sessionWS, in *rtapi.Envelope)
 		}
 
 		if content != "" {
-			if dg := p.discordCache.dg; dg == nil {
+			if !p.appBot.discordEnabled() {
 				// No discord bot
 			} else if discordID, err := GetDiscordIDByUserID(session.Context(), session.pipeline.db, session.UserID().String()); err != nil {
 				logger.Warn("Failed to get discord ID", zap.Error(err))
-			} else if channel, err := dg.UserChannelCreate(discordID); err != nil {
-				logger.Warn("Failed to create DM channel", zap.Error(err))
 			} else {
 
 				// Limit the entire size of the message to 4k bytes
@@ -682,18 +698,8 @@ func ProcessOutgoing(logger *zap.Logger, session *sessionWS, in *rtapi.Envelope)
 					content = content[:4000]
 				}
 
-				// If the message is over 1800 bytes, then send it in chunks. just split it into 1800 byte chunks
-				if len(content) > 1800 {
-					for i := 0; i < len(content); i += 1800 {
-						max := min(i+1800, len(content))
-						if _, err = dg.ChannelMessageSend(channel.ID, content[i:max]); err != nil {
-							logger.Warn("Failed to send message to user", zap.Error(err))
-						}
-					}
-				} else {
-					if _, err = dg.ChannelMessageSend(channel.ID, content); err != nil {
-						logger.Warn("Failed to send message to user", zap.Error(err))
-					}
+				if err := p.appBot.dms.SendUser(session.Context(), discordID, content); err != nil {
+					logger.Warn("Failed to send message to user", zap.Error(err))
 				}
 			}
 		}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/heroiclabs/nakama/v3/server/evr"
+	"go.uber.org/zap"
+)
+
+const (
+	UnassignedLobbyReconcileInterval  = 5 * time.Minute
+	unassignedLobbyHealthcheckTries   = 3
+	unassignedLobbyHealthcheckTimeout = 10 * time.Second // The time allowed to check a single lobby
+	unassignedLobbyHealthcheckWorkers = 16               // The number of lobbies checked at once
+)
+
+// runUnassignedLobbyReconciler periodically shuts down unassigned lobbies whose game servers can no longer be reached.
+func (p *EvrPipeline) runUnassignedLobbyReconciler(ctx context.Context, logger *zap.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.reconcileUnassignedLobbies(ctx, logger); err != nil {
+				logger.Warn("Failed to reconcile unassigned lobbies", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (p *EvrPipeline) reconcileUnassignedLobbies(ctx context.Context, logger *zap.Logger) error {
+	labels, err := lobbyListLabels(ctx, p.runtimeModule, "+label.lobby_type:unassigned")
+	if err != nil {
+		if errors.Is(err, ErrMatchmakingNoAvailableServers) {
+			return nil
+		}
+		return err
+	}
+
+	// Check the lobbies concurrently, so that a pass completes well within the interval.
+	sem := make(chan struct{}, unassignedLobbyHealthcheckWorkers)
+	var wg sync.WaitGroup
+	for _, label := range labels {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(label *MatchLabel) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			ctx, cancel := context.WithTimeout(ctx, unassignedLobbyHealthcheckTimeout)
+			defer cancel()
+
+			p.reconcileUnassignedLobby(ctx, logger.With(zap.String("mid", label.ID.String())), label)
+		}(label)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// reconcileUnassignedLobby shuts down the unassigned lobby if its game server can not be reached.
+func (p *EvrPipeline) reconcileUnassignedLobby(ctx context.Context, logger *zap.Logger, label *MatchLabel) {
+	data, err := SignalMatch(ctx, p.runtimeModule, label.ID, SignalGetEndpoint, nil)
+	if err != nil {
+		// The match may have ended since it was listed.
+		logger.Debug("Failed to get endpoint of unassigned lobby", zap.Error(err))
+		return
+	}
+
+	endpoint := evr.Endpoint{}
+	if err := json.Unmarshal([]byte(data), &endpoint); err != nil {
+		logger.Warn("Failed to unmarshal endpoint", zap.Error(err))
+		return
+	}
+
+	if p.isBroadcasterReachable(ctx, endpoint) {
+		return
+	}
+
+	if ctx.Err() != nil {
+		// The check timed out before the game server could be tried; try again next pass.
+		logger.Debug("Timed out checking unassigned lobby", zap.Error(ctx.Err()))
+		return
+	}
+
+	logger.Warn("Game server could not be reached, shutting down unassigned lobby.", zap.String("endpoint", endpoint.ExternalAddress()))
+
+	if _, err := SignalMatch(ctx, p.runtimeModule, label.ID, SignalShutdown, SignalShutdownPayload{DisconnectGameServer: true}); err != nil {
+		logger.Warn("Failed to shut down unassigned lobby", zap.Error(err))
+		return
+	}
+
+	region := "default"
+	if len(label.Broadcaster.Regions) > 0 {
+		region = label.Broadcaster.Regions[0].String()
+	}
+	p.metrics.CustomCounter("broadcaster_reclaimed_count", map[string]string{"region": region}, 1)
+}
+
+func (p *EvrPipeline) isBroadcasterReachable(ctx context.Context, endpoint evr.Endpoint) bool {
+	for i := 0; i < unassignedLobbyHealthcheckTries && ctx.Err() == nil; i++ {
+		if _, err := BroadcasterHealthcheck(p.internalIP, endpoint.ExternalIP, int(endpoint.Port), 500*time.Millisecond); err == nil {
+			return true
+		}
+		if _, err := BroadcasterHealthcheck(p.internalIP, endpoint.InternalIP, int(endpoint.Port), 500*time.Millisecond); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -136,6 +136,13 @@ func (p *EvrPipeline) gameserverRegistrationRequest(ctx context.Context, logger
 		regions = append(regions, request.RegionHash)
 	}
 
+	// An operator may have corrected the regions for a mis-geolocated server.
+	if overrides, err := LoadServerRegionOverrides(ctx, p.runtimeModule); err != nil {
+		logger.Warn("Failed to load server region overrides", zap.Error(err))
+	} else if override, ok := overrides.Get(request.ServerID); ok {
+		regions = slices.Clone(override)
+	}
+
 	logger = logger.With(zap.String("discord_id", discordId), zap.Strings("group_ids", groupIDs), zap.Strings("tags", sessionParams.ServerTags), zap.Strings("regions", lo.Map(regions, func(v evr.Symbol, _ int) string { return v.String() })))
 
 	// Add the server id as a region
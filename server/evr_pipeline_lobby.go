@@ -41,6 +41,9 @@ func (p *EvrPipeline) lobbySessionRequest(ctx context.Context, logger *zap.Logge
 			if err := session.SendEvr(evr.NewLobbySessionFailure(request.GetMode(), request.GetGroupID(), evr.LobbySessionFailure_KickedFromLobbyGroup, fmt.Sprintf("Wrong version (%s). Ask for help in Echo VR Lounge.", request.GetVersionLock().String())).Version4()); err != nil {
 				logger.Error("Failed to send lobby session failure message", zap.Error(err))
 			}
+			if !p.appBot.discordEnabled() {
+				return nil
+			}
 			discordID := p.discordCache.UserIDToDiscordID(session.userID.String())
 
 			// open channel to mmember
@@ -103,6 +106,12 @@ func (p *EvrPipeline) lobbySessionRequest(ctx context.Context, logger *zap.Logge
 				logger.Warn("Failed to log audit message", zap.Error(err))
 			}
 
+			if lobbyParams.Verbose {
+				if _, err := p.appBot.LogDebugMessage(lobbyParams.GroupID.String(), fmt.Sprintf("```fix\n%s\n\n%T failed:\n %v\n```", session.Username(), in, err)); err != nil {
+					logger.Debug("Failed to log debug message", zap.Error(err))
+				}
+			}
+
 			if err := session.SendEvr(LobbySessionFailureFromError(request.GetMode(), request.GetGroupID(), err)); err != nil {
 				logger.Error("Failed to send lobby session failure message", zap.Error(err))
 			}
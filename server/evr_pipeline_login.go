@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,13 +30,72 @@ const (
 	GameProfileStorageKey        = "gameProfile"
 	RemoteLogStorageCollection   = "RemoteLogs"
 	RemoteLogStorageJournalKey   = "journal"
+
+	// NewAccountFirstLoginWindow is how long after creation a new account's first IP is authorized without a challenge.
+	NewAccountFirstLoginWindow = 24 * time.Hour
+)
+
+// Login failure reasons, used to tag the login failure metric.
+const (
+	LoginFailureInvalidXPID    = "invalid_xpid"
+	LoginFailureVersion        = "version"
+	LoginFailureAuthentication = "authentication"
+	LoginFailureNewIP          = "new_ip"
+	LoginFailureBanned         = "banned"
+	LoginFailure2FA            = "2fa"
+	LoginFailureNoGuildGroups  = "no_guild_groups"
+	LoginFailureInternal       = "internal"
 )
 
+// loginFailureError is a login error, tagged with the reason the login failed.
+type loginFailureError struct {
+	reason string
+	err    error
+}
+
+func newLoginFailure(reason string, err error) error {
+	return &loginFailureError{reason: reason, err: err}
+}
+
+func (e *loginFailureError) Error() string {
+	return e.err.Error()
+}
+
+func (e *loginFailureError) Unwrap() error {
+	return e.err
+}
+
+// loginFailureReason returns the reason the login failed, or "other" if the error is untagged.
+func loginFailureReason(err error) string {
+	var lf *loginFailureError
+	if errors.As(err, &lf) {
+		return lf.reason
+	}
+	return "other"
+}
+
 // errWithEvrIdFn prefixes an error with the EchoVR Id.
 func errWithEvrIdFn(evrId evr.XPID, format string, a ...interface{}) error {
 	return fmt.Errorf("%s: %w", evrId.Token(), fmt.Errorf(format, a...))
 }
 
+// isNewAccount returns true if the account was created recently, and has at most one linked headset.
+func isNewAccount(account *api.Account, now time.Time) bool {
+	if account.GetUser().GetCreateTime() == nil || len(account.GetDevices()) > 1 {
+		return false
+	}
+	return now.Sub(account.GetUser().GetCreateTime().AsTime()) < NewAccountFirstLoginWindow
+}
+
+// checkClientVersion returns an error telling the user to update if the client's lobby version does not match the version lock.
+// Clients that do not report a lobby version are allowed.
+func checkClientVersion(payload *evr.LoginProfile) error {
+	if payload.LobbyVersion == 0 || payload.LobbyVersion == VersionLock {
+		return nil
+	}
+	return fmt.Errorf("game version (build %d) does not match the server: update EchoVR, or use /version in Discord for help", payload.BuildVersion)
+}
+
 // msgFailedLoginFn sends a LoginFailure message to the client.
 // The error message is word-wrapped to 60 characters, 4 lines long.
 func msgFailedLoginFn(session *sessionWS, evrId evr.XPID, err error) error {
@@ -70,6 +130,7 @@ func (p *EvrPipeline) loginRequest(ctx context.Context, logger *zap.Logger, sess
 	// Authenticate the connection
 	gameSettings, err := p.processLogin(ctx, logger, session, request)
 	if err != nil {
+		p.metrics.CustomCounter("login_failure_count", map[string]string{"reason": loginFailureReason(err)}, 1)
 		st := status.Convert(err)
 		return msgFailedLoginFn(session, request.XPID, errors.New(st.Message()))
 	}
@@ -89,7 +150,7 @@ func (p *EvrPipeline) processLogin(ctx context.Context, logger *zap.Logger, sess
 
 	params, ok := LoadParams(ctx)
 	if !ok {
-		return nil, errors.New("session parameters not found")
+		return nil, newLoginFailure(LoginFailureInternal, errors.New("session parameters not found"))
 	}
 
 	payload := request.LoginData
@@ -99,7 +160,14 @@ func (p *EvrPipeline) processLogin(ctx context.Context, logger *zap.Logger, sess
 	xpid := request.GetXPID()
 
 	if xpid.IsNil() || !xpid.IsValid() {
-		return settings, fmt.Errorf("invalid xpid: %s", xpid.Token())
+		return settings, newLoginFailure(LoginFailureInvalidXPID, fmt.Errorf("invalid xpid: %s", xpid.Token()))
+	}
+
+	// Reject incompatible clients up front, rather than letting them fail later in matchmaking.
+	if err := checkClientVersion(&payload); err != nil {
+		logger.Info("Incompatible client version", zap.Int64("build_version", payload.BuildVersion), zap.String("lobby_version", evr.Symbol(payload.LobbyVersion).HexString()), zap.Uint64("app_id", payload.AppId))
+		p.metrics.CustomCounter("login_incompatible_version_count", map[string]string{"build_version": strconv.FormatInt(payload.BuildVersion, 10)}, 1)
+		return settings, newLoginFailure(LoginFailureVersion, err)
 	}
 
 	params.LoginSession.Store(session)
@@ -121,38 +189,71 @@ func (p *EvrPipeline) processLogin(ctx context.Context, logger *zap.Logger, sess
 
 	if authErr != nil && account == nil {
 		// Headset is not linked to an account.
-		return settings, authErr
+		return settings, newLoginFailure(LoginFailureAuthentication, authErr)
 	}
 
 	if account == nil {
-		return settings, fmt.Errorf("account is nil: %w", authErr)
+		return settings, newLoginFailure(LoginFailureAuthentication, fmt.Errorf("account is nil: %w", authErr))
 	}
 
 	// add the login attempt to the login history
 	loginHistory, err := LoginHistoryLoad(ctx, p.runtimeModule, account.User.Id)
 	if err != nil {
-		return settings, fmt.Errorf("failed to load login history: %w", err)
+		return settings, newLoginFailure(LoginFailureInternal, fmt.Errorf("failed to load login history: %w", err))
 	}
 	defer loginHistory.Store(ctx, p.runtimeModule)
 
 	params.LoginHistory.Store(loginHistory)
 
 	loginHistory.UpdateAlternateUserIDs(ctx, p.runtimeModule)
+	isFirstLogin := isNewAccount(account, time.Now()) && len(loginHistory.AuthorizedIPs) == 0
+	previousLogin := loginHistory.LastEntry()
 	loginHistory.Update(xpid, session.clientIP, &payload)
 
+	// Flag logins that are geographically implausible given the previous login
+	if previousLogin != nil && previousLogin.ClientIP != session.clientIP && p.ipqsClient != nil {
+		previousLocation := p.ipqsClient.IPDetailsWithTimeout(previousLogin.ClientIP)
+		location := p.ipqsClient.IPDetailsWithTimeout(session.clientIP)
+		if anomaly := loginHistory.CheckLocationAnomaly(previousLogin, previousLocation, session.clientIP, location, time.Now()); anomaly != nil {
+			logger.Warn("Implausible login location detected.", zap.String("uid", account.User.Id), zap.Any("anomaly", anomaly))
+
+			if p.config.GetRuntime().Environment["NOTIFY_LOCATION_ANOMALY"] == "true" && p.appBot.discordEnabled() {
+				go func() {
+					if err := p.appBot.SendLocationAnomalyNotice(p.ctx, account.User.Id, anomaly); err != nil {
+						logger.Warn("Failed to send location anomaly notice", zap.Error(err))
+					}
+				}()
+			}
+		}
+	}
+
 	if session.UserID().IsNil() {
 		// Validate the clientIP
-		if ok := loginHistory.IsAuthorizedIP(session.ClientIP()); !ok {
+		if isFirstLogin {
+			// A brand-new account has no other IP to compare against, so its first IP is authorized.
+			loginHistory.AuthorizeIP(session.clientIP)
+
+			if p.appBot.discordEnabled() {
+				go func() {
+					if err := p.appBot.SendFirstLoginNotice(p.ctx, account.User.Id, session.clientIP); err != nil {
+						logger.Warn("Failed to send first login notice", zap.Error(err))
+					}
+				}()
+			}
+		} else if ok := loginHistory.IsAuthorizedIP(session.ClientIP()); !ok {
 
-			if p.appBot != nil && p.appBot.dg != nil && p.appBot.dg.State != nil && p.appBot.dg.State.User != nil {
-				ipqs := p.ipqsClient.IPDetailsWithTimeout(session.ClientIP())
-				if err := p.appBot.SendIPApprovalRequest(ctx, account.User.Id, session.ClientIP(), ipqs); err != nil {
-					return settings, fmt.Errorf("failed to send IP approval request: %w", err)
-				}
-				return settings, fmt.Errorf("New location detected.\nPlease check your Discord DMs to accept the \nverification request from @%s.", p.appBot.dg.State.User.Username)
-			} else {
-				return settings, errors.New("New IP address detected. Please check your Discord DMs for a verification request.")
+			var ipqs *IPQSResponse
+			if p.ipqsClient != nil {
+				ipqs = p.ipqsClient.IPDetailsWithTimeout(session.ClientIP())
+			}
+
+			// Without a Discord session, fall back to the plain message.
+			if err := p.appBot.SendIPApprovalRequest(ctx, account.User.Id, session.ClientIP(), ipqs); err != nil && !errors.Is(err, ErrDiscordDisabled) {
+				return settings, newLoginFailure(LoginFailureNewIP, fmt.Errorf("failed to send IP approval request: %w", err))
+			} else if err == nil && p.appBot.dg.State != nil && p.appBot.dg.State.User != nil {
+				return settings, newLoginFailure(LoginFailureNewIP, errors.New(GlobalSettings().NewLocationMessage(account.User.GetLangTag(), p.appBot.dg.State.User.Username)))
 			}
+			return settings, newLoginFailure(LoginFailureNewIP, errors.New("New IP address detected. Please check your Discord DMs for a verification request."))
 
 		}
 	} else {
@@ -169,11 +270,11 @@ func (p *EvrPipeline) processLogin(ctx context.Context, logger *zap.Logger, sess
 			zap.String("uid", account.User.Id),
 			zap.Any("login_payload", payload))
 
-		return settings, fmt.Errorf("User account banned.")
+		return settings, newLoginFailure(LoginFailureBanned, fmt.Errorf("User account banned."))
 	}
 
 	if authErr != nil {
-		return settings, authErr
+		return settings, newLoginFailure(LoginFailureAuthentication, authErr)
 	}
 
 	user := account.GetUser()
@@ -192,7 +293,7 @@ func (p *EvrPipeline) processLogin(ctx context.Context, logger *zap.Logger, sess
 	// Get the user's metadata
 	metadata, err := GetAccountMetadata(ctx, p.runtimeModule, userID)
 	if err != nil {
-		return settings, fmt.Errorf("failed to get user metadata: %w", err)
+		return settings, newLoginFailure(LoginFailureInternal, fmt.Errorf("failed to get user metadata: %w", err))
 	}
 	params.AccountMetadata = metadata
 
@@ -200,26 +301,15 @@ func (p *EvrPipeline) processLogin(ctx context.Context, logger *zap.Logger, sess
 
 	// Check if this user is required to use 2FA
 	if found, err := CheckSystemGroupMembership(ctx, p.db, uid.String(), GroupGlobalRequire2FA); err != nil {
-		if found {
-			allowed := make(chan bool)
-			go func() {
-				ok, err := p.discordCache.CheckUser2FA(ctx, uid)
-				if err != nil {
-					logger.Warn("Failed to check 2FA", zap.Error(err))
-					allowed <- true
-				}
-				allowed <- ok
-			}()
-			// Check if this user has 2FA enabled
-			select {
-			case <-ctx.Done():
-				return settings, ctx.Err()
-			case ok := <-allowed:
-				if !ok {
-					return settings, fmt.Errorf("you must enable 2FA on your Discord account to play")
-				}
-			case <-time.After(time.Second * 2):
-			}
+		logger.Warn("Failed to check 2FA requirement", zap.Error(err))
+		return settings, newLoginFailure(LoginFailure2FA, errors.New("unable to verify your 2FA requirement, please try again later"))
+	} else if !found {
+		// Reset the grace period, in case the requirement is applied again
+		loginHistory.Require2FALogins = 0
+	} else {
+		loginHistory.Require2FALogins++
+		if err := p.checkUser2FA(ctx, logger, uid, loginHistory.Require2FALogins); err != nil {
+			return settings, newLoginFailure(LoginFailure2FA, err)
 		}
 	}
 
@@ -227,7 +317,7 @@ func (p *EvrPipeline) processLogin(ctx context.Context, logger *zap.Logger, sess
 	profile, err := p.profileRegistry.GameProfile(ctx, logger, uuid.FromStringOrNil(userID), request.LoginData, xpid)
 	if err != nil {
 		session.logger.Error("failed to load game profiles", zap.Error(err))
-		return evr.NewDefaultGameSettings(), fmt.Errorf("failed to load game profiles")
+		return evr.NewDefaultGameSettings(), newLoginFailure(LoginFailureInternal, fmt.Errorf("failed to load game profiles"))
 	}
 
 	// Get the GroupID from the user's metadata
@@ -241,7 +331,7 @@ func (p *EvrPipeline) processLogin(ctx context.Context, logger *zap.Logger, sess
 
 	groups, err := UserGuildGroupsList(ctx, p.runtimeModule, userID)
 	if err != nil {
-		return settings, fmt.Errorf("failed to get guild groups: %w", err)
+		return settings, newLoginFailure(LoginFailureInternal, fmt.Errorf("failed to get guild groups: %w", err))
 	}
 
 	for _, g := range groups {
@@ -250,10 +340,10 @@ func (p *EvrPipeline) processLogin(ctx context.Context, logger *zap.Logger, sess
 
 	memberships, err := GetGuildGroupMemberships(ctx, p.runtimeModule, userID)
 	if err != nil {
-		return settings, fmt.Errorf("failed to get guild groups: %w", err)
+		return settings, newLoginFailure(LoginFailureInternal, fmt.Errorf("failed to get guild groups: %w", err))
 	}
 	if len(memberships) == 0 {
-		return settings, fmt.Errorf("user is not in any guild groups")
+		return settings, newLoginFailure(LoginFailureNoGuildGroups, fmt.Errorf("user is not in any guild groups"))
 	}
 
 	var found bool
@@ -309,7 +399,7 @@ func (p *EvrPipeline) processLogin(ctx context.Context, logger *zap.Logger, sess
 	}
 
 	if ismember, err := CheckSystemGroupMembership(ctx, p.db, session.userID.String(), GroupGlobalDevelopers); err != nil {
-		return settings, fmt.Errorf("failed to check system group membership: %w", err)
+		return settings, newLoginFailure(LoginFailureInternal, fmt.Errorf("failed to check system group membership: %w", err))
 	} else if ismember {
 		params.IsGlobalDeveloper.Store(true)
 	}
@@ -324,14 +414,16 @@ func (p *EvrPipeline) processLogin(ctx context.Context, logger *zap.Logger, sess
 		logger.Warn("Failed to set display name history", zap.Error(err))
 	}
 
-	// Update the user's metadata
-	if err := p.runtimeModule.AccountUpdateId(ctx, userID, "", metadata.MarshalMap(), metadata.GetActiveGroupDisplayName(), "", "", "", ""); err != nil {
-		return settings, fmt.Errorf("failed to update user metadata: %w", err)
+	// Update the user's metadata, if it has changed
+	if metadata.IsChanged(displayName) {
+		if err := p.runtimeModule.AccountUpdateId(ctx, userID, "", metadata.MarshalMap(), displayName, "", "", "", ""); err != nil {
+			return settings, newLoginFailure(LoginFailureInternal, fmt.Errorf("failed to update user metadata: %w", err))
+		}
 	}
 
 	// Initialize the full session
 	if err := session.SetIdentity(uuid.FromStringOrNil(userID), xpid, account.User.Username); err != nil {
-		return settings, fmt.Errorf("failed to login: %w", err)
+		return settings, newLoginFailure(LoginFailureInternal, fmt.Errorf("failed to login: %w", err))
 	}
 	ctx = session.Context()
 
@@ -339,7 +431,10 @@ func (p *EvrPipeline) processLogin(ctx context.Context, logger *zap.Logger, sess
 	profile.SetChannel(evr.GUID(metadata.GetActiveGroupID()))
 	profile.UpdateDisplayName(displayName)
 
-	p.profileRegistry.SaveAndCache(ctx, session.userID, profile)
+	// The profile is saved once, after all of the login changes have been applied.
+	if err := p.profileRegistry.SaveAndCache(ctx, session.userID, profile); err != nil {
+		logger.Warn("Failed to save profile", zap.Error(err))
+	}
 	/*
 		session.SendEvr(&evr.EarlyQuitConfig{
 			SteadyPlayerLevel: 1,
@@ -354,6 +449,56 @@ func (p *EvrPipeline) processLogin(ctx context.Context, logger *zap.Logger, sess
 	return settings, nil
 }
 
+// checkUser2FA verifies that the user has 2FA enabled on their Discord account. Logins within the grace period are
+// allowed without 2FA. A failed 2FA lookup rejects the login, and a timed out one is handled according to the configured
+// timeout action.
+func (p *EvrPipeline) checkUser2FA(ctx context.Context, logger *zap.Logger, userID uuid.UUID, loginCount int) error {
+	if p.discordCache == nil {
+		return nil
+	}
+
+	type result struct {
+		enabled bool
+		err     error
+	}
+
+	resultCh := make(chan result, 1)
+	go func() {
+		enabled, err := p.discordCache.CheckUser2FA(ctx, userID)
+		resultCh <- result{enabled, err}
+	}()
+
+	unavailableFn := func() error {
+		if p.require2FAAllowOnTimeout {
+			return nil
+		}
+		return errors.New("unable to verify your Discord 2FA status, please try again later")
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(p.require2FATimeout):
+		logger.Warn("2FA check timed out", zap.Duration("timeout", p.require2FATimeout), zap.Bool("allow", p.require2FAAllowOnTimeout))
+		return unavailableFn()
+	case r := <-resultCh:
+		if r.err != nil {
+			logger.Warn("Failed to check 2FA", zap.Error(r.err))
+			return errors.New("unable to verify your Discord 2FA status, please try again later")
+		}
+		if r.enabled {
+			return nil
+		}
+	}
+
+	if loginCount <= p.require2FAGraceLogins {
+		logger.Info("Allowing login without 2FA during grace period", zap.Int("login_count", loginCount), zap.Int("grace_logins", p.require2FAGraceLogins))
+		return nil
+	}
+
+	return fmt.Errorf("you must enable 2FA on your Discord account to play")
+}
+
 type XPIDHistory struct {
 	Created time.Time
 	Updated time.Time
@@ -405,6 +550,32 @@ func (p *EvrPipeline) authenticateHeadset(ctx context.Context, logger *zap.Logge
 		return account, err
 	}
 
+	// A one-time login code from Discord links the headset to that user's account.
+	if params, ok := LoadParams(session.Context()); ok && params.AuthLoginCode != "" {
+		if ticket, err := ExchangeLoginCode(ctx, p.runtimeModule, params.AuthLoginCode); err != nil {
+			logger.Warn("Failed to exchange login code", zap.Error(err))
+		} else {
+			if err := p.runtimeModule.LinkDevice(ctx, ticket.UserID, xpid.Token()); err != nil {
+				return account, status.Errorf(codes.Internal, "error linking device: %s", err)
+			}
+
+			history, err := LoginHistoryLoad(ctx, p.runtimeModule, ticket.UserID)
+			if err != nil {
+				return account, status.Errorf(codes.Internal, "failed to load login history: %s", err)
+			}
+			history.AuthorizeIP(clientIP)
+			if err := LoginHistoryStore(ctx, p.runtimeModule, ticket.UserID, history); err != nil {
+				return account, status.Errorf(codes.Internal, "failed to save login history: %s", err)
+			}
+
+			account, err = GetAccount(ctx, logger, session.pipeline.db, session.statusRegistry, uuid.FromStringOrNil(ticket.UserID))
+			if err != nil {
+				return account, status.Error(codes.Internal, fmt.Sprintf("failed to get account: %s", err))
+			}
+			return account, nil
+		}
+	}
+
 	// Account requires discord linking.
 	linkTicket, err := p.linkTicket(ctx, logger, xpid, clientIP, &payload)
 	if err != nil {
@@ -444,6 +615,30 @@ func (p *EvrPipeline) validateDeviceID(ctx context.Context, logger *zap.Logger,
 	return account, nil
 }
 
+const ChannelInfoGroupCount = 4
+
+// channelInfoGroups returns the guild groups shown in the in-game channel list; the active group first, then the rest by name.
+func channelInfoGroups(guildGroups map[string]*GuildGroup, activeGroupID string) []*GuildGroup {
+	groups := make([]*GuildGroup, 0, len(guildGroups))
+	for _, g := range guildGroups {
+		if g.ID().String() != activeGroupID {
+			groups = append(groups, g)
+		}
+	}
+	slices.SortFunc(groups, func(a, b *GuildGroup) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	if g, ok := guildGroups[activeGroupID]; ok {
+		groups = append([]*GuildGroup{g}, groups...)
+	}
+
+	if len(groups) > ChannelInfoGroupCount {
+		groups = groups[:ChannelInfoGroupCount]
+	}
+	return groups
+}
+
 func (p *EvrPipeline) channelInfoRequest(ctx context.Context, logger *zap.Logger, session *sessionWS, in evr.Message) error {
 	_ = in.(*evr.ChannelInfoRequest)
 
@@ -459,28 +654,33 @@ func (p *EvrPipeline) channelInfoRequest(ctx context.Context, logger *zap.Logger
 		groupID = params.AccountMetadata.GetActiveGroupID()
 	}
 
-	key := fmt.Sprintf("channelInfo,%s", groupID.String())
+	guildGroups := params.GuildGroupsLoad()
+	if guildGroups == nil {
+		return errors.New("guild groups not found")
+	}
+
+	groups := channelInfoGroups(guildGroups, groupID.String())
+	if len(groups) == 0 {
+		return errors.New("no guild groups found")
+	}
+
+	groupIDs := make([]string, 0, len(groups))
+	for _, g := range groups {
+		groupIDs = append(groupIDs, g.ID().String())
+	}
+	key := fmt.Sprintf("channelInfo,%s", strings.Join(groupIDs, ","))
 
 	// Check the cache first
 	message := p.GetCachedMessage(key)
 
 	if message == nil {
 
-		params, ok := LoadParams(ctx)
-		if !ok {
-			return errors.New("session parameters not found")
-		}
-
-		guildGroups := params.GuildGroupsLoad()
-		if guildGroups == nil {
-			return errors.New("guild groups not found")
-		}
-		g, ok := guildGroups[groupID.String()]
-
 		resource := evr.NewChannelInfoResource()
 
-		resource.Groups = make([]evr.ChannelGroup, 4)
+		// The client expects four channels, so repeat the groups if the user has fewer.
+		resource.Groups = make([]evr.ChannelGroup, ChannelInfoGroupCount)
 		for i := range resource.Groups {
+			g := groups[i%len(groups)]
 			resource.Groups[i] = evr.ChannelGroup{
 				ChannelUuid:  strings.ToUpper(g.ID().String()),
 				Name:         g.Name(),
@@ -493,10 +693,6 @@ func (p *EvrPipeline) channelInfoRequest(ctx context.Context, logger *zap.Logger
 			}
 		}
 
-		if resource == nil {
-			resource = evr.NewChannelInfoResource()
-		}
-
 		message = evr.NewSNSChannelInfoResponse(resource)
 		p.CacheMessage(key, message)
 	}
@@ -596,7 +792,9 @@ func (p *EvrPipeline) handleClientProfileUpdate(ctx context.Context, logger *zap
 					return fmt.Errorf("error updating group: %w", err)
 				}
 
-				p.appBot.LogMessageToChannel(fmt.Sprintf("User <@%s> has accepted the community values.", params.DiscordID), gg.AuditChannelID)
+				if _, err := p.appBot.LogAuditMessage(ctx, gg.ID().String(), fmt.Sprintf("User <@%s> has accepted the community values.", params.DiscordID), false); err != nil && !errors.Is(err, ErrDiscordDisabled) {
+					logger.Warn("Failed to send audit message", zap.Error(err))
+				}
 			}
 		}
 	}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama/v3/server/evr"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCheckClientVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		lobbyVersion uint64
+		wantErr      bool
+	}{
+		{"matching version", VersionLock, false},
+		{"unreported version", 0, false},
+		{"mismatched version", VersionLock + 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := &evr.LoginProfile{BuildVersion: 631547, LobbyVersion: tt.lobbyVersion}
+			if err := checkClientVersion(payload); (err != nil) != tt.wantErr {
+				t.Errorf("checkClientVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsNewAccount(t *testing.T) {
+	now := time.Now()
+	newAccount := func(age time.Duration, devices int) *api.Account {
+		account := &api.Account{
+			User: &api.User{CreateTime: timestamppb.New(now.Add(-age))},
+		}
+		for i := 0; i < devices; i++ {
+			account.Devices = append(account.Devices, &api.AccountDevice{})
+		}
+		return account
+	}
+
+	tests := []struct {
+		name    string
+		account *api.Account
+		want    bool
+	}{
+		{"recently created, one headset", newAccount(time.Hour, 1), true},
+		{"old account", newAccount(NewAccountFirstLoginWindow+time.Hour, 1), false},
+		{"recently created, several headsets", newAccount(time.Hour, 2), false},
+		{"no create time", &api.Account{User: &api.User{}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNewAccount(tt.account, now); got != tt.want {
+				t.Errorf("isNewAccount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
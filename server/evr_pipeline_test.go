@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"net"
 	"os"
@@ -81,7 +82,7 @@ func TestProcessOutgoingNotificationConnectionInfo(t *testing.T) {
 			name: "Connection info",
 			args: args{
 				logger:  testLogger,
-				session: &sessionWS{},
+				session: &sessionWS{ctx: context.Background()},
 				in: &rtapi.Envelope{
 					Cid: "1",
 					Message: &rtapi.Envelope_Notifications{
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+const (
+	PlayerReportStorageCollection = "PlayerReports"
+	PlayerReportStorageKey        = "reports"
+	PlayerReportReasonMaxLength   = 500
+	PlayerReportHistoryLimit      = 100 // The number of reports kept for each player
+)
+
+type PlayerReport struct {
+	ReporterID string    `json:"reporter_id"`
+	GroupID    string    `json:"group_id"`
+	Reason     string    `json:"reason"`
+	MatchID    string    `json:"match_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// PlayerReports are the reports made against a player, oldest first.
+type PlayerReports struct {
+	Reports []PlayerReport `json:"reports"`
+}
+
+// Add appends the report, and drops the oldest reports beyond the history limit.
+func (r *PlayerReports) Add(report PlayerReport) {
+	r.Reports = append(r.Reports, report)
+	if len(r.Reports) > PlayerReportHistoryLimit {
+		r.Reports = r.Reports[len(r.Reports)-PlayerReportHistoryLimit:]
+	}
+}
+
+// CountByGroup returns the number of reports made in the guild group.
+func (r *PlayerReports) CountByGroup(groupID string) int {
+	count := 0
+	for _, report := range r.Reports {
+		if report.GroupID == groupID {
+			count++
+		}
+	}
+	return count
+}
+
+func LoadPlayerReports(ctx context.Context, nk runtime.NakamaModule, userID string) (*PlayerReports, error) {
+	objs, err := nk.StorageRead(ctx, []*runtime.StorageRead{
+		{
+			Collection: PlayerReportStorageCollection,
+			Key:        PlayerReportStorageKey,
+			UserID:     userID,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read player reports: %w", err)
+	}
+
+	reports := &PlayerReports{
+		Reports: make([]PlayerReport, 0),
+	}
+	if len(objs) > 0 {
+		if err := json.Unmarshal([]byte(objs[0].Value), reports); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal player reports: %w", err)
+		}
+	}
+	return reports, nil
+}
+
+func StorePlayerReports(ctx context.Context, nk runtime.NakamaModule, userID string, reports *PlayerReports) error {
+	data, err := json.Marshal(reports)
+	if err != nil {
+		return fmt.Errorf("failed to marshal player reports: %w", err)
+	}
+
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{
+		{
+			Collection:      PlayerReportStorageCollection,
+			Key:             PlayerReportStorageKey,
+			UserID:          userID,
+			PermissionRead:  0,
+			PermissionWrite: 0,
+			Value:           string(data),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to write player reports: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package server
+
+import (
+	"testing"
+)
+
+func TestPlayerReports_Add(t *testing.T) {
+	reports := &PlayerReports{}
+	for i := 0; i < PlayerReportHistoryLimit+5; i++ {
+		groupID := "a"
+		if i%2 == 0 {
+			groupID = "b"
+		}
+		reports.Add(PlayerReport{GroupID: groupID, Reason: "reason"})
+	}
+
+	if len(reports.Reports) != PlayerReportHistoryLimit {
+		t.Errorf("expected %d reports, got %d", PlayerReportHistoryLimit, len(reports.Reports))
+	}
+
+	if got := reports.CountByGroup("a") + reports.CountByGroup("b"); got != PlayerReportHistoryLimit {
+		t.Errorf("expected the group counts to total %d, got %d", PlayerReportHistoryLimit, got)
+	}
+}
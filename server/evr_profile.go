@@ -31,6 +31,7 @@ type GameProfile interface {
 	UpdateUnlocks(unlocks evr.UnlockedCosmetics) error
 	IsStale() bool
 	SetStale()
+	SetSaved(version string)
 }
 
 type GameProfileData struct {
@@ -139,6 +140,23 @@ func (p *GameProfileData) SetJerseyNumber(number int) {
 	p.SetStale()
 }
 
+// JerseyNumberDecal is the decal that displays the jersey number in-game.
+const JerseyNumberDecal = "loadout_number"
+
+// JerseyNumberDisplayed returns true if the equipped decal displays the jersey number.
+func (p *GameProfileData) JerseyNumberDisplayed() bool {
+	return p.Server.EquippedCosmetics.Instances.Unified.Slots.Decal == JerseyNumberDecal
+}
+
+// EquipJerseyNumberDecal equips the decal that displays the jersey number.
+func (p *GameProfileData) EquipJerseyNumberDecal() {
+	if p.JerseyNumberDisplayed() {
+		return
+	}
+	p.Server.EquippedCosmetics.Instances.Unified.Slots.Decal = JerseyNumberDecal
+	p.SetStale()
+}
+
 func (p *GameProfileData) SetChannel(c evr.GUID) {
 	if p.Server.Social.Channel == c && p.Client.Social.Channel == c {
 		return
@@ -234,6 +252,12 @@ func (p *GameProfileData) SetStale() {
 	p.Client.ModifyTime = time.Now().UTC().Unix()
 }
 
+// SetSaved marks the profile as saved at the given version, so that it is not saved again until it is changed.
+func (p *GameProfileData) SetSaved(version string) {
+	p.Version = version
+	p.Stale = false
+}
+
 func (p *GameProfileData) IsStale() bool {
 	return p.Stale
 }
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+
+	"github.com/heroiclabs/nakama/v3/server/evr"
+)
+
+const (
+	DefaultProfileCacheMaxSize = 10000
+)
+
+type profileCacheEntry struct {
+	xpID evr.XPID
+	data *json.RawMessage
+}
+
+// profileCache is a least-recently-used cache of serialized server profiles.
+type profileCache struct {
+	sync.Mutex
+	maxSize int // Zero or less is unbounded
+	entries map[evr.XPID]*list.Element
+	order   *list.List // Most recently used at the front
+}
+
+func newProfileCache(maxSize int) *profileCache {
+	return &profileCache{
+		maxSize: maxSize,
+		entries: make(map[evr.XPID]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (c *profileCache) Get(xpID evr.XPID) (*json.RawMessage, bool) {
+	c.Lock()
+	defer c.Unlock()
+	if e, ok := c.entries[xpID]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*profileCacheEntry).data, true
+	}
+	return nil, false
+}
+
+// Set adds or replaces the profile, returning the number of profiles evicted to stay within the size bound.
+func (c *profileCache) Set(xpID evr.XPID, data *json.RawMessage) int {
+	c.Lock()
+	defer c.Unlock()
+
+	if e, ok := c.entries[xpID]; ok {
+		e.Value.(*profileCacheEntry).data = data
+		c.order.MoveToFront(e)
+		return 0
+	}
+
+	c.entries[xpID] = c.order.PushFront(&profileCacheEntry{xpID: xpID, data: data})
+
+	evicted := 0
+	for c.maxSize > 0 && c.order.Len() > c.maxSize {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*profileCacheEntry).xpID)
+		evicted++
+	}
+	return evicted
+}
+
+func (c *profileCache) Delete(xpID evr.XPID) {
+	c.Lock()
+	defer c.Unlock()
+	if e, ok := c.entries[xpID]; ok {
+		c.order.Remove(e)
+		delete(c.entries, xpID)
+	}
+}
+
+func (c *profileCache) Keys() []evr.XPID {
+	c.Lock()
+	defer c.Unlock()
+	keys := make([]evr.XPID, 0, len(c.entries))
+	for k := range c.entries {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (c *profileCache) Len() int {
+	c.Lock()
+	defer c.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/heroiclabs/nakama/v3/server/evr"
+)
+
+func TestProfileCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newProfileCache(2)
+
+	a := evr.NewXPID(evr.OVR, 1)
+	b := evr.NewXPID(evr.OVR, 2)
+	c := evr.NewXPID(evr.OVR, 3)
+	data := json.RawMessage(`{}`)
+
+	if n := cache.Set(a, &data); n != 0 {
+		t.Errorf("Set() evicted = %d, want 0", n)
+	}
+	cache.Set(b, &data)
+
+	// Touch a, so that b is the least recently used
+	if _, ok := cache.Get(a); !ok {
+		t.Fatalf("Get() did not find %s", a)
+	}
+
+	if n := cache.Set(c, &data); n != 1 {
+		t.Errorf("Set() evicted = %d, want 1", n)
+	}
+
+	if _, ok := cache.Get(b); ok {
+		t.Errorf("Get() found evicted profile %s", b)
+	}
+	for _, xpID := range []evr.XPID{a, c} {
+		if _, ok := cache.Get(xpID); !ok {
+			t.Errorf("Get() did not find %s", xpID)
+		}
+	}
+
+	if got := cache.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+}
+
+func TestProfileCache_Unbounded(t *testing.T) {
+	cache := newProfileCache(0)
+	data := json.RawMessage(`{}`)
+
+	for i := 1; i <= 100; i++ {
+		if n := cache.Set(evr.NewXPID(evr.OVR, evr.AccountID(i)), &data); n != 0 {
+			t.Fatalf("Set() evicted = %d, want 0", n)
+		}
+	}
+
+	cache.Delete(evr.NewXPID(evr.OVR, 1))
+
+	if got := cache.Len(); got != 99 {
+		t.Errorf("Len() = %d, want 99", got)
+	}
+}
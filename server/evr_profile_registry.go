@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"math/rand"
 	"reflect"
-	"sync"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
@@ -33,13 +32,12 @@ type ProfileRegistry struct {
 	// Unlocks by item name
 	unlocksByItemName map[string]string
 
-	cacheMu sync.RWMutex
-	cache   map[evr.XPID]*json.RawMessage
+	cache *profileCache
 	// Load out default items
 	defaults map[string]string
 }
 
-func NewProfileRegistry(nk runtime.NakamaModule, db *sql.DB, logger runtime.Logger, tracker Tracker, metrics Metrics) *ProfileRegistry {
+func NewProfileRegistry(nk runtime.NakamaModule, db *sql.DB, logger runtime.Logger, tracker Tracker, metrics Metrics, cacheMaxSize int) *ProfileRegistry {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	unlocksByFieldName := createUnlocksFieldByKey()
@@ -53,7 +51,7 @@ func NewProfileRegistry(nk runtime.NakamaModule, db *sql.DB, logger runtime.Logg
 		tracker:     tracker,
 		metrics:     metrics,
 
-		cache: make(map[evr.XPID]*json.RawMessage),
+		cache: newProfileCache(cacheMaxSize),
 
 		unlocksByItemName: unlocksByFieldName,
 		defaults:          generateDefaultLoadoutMap(),
@@ -68,17 +66,16 @@ func NewProfileRegistry(nk runtime.NakamaModule, db *sql.DB, logger runtime.Logg
 				ticker.Stop()
 				return
 			case <-ticker.C:
-				profileRegistry.cacheMu.Lock()
-				for evrId := range profileRegistry.cache {
+				for _, evrId := range profileRegistry.cache.Keys() {
 					if tracker.CountByStream(PresenceStream{
 						Mode:    StreamModeService,
 						Subject: evrId.UUID(),
 						Label:   StreamLabelMatchService,
 					}) == 0 {
-						delete(profileRegistry.cache, evrId)
+						profileRegistry.cache.Delete(evrId)
 					}
 				}
-				profileRegistry.cacheMu.Unlock()
+				metrics.CustomGauge("profile_cache_size", nil, float64(profileRegistry.cache.Len()))
 			}
 		}
 	}()
@@ -136,7 +133,7 @@ func (r *ProfileRegistry) Save(ctx context.Context, userID uuid.UUID, profile Ga
 	if err != nil {
 		return err
 	}
-	_, err = r.nk.StorageWrite(ctx, []*runtime.StorageWrite{
+	acks, err := r.nk.StorageWrite(ctx, []*runtime.StorageWrite{
 		{
 			Collection: GameProfileStorageCollection,
 			Key:        GameProfileStorageKey,
@@ -148,11 +145,12 @@ func (r *ProfileRegistry) Save(ctx context.Context, userID uuid.UUID, profile Ga
 	if err != nil {
 		return err
 	}
+	if len(acks) > 0 {
+		profile.SetSaved(acks[0].GetVersion())
+	}
 
 	// Purge the cache
-	r.cacheMu.Lock()
-	delete(r.cache, profile.GetXPID())
-	r.cacheMu.Unlock()
+	r.cache.Delete(profile.GetXPID())
 
 	return err
 }
@@ -189,29 +187,30 @@ func (r *ProfileRegistry) SaveAndCache(ctx context.Context, userID uuid.UUID, pr
 		return err
 	}
 
-	r.cacheMu.Lock()
-	r.cache[serverProfile.XPID] = &data
-	defer r.cacheMu.Unlock()
+	r.cacheSet(serverProfile.XPID, &data)
 
 	return err
 }
 
+func (r *ProfileRegistry) cacheSet(xpID evr.XPID, data *json.RawMessage) {
+	if evicted := r.cache.Set(xpID, data); evicted > 0 {
+		r.metrics.CustomCounter("profile_cache_evicted", nil, int64(evicted))
+	}
+}
+
 // Retrieves the bytes of a server profile from the cache.
 func (s *ProfileRegistry) GetCached(ctx context.Context, xpID evr.XPID) (*json.RawMessage, error) {
-	s.cacheMu.RLock()
-	if data, ok := s.cache[xpID]; ok {
-		s.cacheMu.RUnlock()
+	if data, ok := s.cache.Get(xpID); ok {
+		s.metrics.CustomCounter("profile_cache_hit", nil, 1)
 		return data, nil
 	}
-	s.cacheMu.RUnlock()
+	s.metrics.CustomCounter("profile_cache_miss", nil, 1)
 
 	_, data, err := StorageReadEVRProfileByXPI(ctx, s.db, xpID)
 	if err != nil {
 		return nil, err
 	}
-	s.cacheMu.Lock()
-	s.cache[xpID] = &data
-	s.cacheMu.Unlock()
+	s.cacheSet(xpID, &data)
 
 	return &data, err
 }
@@ -305,8 +304,7 @@ func (r *ProfileRegistry) GameProfile(ctx context.Context, logger *zap.Logger, u
 	p.Server.CreateTime = time.Date(2023, 10, 31, 0, 0, 0, 0, time.UTC).Unix()
 	p.SetStale()
 
-	r.SaveAndCache(ctx, userID, p)
-
+	// The caller saves the profile, once it has finished updating it.
 	return p, nil
 }
 func (r *ProfileRegistry) UpdateClientProfile(ctx context.Context, logger *zap.Logger, session *sessionWS, update evr.ClientProfile) (err error) {
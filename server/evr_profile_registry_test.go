@@ -24,7 +24,7 @@ func createTestProfileRegistry(t *testing.T, logger *zap.Logger) (*ProfileRegist
 	db := NewDB(t)
 	nk := NewRuntimeGoNakamaModule(logger, db, nil, cfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
-	profileRegistry := NewProfileRegistry(nk, db, runtimeLogger, tracker, metrics)
+	profileRegistry := NewProfileRegistry(nk, db, runtimeLogger, tracker, metrics, DefaultProfileCacheMaxSize)
 
 	return profileRegistry, nil
 }
@@ -251,3 +251,15 @@ type testProfileRegistry struct {
 func (r *testProfileRegistry) Load(userID uuid.UUID) (*GameProfileData, bool) {
 	return r.mockLoadFn(userID)
 }
+
+func TestEquipJerseyNumberDecal(t *testing.T) {
+	profile := &GameProfileData{}
+	profile.Server.EquippedCosmetics.Instances.Unified.Slots.Decal = "decal_default"
+
+	assert.False(t, profile.JerseyNumberDisplayed())
+
+	profile.EquipJerseyNumberDecal()
+
+	assert.True(t, profile.JerseyNumberDisplayed())
+	assert.True(t, profile.Stale)
+}
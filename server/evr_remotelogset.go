@@ -125,7 +125,7 @@ func (p *EvrPipeline) processRemoteLogSets(ctx context.Context, logger *zap.Logg
 				logger.Warn("Failed to create match ID", zap.Error(err), zap.Any("msg", msg))
 			} else {
 				update, _ = updates.LoadOrStore(matchID.UUID, &MatchGameStateUpdate{})
-				update.CurrentGameClock = time.Duration(msg.GameTime()) * time.Second
+				update.CurrentGameClock = msg.GameTime()
 			}
 		}
 		logger := logger.With(zap.String("message_type", fmt.Sprintf("%T", e.Parsed)))
@@ -315,6 +315,9 @@ func (p *EvrPipeline) processRemoteLogSets(ctx context.Context, logger *zap.Logg
 				logger.Error("Failed to marshal message content", zap.Error(err))
 			}
 			p.appBot.LogUserErrorMessage(ctx, label.GetGroupID().String(), fmt.Sprintf("```json\n%s\n```", string(contentData)), false)
+			if _, err := p.appBot.LogDebugMessage(label.GetGroupID().String(), fmt.Sprintf("Server connection failed for <@%s> in match `%s`.", params.DiscordID, label.ID.String())); err != nil {
+				logger.Debug("Failed to log debug message", zap.Error(err))
+			}
 
 			logger.Warn("Server connection failed", zap.String("username", session.Username()), zap.String("match_id", msg.SessionUUID().String()), zap.String("xp_id", xpID.String()), zap.Any("remote_log_message", msg))
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -106,9 +107,21 @@ func InitializeEvrRuntimeModule(ctx context.Context, logger runtime.Logger, db *
 			return fmt.Errorf("unable to create core groups: %w", err)
 		}
 	}
+	broadcasterJoinTimeoutSecs := BroadcasterJoinTimeoutSecs
+	maxRoundsByMode := make(map[evr.Symbol]int)
+	if goNk, ok := nk.(*RuntimeGoNakamaModule); ok && goNk.config != nil {
+		broadcasterJoinTimeoutSecs = goNk.config.GetMatch().BroadcasterJoinTimeoutSec
+		for mode, rounds := range goNk.config.GetMatch().MaxRoundsByMode {
+			maxRoundsByMode[evr.ToSymbol(mode)] = rounds
+		}
+	}
+
 	// Register the "matchmaking" handler
 	if err := initializer.RegisterMatch(EvrMatchmakerModule, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) (runtime.Match, error) {
-		return &EvrMatch{}, nil
+		return &EvrMatch{
+			broadcasterJoinTimeoutSecs: broadcasterJoinTimeoutSecs,
+			maxRoundsByMode:            maxRoundsByMode,
+		}, nil
 	}); err != nil {
 		return err
 	}
@@ -612,6 +625,20 @@ func MatchLabelByID(ctx context.Context, nk runtime.NakamaModule, matchID MatchI
 	return &label, nil
 }
 
+// MatchStatsByID returns the statistics of the in-progress match.
+func MatchStatsByID(ctx context.Context, nk runtime.NakamaModule, matchID MatchID) (*MatchLiveStats, error) {
+	payload, err := SignalMatch(ctx, nk, matchID, SignalGetStats, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := MatchLiveStats{}
+	if err := json.Unmarshal([]byte(payload), &stats); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal match stats: %w", err)
+	}
+	return &stats, nil
+}
+
 func PartyMemberList(ctx context.Context, nk runtime.NakamaModule, partyID uuid.UUID) ([]runtime.Presence, error) {
 	node, ok := ctx.Value(runtime.RUNTIME_CTX_NODE).(string)
 	if !ok {
@@ -942,7 +969,42 @@ func RuntimeLoggerToZapLogger(logger runtime.Logger) *zap.Logger {
 	return logger.(*RuntimeGoLogger).logger
 }
 
+var (
+	ErrNextMatchUnavailable = errors.New("the match is no longer available")
+	ErrNextMatchFull        = errors.New("the match has no open slots for that role")
+)
+
+// ValidateNextMatch returns the match's label if the match still exists and has an open slot for the role.
+func ValidateNextMatch(ctx context.Context, nk runtime.NakamaModule, matchID MatchID, role TeamIndex) (*MatchLabel, error) {
+	label, err := MatchLabelByID(ctx, nk, matchID)
+	if err != nil || label == nil {
+		return nil, ErrNextMatchUnavailable
+	}
+
+	var open int
+	switch role {
+	case BlueTeam, OrangeTeam, Spectator:
+		if open, err = label.OpenSlotsByRole(int(role)); err != nil {
+			return nil, ErrNextMatchUnavailable
+		}
+	case Moderator:
+		open = label.OpenSlots()
+	default:
+		open = label.OpenPlayerSlots()
+	}
+	if open <= 0 || label.OpenSlots() <= 0 {
+		return nil, ErrNextMatchFull
+	}
+	return label, nil
+}
+
+// SetNextMatchID sets the match the player joins the next time they look for a match. The match must still
+// exist, and have an open slot for the role.
 func SetNextMatchID(ctx context.Context, nk runtime.NakamaModule, userID string, matchID MatchID, role TeamIndex, hostDiscordID string) error {
+	if _, err := ValidateNextMatch(ctx, nk, matchID, role); err != nil {
+		return err
+	}
+
 	settings, err := LoadMatchmakingSettings(ctx, nk, userID)
 	if err != nil {
 		return fmt.Errorf("Error loading matchmaking settings: %w", err)
@@ -958,3 +1020,34 @@ func SetNextMatchID(ctx context.Context, nk runtime.NakamaModule, userID string,
 
 	return nil
 }
+
+// RetryWithBackoff calls fn until it succeeds, returns a permanent error, or the attempts are exhausted.
+// The delay between attempts doubles from the initial backoff.
+func RetryWithBackoff(ctx context.Context, attempts int, backoff time.Duration, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err = fn(); err == nil || !isTransientError(err) {
+			return err
+		}
+
+		if attempt == attempts-1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff << attempt):
+		}
+	}
+	return err
+}
+
+// isTransientError returns false for errors that will not succeed on retry (e.g. the device is linked to another account).
+func isTransientError(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied, codes.FailedPrecondition, codes.Unauthenticated:
+		return false
+	}
+	return true
+}
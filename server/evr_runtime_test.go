@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryWithBackoff(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("recovers from transient errors", func(t *testing.T) {
+		calls := 0
+		err := RetryWithBackoff(ctx, 3, time.Millisecond, func() error {
+			calls++
+			if calls < 3 {
+				return errors.New("transient")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("expected 3 calls, got %d", calls)
+		}
+	})
+
+	t.Run("stops on permanent errors", func(t *testing.T) {
+		calls := 0
+		err := RetryWithBackoff(ctx, 3, time.Millisecond, func() error {
+			calls++
+			return status.Error(codes.AlreadyExists, "device already in use")
+		})
+		if status.Code(err) != codes.AlreadyExists {
+			t.Fatalf("expected AlreadyExists, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 call, got %d", calls)
+		}
+	})
+
+	t.Run("returns the last error after the attempts are exhausted", func(t *testing.T) {
+		calls := 0
+		err := RetryWithBackoff(ctx, 3, time.Millisecond, func() error {
+			calls++
+			return errors.New("transient")
+		})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if calls != 3 {
+			t.Errorf("expected 3 calls, got %d", calls)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama/v3/server/evr"
+)
+
+const (
+	ServerRegionOverrideStorageCollection = "GameServers"
+	ServerRegionOverrideStorageKey        = "region_overrides"
+	ServerRegionOverrideMaxRegions        = 8 // The most regions an override may set for a server
+)
+
+// ServerRegionOverrides are the regions operators have set for game servers, by server ID.
+// An override replaces the regions the server advertises when it registers.
+type ServerRegionOverrides struct {
+	Regions map[string][]evr.Symbol `json:"regions"`
+}
+
+// Get returns the override for the server, if one is set.
+func (o *ServerRegionOverrides) Get(serverID uint64) ([]evr.Symbol, bool) {
+	regions, ok := o.Regions[strconv.FormatUint(serverID, 10)]
+	return regions, ok && len(regions) > 0
+}
+
+// Set replaces the override for the server. An empty list of regions clears it.
+func (o *ServerRegionOverrides) Set(serverID uint64, regions []evr.Symbol) {
+	key := strconv.FormatUint(serverID, 10)
+	if len(regions) == 0 {
+		delete(o.Regions, key)
+		return
+	}
+	regions = slices.Clone(regions)
+	slices.Sort(regions)
+	o.Regions[key] = slices.Compact(regions)
+}
+
+func LoadServerRegionOverrides(ctx context.Context, nk runtime.NakamaModule) (*ServerRegionOverrides, error) {
+	objs, err := nk.StorageRead(ctx, []*runtime.StorageRead{
+		{
+			Collection: ServerRegionOverrideStorageCollection,
+			Key:        ServerRegionOverrideStorageKey,
+			UserID:     SystemUserID,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read server region overrides: %w", err)
+	}
+
+	overrides := &ServerRegionOverrides{}
+	if len(objs) > 0 {
+		if err := json.Unmarshal([]byte(objs[0].Value), overrides); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal server region overrides: %w", err)
+		}
+	}
+	if overrides.Regions == nil {
+		overrides.Regions = make(map[string][]evr.Symbol)
+	}
+	return overrides, nil
+}
+
+func StoreServerRegionOverrides(ctx context.Context, nk runtime.NakamaModule, overrides *ServerRegionOverrides) error {
+	data, err := json.Marshal(overrides)
+	if err != nil {
+		return fmt.Errorf("failed to marshal server region overrides: %w", err)
+	}
+
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{
+		{
+			Collection:      ServerRegionOverrideStorageCollection,
+			Key:             ServerRegionOverrideStorageKey,
+			UserID:          SystemUserID,
+			PermissionRead:  0,
+			PermissionWrite: 0,
+			Value:           string(data),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to write server region overrides: %w", err)
+	}
+	return nil
+}
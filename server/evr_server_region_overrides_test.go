@@ -0,0 +1,30 @@
+package server
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/heroiclabs/nakama/v3/server/evr"
+)
+
+func TestServerRegionOverrides_Set(t *testing.T) {
+	overrides := &ServerRegionOverrides{Regions: make(map[string][]evr.Symbol)}
+
+	if _, ok := overrides.Get(42); ok {
+		t.Fatalf("expected no override for an unknown server")
+	}
+
+	overrides.Set(42, []evr.Symbol{evr.ToSymbol("uswest"), evr.ToSymbol("useast"), evr.ToSymbol("uswest")})
+	got, ok := overrides.Get(42)
+	if !ok {
+		t.Fatalf("expected an override to be set")
+	}
+	if len(got) != 2 || !slices.Contains(got, evr.ToSymbol("uswest")) || !slices.Contains(got, evr.ToSymbol("useast")) {
+		t.Errorf("expected the duplicate region to be removed, got %v", got)
+	}
+
+	overrides.Set(42, nil)
+	if _, ok := overrides.Get(42); ok {
+		t.Errorf("expected the override to be cleared")
+	}
+}
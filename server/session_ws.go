@@ -127,6 +127,7 @@ type SessionParameters struct {
 	LoginHistory            *atomic.Pointer[LoginHistory] // The login history
 	AuthDiscordID           string                        // The Discord ID use for authentication
 	AuthPassword            string                        // The Password use for authentication
+	AuthLoginCode           string                        // The one-time login code (from /login-link) use for authentication
 	UserDisplayNameOverride string                        // The display name override (user-defined)
 
 	ExternalServerAddr string // The external server address (IP:port)
@@ -135,6 +136,7 @@ type SessionParameters struct {
 
 	SupportedFeatures []string     // features from the urlparam
 	RequiredFeatures  []string     // required_features from the urlparam
+	PreferredFeatures []string     // preferred features from the urlparam
 	DisableEncryption bool         // The user has disabled encryption
 	DisableMAC        bool         // The user has disabled MAC
 	IsVR              *atomic.Bool // The user is using a VR headset
@@ -232,6 +234,7 @@ func NewSessionWS(logger *zap.Logger, config Config, format SessionFormat, sessi
 		Node:                    pipeline.node,
 		AuthDiscordID:           parseUserQueryFunc(&request, "discordid", 20, discordIDPattern),
 		AuthPassword:            parseUserQueryFunc(&request, "password", 32, nil),
+		AuthLoginCode:           parseUserQueryFunc(&request, "logincode", LoginCodeLength, nil),
 		UserDisplayNameOverride: ign,
 		LoginHistory:            atomic.NewPointer((*LoginHistory)(nil)),
 
@@ -246,6 +249,7 @@ func NewSessionWS(logger *zap.Logger, config Config, format SessionFormat, sessi
 		IsPCVR:               atomic.NewBool(false),
 		SupportedFeatures:    parseUserQueryCommaDelimited(&request, "features", 32, featurePattern),
 		RequiredFeatures:     parseUserQueryCommaDelimited(&request, "requires", 32, featurePattern),
+		PreferredFeatures:    parseUserQueryCommaDelimited(&request, "prefers", 32, featurePattern),
 		ServerTags:           parseUserQueryCommaDelimited(&request, "tags", 32, tagsPattern),
 		ServerGuilds:         parseUserQueryCommaDelimited(&request, "guilds", 32, guildPattern),
 		ServerRegions:        parseUserQueryCommaDelimited(&request, "regions", 32, regionPattern),
@@ -264,7 +268,7 @@ func NewSessionWS(logger *zap.Logger, config Config, format SessionFormat, sessi
 
 	ctx = context.WithValue(ctx, ctxSessionParametersKey{}, atomic.NewPointer(&params))
 
-	for _, f := range params.RequiredFeatures {
+	for _, f := range slices.Concat(params.RequiredFeatures, params.PreferredFeatures) {
 		if !slices.Contains(params.SupportedFeatures, f) {
 			params.SupportedFeatures = append(params.SupportedFeatures, f)
 		}